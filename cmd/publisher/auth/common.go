@@ -10,6 +10,8 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/modelcontextprotocol/registry/pkg/domainkey"
 )
 
 // CryptoProvider provides common functionality for DNS and HTTP authentication
@@ -31,17 +33,11 @@ func (c *CryptoProvider) GetToken(ctx context.Context) (string, error) {
 	}
 
 	// Decode hex seed to private key
-	seedBytes, err := hex.DecodeString(c.hexSeed)
+	privateKey, err := domainkey.PrivateKeyFromSeed(c.hexSeed)
 	if err != nil {
-		return "", fmt.Errorf("invalid hex seed format: %w", err)
-	}
-
-	if len(seedBytes) != ed25519.SeedSize {
-		return "", fmt.Errorf("invalid seed length: expected %d bytes, got %d", ed25519.SeedSize, len(seedBytes))
+		return "", err
 	}
 
-	privateKey := ed25519.NewKeyFromSeed(seedBytes)
-
 	// Generate current timestamp
 	timestamp := time.Now().UTC().Format(time.RFC3339)
 
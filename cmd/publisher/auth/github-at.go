@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"time"
 )
 
@@ -46,6 +47,7 @@ type RegistryTokenResponse struct {
 type StoredRegistryToken struct {
 	Token     string `json:"token"`
 	ExpiresAt int64  `json:"expires_at"`
+	Registry  string `json:"registry,omitempty"`
 }
 
 // GitHubATProvider implements the Provider interface using GitHub's device flow
@@ -53,6 +55,8 @@ type GitHubATProvider struct {
 	clientID    string
 	forceLogin  bool
 	registryURL string
+	// exchangeOnly skips the cached registry token, so GetToken always exchanges the GitHub token
+	exchangeOnly bool
 }
 
 // ServerHealthResponse represents the response from the health endpoint
@@ -69,12 +73,23 @@ func NewGitHubATProvider(forceLogin bool, registryURL string) Provider {
 	}
 }
 
+// NewGitHubATExchangeProvider creates a GitHub OAuth provider that exchanges the saved GitHub token for a
+// new registry token, rather than returning the cached one. It refreshes a registry token about to expire.
+func NewGitHubATExchangeProvider(registryURL string) Provider {
+	return &GitHubATProvider{
+		registryURL:  registryURL,
+		exchangeOnly: true,
+	}
+}
+
 // GetToken retrieves the registry JWT token (exchanges GitHub token if needed)
 func (g *GitHubATProvider) GetToken(ctx context.Context) (string, error) {
-	// Check if we have a valid registry token
-	registryToken, err := readRegistryToken()
-	if err == nil && registryToken != "" {
-		return registryToken, nil
+	// Check if we have a valid registry token, unless logging in again or refreshing it
+	if !g.forceLogin && !g.exchangeOnly {
+		registryToken, err := readRegistryToken(g.registryURL)
+		if err == nil && registryToken != "" {
+			return registryToken, nil
+		}
 	}
 
 	// If no valid registry token, exchange GitHub token for registry token
@@ -90,7 +105,7 @@ func (g *GitHubATProvider) GetToken(ctx context.Context) (string, error) {
 	}
 
 	// Store the registry token
-	err = saveRegistryToken(registryToken, expiresAt)
+	err = saveRegistryToken(registryToken, expiresAt, g.registryURL)
 	if err != nil {
 		return "", fmt.Errorf("failed to save registry token: %w", err)
 	}
@@ -105,13 +120,13 @@ func (g *GitHubATProvider) NeedsLogin() bool {
 	}
 
 	// Check if GitHub token exists
-	_, statErr := os.Stat(gitHubTokenFilePath)
+	_, statErr := os.Stat(tokenFilePath(gitHubTokenFilePath))
 	if os.IsNotExist(statErr) {
 		return true
 	}
 
 	// Check if valid registry token exists
-	_, err := readRegistryToken()
+	_, err := readRegistryToken(g.registryURL)
 	if err != nil {
 		// No valid registry token, but we have GitHub token
 		// We don't need to login, just exchange tokens
@@ -286,12 +301,12 @@ func (g *GitHubATProvider) pollForToken(ctx context.Context, deviceCode string)
 
 // saveToken saves the GitHub access token to a local file
 func saveToken(token string) error {
-	return os.WriteFile(gitHubTokenFilePath, []byte(token), 0600)
+	return os.WriteFile(tokenFilePath(gitHubTokenFilePath), []byte(token), 0600)
 }
 
 // readToken reads the GitHub access token from a local file
 func readToken() (string, error) {
-	tokenData, err := os.ReadFile(gitHubTokenFilePath)
+	tokenData, err := os.ReadFile(tokenFilePath(gitHubTokenFilePath))
 	if err != nil {
 		return "", err
 	}
@@ -387,11 +402,22 @@ func (g *GitHubATProvider) exchangeTokenForRegistry(ctx context.Context, githubT
 	return tokenResp.RegistryToken, tokenResp.ExpiresAt, nil
 }
 
+// tokenFilePath returns where a token file is kept: the home directory, so the saved GitHub token
+// is found whichever directory the publisher runs in
+func tokenFilePath(name string) string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return name
+	}
+	return filepath.Join(homeDir, name)
+}
+
 // saveRegistryToken saves the registry JWT token to a local file with expiration
-func saveRegistryToken(token string, expiresAt int64) error {
+func saveRegistryToken(token string, expiresAt int64, registryURL string) error {
 	storedToken := StoredRegistryToken{
 		Token:     token,
 		ExpiresAt: expiresAt,
+		Registry:  registryURL,
 	}
 
 	data, err := json.Marshal(storedToken)
@@ -399,12 +425,12 @@ func saveRegistryToken(token string, expiresAt int64) error {
 		return fmt.Errorf("failed to marshal token: %w", err)
 	}
 
-	return os.WriteFile(registryTokenFilePath, data, 0600)
+	return os.WriteFile(tokenFilePath(registryTokenFilePath), data, 0600)
 }
 
-// readRegistryToken reads the registry JWT token from a local file
-func readRegistryToken() (string, error) {
-	data, err := os.ReadFile(registryTokenFilePath)
+// readRegistryToken reads the registry JWT token from a local file, if it was issued by registryURL
+func readRegistryToken(registryURL string) (string, error) {
+	data, err := os.ReadFile(tokenFilePath(registryTokenFilePath))
 	if err != nil {
 		return "", err
 	}
@@ -415,10 +441,14 @@ func readRegistryToken() (string, error) {
 		return "", fmt.Errorf("failed to unmarshal token: %w", err)
 	}
 
+	if storedToken.Registry != registryURL {
+		return "", fmt.Errorf("registry token was issued by a different registry")
+	}
+
 	// Check if token has expired
 	if time.Now().Unix() >= storedToken.ExpiresAt {
 		// Token has expired, remove the file
-		os.Remove(registryTokenFilePath)
+		os.Remove(tokenFilePath(registryTokenFilePath))
 		return "", fmt.Errorf("registry token has expired")
 	}
 
@@ -0,0 +1,302 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// OIDCDiscovery is the part of an issuer's OpenID Provider metadata the device flow needs
+type OIDCDiscovery struct {
+	Issuer                      string `json:"issuer"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+	TokenEndpoint               string `json:"token_endpoint"`
+}
+
+// OIDCDeviceCodeResponse represents the response from an issuer's device authorization endpoint (RFC 8628)
+type OIDCDeviceCodeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// OIDCTokenResponse represents the response from an issuer's token endpoint
+type OIDCTokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error,omitempty"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// OIDCProvider implements the Provider interface for any OpenID Connect issuer the registry trusts.
+// The ID token comes from the OAuth device authorization grant, or from an environment variable in CI.
+type OIDCProvider struct {
+	registryURL string
+	issuer      string
+	clientID    string
+	idTokenEnv  string
+	idToken     string
+}
+
+// NewOIDCProvider creates a new generic OIDC provider. When idTokenEnv is set the ID token is read
+// from that environment variable instead of running the device flow.
+func NewOIDCProvider(registryURL, issuer, clientID, idTokenEnv string) Provider {
+	return &OIDCProvider{
+		registryURL: registryURL,
+		issuer:      strings.TrimSuffix(issuer, "/"),
+		clientID:    clientID,
+		idTokenEnv:  idTokenEnv,
+	}
+}
+
+// GetToken exchanges the ID token for a registry JWT token
+func (o *OIDCProvider) GetToken(ctx context.Context) (string, error) {
+	idToken := o.idToken
+	if o.idTokenEnv != "" {
+		idToken = os.Getenv(o.idTokenEnv)
+		if idToken == "" {
+			return "", fmt.Errorf("%s environment variable is empty - it should hold an ID token from %s", o.idTokenEnv, o.issuer)
+		}
+	}
+	if idToken == "" {
+		return "", fmt.Errorf("no ID token - login is required")
+	}
+
+	registryToken, err := o.exchangeIDTokenForRegistry(ctx, idToken)
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange OIDC token: %w", err)
+	}
+	return registryToken, nil
+}
+
+// NeedsLogin returns true unless the ID token is read from the environment, as ID tokens from
+// the device flow are not saved
+func (o *OIDCProvider) NeedsLogin() bool {
+	return o.idTokenEnv == "" && o.idToken == ""
+}
+
+// Login runs the OAuth device authorization grant against the issuer, unless the ID token is read
+// from the environment
+func (o *OIDCProvider) Login(ctx context.Context) error {
+	if o.idTokenEnv != "" {
+		return nil
+	}
+	if o.issuer == "" || o.clientID == "" {
+		return fmt.Errorf("issuer and client ID are required for the device flow")
+	}
+
+	discovery, err := o.discover(ctx)
+	if err != nil {
+		return fmt.Errorf("error discovering OIDC endpoints: %w", err)
+	}
+	if discovery.DeviceAuthorizationEndpoint == "" {
+		return fmt.Errorf("issuer %s does not support the device authorization grant", o.issuer)
+	}
+
+	deviceCode, err := o.requestDeviceCode(ctx, discovery.DeviceAuthorizationEndpoint)
+	if err != nil {
+		return fmt.Errorf("error requesting device code: %w", err)
+	}
+
+	// Display instructions to the user
+	_, _ = fmt.Fprintln(os.Stdout, "\nTo authenticate, please:")
+	if deviceCode.VerificationURIComplete != "" {
+		_, _ = fmt.Fprintln(os.Stdout, "1. Go to:", deviceCode.VerificationURIComplete)
+		_, _ = fmt.Fprintln(os.Stdout, "2. Check the code is:", deviceCode.UserCode)
+	} else {
+		_, _ = fmt.Fprintln(os.Stdout, "1. Go to:", deviceCode.VerificationURI)
+		_, _ = fmt.Fprintln(os.Stdout, "2. Enter code:", deviceCode.UserCode)
+	}
+	_, _ = fmt.Fprintln(os.Stdout, "3. Authorize this application")
+
+	_, _ = fmt.Fprintln(os.Stdout, "Waiting for authorization...")
+	idToken, err := o.pollForIDToken(ctx, discovery.TokenEndpoint, deviceCode)
+	if err != nil {
+		return fmt.Errorf("error polling for token: %w", err)
+	}
+	o.idToken = idToken
+
+	_, _ = fmt.Fprintln(os.Stdout, "Successfully authenticated!")
+	return nil
+}
+
+// Name returns the name of this auth provider
+func (o *OIDCProvider) Name() string {
+	return "oidc"
+}
+
+// discover fetches the issuer's OpenID Provider metadata
+func (o *OIDCProvider) discover(ctx context.Context) (*OIDCDiscovery, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	body, status, err := doOIDCRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("discovery failed with status %d: %s", status, body)
+	}
+
+	var discovery OIDCDiscovery
+	if err := json.Unmarshal(body, &discovery); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal discovery document: %w", err)
+	}
+	if discovery.TokenEndpoint == "" {
+		return nil, fmt.Errorf("discovery document has no token_endpoint")
+	}
+	return &discovery, nil
+}
+
+// requestDeviceCode initiates the device authorization flow
+func (o *OIDCProvider) requestDeviceCode(ctx context.Context, endpoint string) (*OIDCDeviceCodeResponse, error) {
+	form := url.Values{
+		"client_id": {o.clientID},
+		"scope":     {"openid"},
+	}
+	body, status, err := postOIDCForm(ctx, endpoint, form)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("request device code failed with status %d: %s", status, body)
+	}
+
+	var deviceCode OIDCDeviceCodeResponse
+	if err := json.Unmarshal(body, &deviceCode); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal device code response: %w", err)
+	}
+	if deviceCode.DeviceCode == "" {
+		return nil, fmt.Errorf("device code response has no device_code")
+	}
+	return &deviceCode, nil
+}
+
+// pollForIDToken polls the token endpoint until the user completes authorization
+func (o *OIDCProvider) pollForIDToken(ctx context.Context, endpoint string, deviceCode *OIDCDeviceCodeResponse) (string, error) {
+	form := url.Values{
+		"client_id":   {o.clientID},
+		"device_code": {deviceCode.DeviceCode},
+		"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+	}
+
+	// Defaults from RFC 8628 when the issuer does not say
+	interval := 5 * time.Second
+	if deviceCode.Interval > 0 {
+		interval = time.Duration(deviceCode.Interval) * time.Second
+	}
+	expiresIn := 15 * time.Minute
+	if deviceCode.ExpiresIn > 0 {
+		expiresIn = time.Duration(deviceCode.ExpiresIn) * time.Second
+	}
+	deadline := time.Now().Add(expiresIn)
+
+	for time.Now().Before(deadline) {
+		body, _, err := postOIDCForm(ctx, endpoint, form)
+		if err != nil {
+			return "", err
+		}
+
+		var tokenResp OIDCTokenResponse
+		if err := json.Unmarshal(body, &tokenResp); err != nil {
+			return "", fmt.Errorf("failed to unmarshal token response: %w", err)
+		}
+
+		switch tokenResp.Error {
+		case "":
+			if tokenResp.IDToken == "" {
+				return "", fmt.Errorf("token response has no id_token - does the client request the openid scope?")
+			}
+			return tokenResp.IDToken, nil
+		case "authorization_pending":
+			// User hasn't authorized yet, wait and retry
+		case "slow_down":
+			interval += 5 * time.Second
+		default:
+			if tokenResp.ErrorDescription != "" {
+				return "", fmt.Errorf("token request failed: %s: %s", tokenResp.Error, tokenResp.ErrorDescription)
+			}
+			return "", fmt.Errorf("token request failed: %s", tokenResp.Error)
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+
+	return "", fmt.Errorf("device code authorization timed out")
+}
+
+// exchangeIDTokenForRegistry exchanges an OIDC ID token for a registry JWT token
+func (o *OIDCProvider) exchangeIDTokenForRegistry(ctx context.Context, idToken string) (string, error) {
+	if o.registryURL == "" {
+		return "", fmt.Errorf("registry URL is required for token exchange")
+	}
+
+	jsonData, err := json.Marshal(map[string]string{"oidc_token": idToken})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	exchangeURL := strings.TrimSuffix(o.registryURL, "/") + "/v0/auth/oidc"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, exchangeURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	body, status, err := doOIDCRequest(req)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("token exchange failed with status %d: %s", status, body)
+	}
+
+	var tokenResp RegistryTokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return tokenResp.RegistryToken, nil
+}
+
+// postOIDCForm posts a form to an issuer endpoint, returning the response body and status
+func postOIDCForm(ctx context.Context, endpoint string, form url.Values) ([]byte, int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	return doOIDCRequest(req)
+}
+
+func doOIDCRequest(req *http.Request) ([]byte, int, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read response: %w", err)
+	}
+	return body, resp.StatusCode, nil
+}
@@ -0,0 +1,358 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// clientConfig holds the server entries of an MCP client configuration file
+type clientConfig struct {
+	// Servers is used by VS Code mcp.json
+	Servers map[string]clientServer `json:"servers"`
+	// MCPServers is used by Claude Desktop, Cursor and others
+	MCPServers map[string]clientServer `json:"mcpServers"`
+	// MCP is used by VS Code settings.json
+	MCP *struct {
+		Servers map[string]clientServer `json:"servers"`
+		Inputs  []clientInput           `json:"inputs"`
+	} `json:"mcp"`
+	Inputs []clientInput `json:"inputs"`
+}
+
+type clientServer struct {
+	Type    string            `json:"type"`
+	Command string            `json:"command"`
+	Args    []string          `json:"args"`
+	Env     map[string]string `json:"env"`
+	URL     string            `json:"url"`
+}
+
+// clientInput is a VS Code input, which the client prompts for when a server references it
+type clientInput struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Password    bool   `json:"password"`
+}
+
+// inputReference matches a VS Code reference to an input, such as ${input:api-key}
+var inputReference = regexp.MustCompile(`^\$\{input:([^}]+)\}$`)
+
+// importClientConfig reads a server entry from a client configuration file and maps its command
+// back to the package it runs. The entry may be omitted when the file has only one server.
+func importClientConfig(configFile, entry string) (*projectInfo, error) {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client config: %w", err)
+	}
+	var config clientConfig
+	if err := json.Unmarshal(stripJSONComments(data), &config); err != nil {
+		return nil, fmt.Errorf("failed to parse client config %s: %w", configFile, err)
+	}
+
+	servers := config.MCPServers
+	if servers == nil {
+		servers = config.Servers
+	}
+	inputs := config.Inputs
+	if servers == nil && config.MCP != nil {
+		servers = config.MCP.Servers
+		inputs = config.MCP.Inputs
+	}
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("no MCP servers found in %s", configFile)
+	}
+
+	names := make([]string, 0, len(servers))
+	for name := range servers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	if entry == "" {
+		if len(names) > 1 {
+			return nil, fmt.Errorf("%s has several servers. Choose one with --entry: %s", configFile, strings.Join(names, ", "))
+		}
+		entry = names[0]
+	}
+	server, ok := servers[entry]
+	if !ok {
+		return nil, fmt.Errorf("no server named %q in %s. Servers: %s", entry, configFile, strings.Join(names, ", "))
+	}
+	if server.Command == "" {
+		return nil, fmt.Errorf("server %q has no command. Only servers run from a package can be imported", entry)
+	}
+
+	pkg, version, err := packageFromCommand(server.Command, server.Args)
+	if err != nil {
+		return nil, fmt.Errorf("server %q: %w", entry, err)
+	}
+
+	inputsByID := make(map[string]clientInput, len(inputs))
+	for _, input := range inputs {
+		inputsByID[input.ID] = input
+	}
+	for _, arguments := range [][]model.Argument{pkg.RuntimeArguments, pkg.PackageArguments} {
+		for i := range arguments {
+			if id, ok := resolveInputReference(&arguments[i].InputWithVariables, arguments[i].Value, inputsByID); ok && arguments[i].Type == model.ArgumentTypePositional {
+				arguments[i].ValueHint = id
+			}
+		}
+	}
+
+	// Variables forwarded with docker -e are already listed, in the order they are forwarded
+	envNames := make([]string, 0, len(server.Env))
+	for name := range server.Env {
+		envNames = append(envNames, name)
+	}
+	slices.Sort(envNames)
+	for _, name := range envNames {
+		envVar := exampleEnvVar(name, server.Env[name], "")
+		resolveInputReference(&envVar.InputWithVariables, server.Env[name], inputsByID)
+		if i := slices.IndexFunc(pkg.EnvironmentVariables, func(listed model.KeyValueInput) bool { return listed.Name == name }); i >= 0 {
+			pkg.EnvironmentVariables[i] = envVar
+		} else {
+			pkg.EnvironmentVariables = append(pkg.EnvironmentVariables, envVar)
+		}
+	}
+
+	return &projectInfo{
+		source:  fmt.Sprintf("server %q in %s", entry, filepath.Base(configFile)),
+		name:    pkg.Identifier,
+		version: version,
+		pkg:     pkg,
+	}, nil
+}
+
+// resolveInputReference replaces a value that references a VS Code input with one the client
+// prompts for, described the way the input is. It returns the ID of the input referenced.
+func resolveInputReference(input *model.InputWithVariables, value string, inputs map[string]clientInput) (string, bool) {
+	match := inputReference.FindStringSubmatch(value)
+	if match == nil {
+		return "", false
+	}
+
+	referenced := inputs[match[1]]
+	input.Default = ""
+	input.Value = ""
+	input.IsRequired = true
+	input.IsSecret = input.IsSecret || referenced.Password
+	if referenced.Description != "" {
+		input.Description = referenced.Description
+	}
+	return match[1], true
+}
+
+// runtime describes how a package runtime's command line is laid out
+type runtime struct {
+	registryType string
+	// valueFlags are the runtime flags that take a value
+	valueFlags []string
+	// implicitFlags are added by clients themselves, so they are left out of the runtime arguments
+	implicitFlags []string
+	// splitVersion splits a package spec into its identifier and version
+	splitVersion func(spec string) (string, string)
+}
+
+var runtimes = map[string]runtime{
+	"npx": {
+		registryType:  model.RegistryTypeNPM,
+		valueFlags:    []string{"--registry", "--cache", "--userconfig"},
+		implicitFlags: []string{"-y", "--yes"},
+		splitVersion:  splitAtVersion,
+	},
+	"uvx": {
+		registryType: model.RegistryTypePyPI,
+		valueFlags:   []string{"--with", "--python", "-p", "--index", "--index-url", "--extra-index-url", "--default-index"},
+		splitVersion: func(spec string) (string, string) {
+			if identifier, version, ok := strings.Cut(spec, "=="); ok {
+				return identifier, version
+			}
+			return splitAtVersion(spec)
+		},
+	},
+	"dnx": {
+		registryType:  model.RegistryTypeNuGet,
+		valueFlags:    []string{"--version", "--source", "--add-source", "--configfile"},
+		implicitFlags: []string{"-y", "--yes"},
+		splitVersion:  splitAtVersion,
+	},
+	"docker": {
+		registryType: model.RegistryTypeOCI,
+		valueFlags: []string{
+			"-e", "--env", "--env-file", "-v", "--volume", "--mount", "-p", "--publish", "--name", "--network",
+			"-w", "--workdir", "-u", "--user", "--platform", "--entrypoint", "-l", "--label", "--add-host", "-m", "--memory", "--cpus",
+		},
+		implicitFlags: []string{"-i", "--interactive", "--rm"},
+		// The version of an OCI package is the tag, which stays in its identifier
+		splitVersion: func(spec string) (string, string) {
+			image := spec[strings.LastIndex(spec, "/")+1:]
+			if _, tag, ok := strings.Cut(image, ":"); ok && !strings.Contains(image, "@") {
+				return spec, tag
+			}
+			return spec, ""
+		},
+	},
+}
+
+// packageFromCommand maps the command a client runs back to the package it runs, returning the
+// package version when the command pins one
+func packageFromCommand(command string, args []string) (model.Package, string, error) {
+	name := strings.ToLower(path.Base(strings.ReplaceAll(command, `\`, "/")))
+	name = strings.TrimSuffix(name, path.Ext(name))
+	// Windows configurations often wrap the command in cmd /c
+	if name == "cmd" && len(args) > 1 && strings.EqualFold(args[0], "/c") {
+		return packageFromCommand(args[1], args[2:])
+	}
+
+	rt, ok := runtimes[name]
+	if !ok {
+		return model.Package{}, "", fmt.Errorf("cannot tell the package type from command %q. Only npx, uvx, dnx and docker commands can be imported", command)
+	}
+	if name == "docker" {
+		if len(args) == 0 || args[0] != "run" {
+			return model.Package{}, "", fmt.Errorf("only 'docker run' commands can be imported")
+		}
+		args = args[1:]
+	}
+
+	// Runtime flags come before the package
+	var runtimeArgs []string
+	i := 0
+	for ; i < len(args) && strings.HasPrefix(args[i], "-"); i++ {
+		runtimeArgs = append(runtimeArgs, args[i])
+		if slices.Contains(rt.valueFlags, args[i]) && i+1 < len(args) {
+			i++
+			runtimeArgs = append(runtimeArgs, args[i])
+		}
+	}
+	if i == len(args) {
+		return model.Package{}, "", fmt.Errorf("no package found in the arguments to %s", name)
+	}
+	identifier, version := rt.splitVersion(args[i])
+	packageArgs := args[i+1:]
+
+	// dnx takes its own flags after the package, until --
+	if separator := slices.Index(packageArgs, "--"); name == "dnx" && separator >= 0 {
+		runtimeArgs = append(runtimeArgs, packageArgs[:separator]...)
+		packageArgs = packageArgs[separator+1:]
+	}
+
+	pkg := model.Package{
+		RegistryType: rt.registryType,
+		Identifier:   identifier,
+		RunTimeHint:  name,
+		Transport:    model.Transport{Type: model.TransportTypeStdio},
+	}
+	pkg.RuntimeArguments = commandArguments(runtimeArgs, rt, &version, &pkg.EnvironmentVariables)
+	pkg.PackageArguments = commandArguments(packageArgs, runtime{}, nil, nil)
+	return pkg, version, nil
+}
+
+// splitAtVersion splits a spec such as @scope/name@1.2.3 at its last @
+func splitAtVersion(spec string) (string, string) {
+	if at := strings.LastIndex(spec, "@"); at > 0 {
+		return spec[:at], spec[at+1:]
+	}
+	return spec, ""
+}
+
+// commandArguments turns command line arguments into named and positional arguments. A flag
+// followed by a value becomes a named argument with that value, which clients pass the same way.
+// The values of flags that look like secrets are dropped, so the client prompts for them instead.
+// Runtime flags are also checked for versions and for environment variables passed to docker.
+func commandArguments(args []string, rt runtime, version *string, envVars *[]model.KeyValueInput) []model.Argument {
+	var arguments []model.Argument
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if slices.Contains(rt.implicitFlags, arg) {
+			continue
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			arguments = append(arguments, model.Argument{
+				Type:               model.ArgumentTypePositional,
+				InputWithVariables: model.InputWithVariables{Input: model.Input{Value: arg}},
+			})
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(arg, "=")
+		if !hasValue && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			value, hasValue = args[i+1], true
+			i++
+		}
+
+		switch {
+		case version != nil && name == "--version":
+			// dnx pins the version with a flag
+			*version = value
+			continue
+		case envVars != nil && (name == "-e" || name == "--env") && hasValue:
+			// docker forwards the variable from the client, which sets it from the environment variables
+			envName, envValue, _ := strings.Cut(value, "=")
+			*envVars = append(*envVars, exampleEnvVar(envName, envValue, ""))
+			value = envName
+		}
+
+		argument := model.Argument{Type: model.ArgumentTypeNamed, Name: name}
+		argument.Value = value
+		if secretEnvName.MatchString(strings.ToUpper(strings.TrimLeft(name, "-"))) {
+			argument.IsSecret = true
+			if hasValue && !inputReference.MatchString(value) {
+				argument.Value = ""
+				argument.IsRequired = true
+			}
+		}
+		arguments = append(arguments, argument)
+	}
+	return arguments
+}
+
+// stripJSONComments removes the comments and trailing commas that VS Code allows in its JSON files
+func stripJSONComments(data []byte) []byte {
+	var out []byte
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inString:
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			i--
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := strings.Index(string(data[i+2:]), "*/")
+			if end < 0 {
+				return out
+			}
+			i += end + 3
+		case c == ']' || c == '}':
+			// Drop a comma before the closing bracket
+			trimmed := strings.TrimRight(string(out), " \t\r\n")
+			if strings.HasSuffix(trimmed, ",") {
+				out = append([]byte(strings.TrimSuffix(trimmed, ",")), out[len(trimmed):]...)
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/auth"
+)
+
+// tokenRefreshMargin is how long before it expires a saved token is refreshed, so it does not
+// expire while a request is in flight
+const tokenRefreshMargin = 30 * time.Second
+
+// credentials are the saved token of a profile, refreshed through the login method recorded in
+// the profile when the registry token has expired
+type credentials struct {
+	config  *publisherConfig
+	name    string
+	profile *Profile
+}
+
+// loadCredentials returns the credentials of the profile selected as described on profileName
+func loadCredentials(profileFlag string) (*credentials, error) {
+	config, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	name := config.profileName(profileFlag)
+	profile, ok := config.Profiles[name]
+	if !ok || profile.Token == "" {
+		if name != DefaultProfile {
+			return nil, fmt.Errorf("%w for profile %q", errNotAuthenticated, name)
+		}
+		return nil, errNotAuthenticated
+	}
+	return &credentials{config: config, name: name, profile: profile}, nil
+}
+
+// registryURL returns the registry the token was issued by
+func (c *credentials) registryURL() string {
+	return c.profile.registryURL()
+}
+
+// token returns the saved token, refreshing it first if it is about to expire
+func (c *credentials) token(ctx context.Context) (string, error) {
+	expiresAt := c.profile.ExpiresAt
+	if !expiresAt.IsZero() && time.Until(expiresAt) < tokenRefreshMargin {
+		if err := c.refresh(ctx); err != nil {
+			return "", err
+		}
+	}
+	return c.profile.Token, nil
+}
+
+// refresh exchanges the credentials behind the login for a new registry token and saves it.
+// It never prompts: a login that needs the user again returns an error asking for it.
+func (c *credentials) refresh(ctx context.Context) error {
+	loginAgain := fmt.Sprintf("run 'mcp-publisher login %s", c.profile.Method)
+	if c.name != DefaultProfile {
+		loginAgain += " --profile " + c.name
+	}
+	loginAgain += "' again"
+
+	if c.profile.Method == "" {
+		return fmt.Errorf("your registry token has expired, %s", loginAgain)
+	}
+	provider, err := newAuthProvider(c.profile, os.Getenv(PrivateKeyEnvVar), false)
+	if err != nil {
+		return fmt.Errorf("your registry token has expired and cannot be refreshed (%w). Set %s or %s", err, PrivateKeyEnvVar, loginAgain)
+	}
+	if c.profile.Method == "github" {
+		// The GitHub provider would return its cached registry token, which expires with the saved one
+		provider = auth.NewGitHubATExchangeProvider(c.registryURL())
+	}
+	if provider.NeedsLogin() {
+		return fmt.Errorf("your registry token has expired, %s", loginAgain)
+	}
+
+	token, err := provider.GetToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to refresh registry token, %s: %w", loginAgain, err)
+	}
+
+	c.profile.Token = token
+	c.profile.ExpiresAt = tokenExpiry(token)
+	return c.config.save()
+}
+
+// withToken calls send with the token, refreshing the token and retrying once if the registry
+// rejects it with 401 Unauthorized
+func (c *credentials) withToken(ctx context.Context, send func(token string) error) error {
+	token, err := c.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = send(token)
+	var respErr *responseError
+	if !errors.As(err, &respErr) || respErr.StatusCode != http.StatusUnauthorized {
+		return err
+	}
+
+	if err := c.refresh(ctx); err != nil {
+		return err
+	}
+	return send(c.profile.Token)
+}
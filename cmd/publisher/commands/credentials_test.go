@@ -0,0 +1,131 @@
+package commands_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// testJWT returns an unsigned JWT with a subject and an expiry, which is all the publisher reads
+func testJWT(subject string, expiresAt time.Time) string {
+	encode := func(value string) string { return base64.RawURLEncoding.EncodeToString([]byte(value)) }
+	return encode(`{"alg":"none"}`) + "." + encode(fmt.Sprintf(`{"sub":%q,"exp":%d}`, subject, expiresAt.Unix())) + ".signature"
+}
+
+func TestPublishCommand_RefreshesToken(t *testing.T) {
+	freshToken := testJWT("fresh", time.Now().Add(5*time.Minute))
+	var publishAuthorizations []string
+	tokensIssued := 0
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v0/auth/none", "/v0/auth/github-at":
+			tokensIssued++
+			_ = json.NewEncoder(w).Encode(map[string]any{"registry_token": freshToken, "expires_at": 0})
+		case "/v0/publish":
+			publishAuthorizations = append(publishAuthorizations, r.Header.Get("Authorization"))
+			if r.Header.Get("Authorization") != "Bearer "+freshToken {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"title":"Unauthorized","status":401,"detail":"Invalid or expired Registry JWT token"}`))
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(apiv0.ServerResponse{Server: apiv0.ServerJSON{Name: "com.example/test-server", Version: "1.0.0"}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer registry.Close()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(commands.ProfileEnvVar, "")
+	writeProfile := func(profile commands.Profile) {
+		t.Helper()
+		data, err := json.Marshal(map[string]any{"profiles": map[string]commands.Profile{"default": profile}})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(home, commands.ConfigFileName), data, 0600))
+	}
+	savedToken := func() string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(home, commands.ConfigFileName))
+		require.NoError(t, err)
+		var config struct {
+			Profiles map[string]commands.Profile `json:"profiles"`
+		}
+		require.NoError(t, json.Unmarshal(data, &config))
+		return config.Profiles["default"].Token
+	}
+
+	serverData, err := json.Marshal(apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/test-server",
+		Description: "A test server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+	serverFile := filepath.Join(home, "server.json")
+	require.NoError(t, os.WriteFile(serverFile, serverData, 0600))
+
+	t.Run("expired token is refreshed before publishing", func(t *testing.T) {
+		expired := time.Now().Add(-time.Minute)
+		writeProfile(commands.Profile{Registry: registry.URL, Method: "none", Token: testJWT("old", expired), ExpiresAt: expired})
+		publishAuthorizations, tokensIssued = nil, 0
+
+		captureStdout(t, func() { require.NoError(t, commands.PublishCommand([]string{serverFile})) })
+		assert.Equal(t, 1, tokensIssued)
+		assert.Equal(t, []string{"Bearer " + freshToken}, publishAuthorizations)
+		assert.Equal(t, freshToken, savedToken())
+	})
+
+	t.Run("github token about to expire is exchanged again", func(t *testing.T) {
+		// The cached registry token of the GitHub login expires with the saved token, so must not be reused
+		expiring := time.Now().Add(10 * time.Second)
+		expiringToken := testJWT("old", expiring)
+		cached, err := json.Marshal(map[string]any{"token": expiringToken, "expires_at": expiring.Unix(), "registry": registry.URL})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(home, ".mcpregistry_registry_token"), cached, 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(home, ".mcpregistry_github_token"), []byte("github-token"), 0600))
+		writeProfile(commands.Profile{Registry: registry.URL, Method: "github", Token: expiringToken, ExpiresAt: expiring})
+		publishAuthorizations, tokensIssued = nil, 0
+
+		captureStdout(t, func() { require.NoError(t, commands.PublishCommand([]string{serverFile})) })
+		assert.Equal(t, 1, tokensIssued)
+		assert.Equal(t, []string{"Bearer " + freshToken}, publishAuthorizations)
+		assert.Equal(t, freshToken, savedToken())
+	})
+
+	t.Run("rejected token is refreshed and the publish retried once", func(t *testing.T) {
+		writeProfile(commands.Profile{Registry: registry.URL, Method: "none", Token: "revoked-token"})
+		publishAuthorizations, tokensIssued = nil, 0
+
+		captureStdout(t, func() { require.NoError(t, commands.PublishCommand([]string{serverFile})) })
+		assert.Equal(t, 1, tokensIssued)
+		assert.Equal(t, []string{"Bearer revoked-token", "Bearer " + freshToken}, publishAuthorizations)
+		assert.Equal(t, freshToken, savedToken())
+	})
+
+	t.Run("login is needed when the credentials cannot be refreshed", func(t *testing.T) {
+		t.Setenv(commands.PrivateKeyEnvVar, "")
+		writeProfile(commands.Profile{Registry: registry.URL, Method: "dns", Domain: "example.com", Token: "revoked-token"})
+		publishAuthorizations, tokensIssued = nil, 0
+
+		var err error
+		captureStdout(t, func() { err = commands.PublishCommand([]string{serverFile}) })
+		assert.ErrorContains(t, err, "Set "+commands.PrivateKeyEnvVar+" or run 'mcp-publisher login dns' again")
+		assert.Len(t, publishAuthorizations, 1, "the publish is not retried")
+	})
+}
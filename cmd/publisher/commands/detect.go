@@ -0,0 +1,494 @@
+package commands
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// projectInfo is what init detects about the package in a directory
+type projectInfo struct {
+	// source is the file the package was detected from
+	source string
+	// serverName is a server name declared by the project, such as mcpName in package.json
+	serverName  string
+	name        string
+	description string
+	version     string
+	// bundle is the file name of an MCPB bundle built next to its manifest
+	bundle string
+	pkg    model.Package
+}
+
+// projectDetectors are tried in order, so an MCPB manifest.json wins over the package.json next to it
+var projectDetectors = []func(dir string) (*projectInfo, bool){
+	detectMCPB,
+	detectNPM,
+	detectPyPI,
+	detectNuGet,
+	detectOCI,
+}
+
+// detectProject detects the package in dir, defaulting to an npm package when nothing is found
+func detectProject(dir string) *projectInfo {
+	project := &projectInfo{pkg: model.Package{RegistryType: model.RegistryTypeNPM}}
+	for _, detect := range projectDetectors {
+		if detected, ok := detect(dir); ok {
+			project = detected
+			break
+		}
+	}
+
+	if project.serverName == "" {
+		project.serverName = detectReadmeServerName(dir)
+	}
+	if project.pkg.Transport.Type == "" {
+		project.pkg.Transport.Type = model.TransportTypeStdio
+	}
+	if len(project.pkg.EnvironmentVariables) == 0 {
+		project.pkg.EnvironmentVariables = detectEnvExample(dir)
+	}
+	return project
+}
+
+func readJSONFile(path string, v any) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, v) == nil
+}
+
+func detectNPM(dir string) (*projectInfo, bool) {
+	var pkg struct {
+		Name        string `json:"name"`
+		Version     string `json:"version"`
+		Description string `json:"description"`
+		MCPName     string `json:"mcpName"`
+		Bin         any    `json:"bin"`
+	}
+	if !readJSONFile(filepath.Join(dir, "package.json"), &pkg) {
+		return nil, false
+	}
+
+	project := &projectInfo{
+		source:      "package.json",
+		serverName:  pkg.MCPName,
+		name:        pkg.Name,
+		description: pkg.Description,
+		version:     pkg.Version,
+		pkg: model.Package{
+			RegistryType: model.RegistryTypeNPM,
+			Identifier:   pkg.Name,
+		},
+	}
+	// Only packages with a bin entry can be run with npx
+	if pkg.Bin != nil {
+		project.pkg.RunTimeHint = "npx"
+	}
+	return project, true
+}
+
+// pyprojectMetadata holds the package metadata init reads from pyproject.toml
+type pyprojectMetadata struct {
+	Name        string         `toml:"name"`
+	Version     string         `toml:"version"`
+	Description string         `toml:"description"`
+	Scripts     map[string]any `toml:"scripts"`
+}
+
+func detectPyPI(dir string) (*projectInfo, bool) {
+	if data, err := os.ReadFile(filepath.Join(dir, "pyproject.toml")); err == nil {
+		var pyproject struct {
+			Project pyprojectMetadata `toml:"project"`
+			Tool    struct {
+				Poetry pyprojectMetadata `toml:"poetry"`
+			} `toml:"tool"`
+		}
+		if err := toml.Unmarshal(data, &pyproject); err != nil {
+			// An unreadable pyproject.toml still marks a Python project
+			return &projectInfo{source: "pyproject.toml", pkg: model.Package{RegistryType: model.RegistryTypePyPI}}, true
+		}
+
+		// PEP 621 metadata, falling back to Poetry's own table
+		metadata := pyproject.Project
+		if metadata.Name == "" {
+			metadata = pyproject.Tool.Poetry
+		}
+
+		project := &projectInfo{
+			source:      "pyproject.toml",
+			name:        metadata.Name,
+			description: metadata.Description,
+			version:     metadata.Version,
+		}
+		project.pkg = model.Package{
+			RegistryType: model.RegistryTypePyPI,
+			Identifier:   project.name,
+		}
+		// Only packages with console scripts can be run with uvx
+		if len(metadata.Scripts) > 0 {
+			project.pkg.RunTimeHint = "uvx"
+		}
+		return project, true
+	}
+
+	if data, err := os.ReadFile(filepath.Join(dir, "setup.py")); err == nil {
+		project := &projectInfo{
+			source:      "setup.py",
+			name:        setupPyArgument(string(data), "name"),
+			description: setupPyArgument(string(data), "description"),
+			version:     setupPyArgument(string(data), "version"),
+		}
+		project.pkg = model.Package{
+			RegistryType: model.RegistryTypePyPI,
+			Identifier:   project.name,
+		}
+		if strings.Contains(string(data), "console_scripts") {
+			project.pkg.RunTimeHint = "uvx"
+		}
+		return project, true
+	}
+
+	return nil, false
+}
+
+// setupPyArgument finds a string literal keyword argument, such as name="my-server", in setup.py
+func setupPyArgument(setupPy, name string) string {
+	match := regexp.MustCompile(`\b` + name + `\s*=\s*["']([^"']*)["']`).FindStringSubmatch(setupPy)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// csproj holds the .csproj properties init reads. Properties may be split across property groups.
+type csproj struct {
+	PropertyGroups []struct {
+		PackageID      string `xml:"PackageId"`
+		AssemblyName   string `xml:"AssemblyName"`
+		Version        string `xml:"Version"`
+		PackageVersion string `xml:"PackageVersion"`
+		Description    string `xml:"Description"`
+		PackAsTool     string `xml:"PackAsTool"`
+	} `xml:"PropertyGroup"`
+}
+
+func detectNuGet(dir string) (*projectInfo, bool) {
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.csproj"))
+	if len(matches) == 0 {
+		return nil, false
+	}
+	path := matches[0]
+
+	project := &projectInfo{
+		source: filepath.Base(path),
+		pkg:    model.Package{RegistryType: model.RegistryTypeNuGet},
+	}
+
+	var assemblyName, packageVersion string
+	if data, err := os.ReadFile(path); err == nil {
+		var proj csproj
+		if xml.Unmarshal(data, &proj) == nil {
+			for _, group := range proj.PropertyGroups {
+				project.name = firstNonEmpty(group.PackageID, project.name)
+				assemblyName = firstNonEmpty(group.AssemblyName, assemblyName)
+				project.version = firstNonEmpty(group.Version, project.version)
+				packageVersion = firstNonEmpty(group.PackageVersion, packageVersion)
+				project.description = firstNonEmpty(group.Description, project.description)
+				// Only .NET tools can be run with dnx
+				if strings.EqualFold(strings.TrimSpace(group.PackAsTool), "true") {
+					project.pkg.RunTimeHint = "dnx"
+				}
+			}
+		}
+	}
+
+	// NuGet falls back to the assembly name, then the project file name, for the package ID
+	project.name = firstNonEmpty(project.name, assemblyName, strings.TrimSuffix(filepath.Base(path), ".csproj"))
+	project.version = firstNonEmpty(packageVersion, project.version)
+	project.pkg.Identifier = project.name
+	return project, true
+}
+
+// mcpbManifest holds the MCPB manifest.json fields init reads
+type mcpbManifest struct {
+	ManifestVersion string `json:"manifest_version"`
+	DXTVersion      string `json:"dxt_version"`
+	Name            string `json:"name"`
+	Version         string `json:"version"`
+	Description     string `json:"description"`
+	Server          *struct {
+		MCPConfig struct {
+			Env map[string]string `json:"env"`
+		} `json:"mcp_config"`
+	} `json:"server"`
+	UserConfig map[string]struct {
+		Type        string `json:"type"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		Required    bool   `json:"required"`
+		Sensitive   bool   `json:"sensitive"`
+		Default     any    `json:"default"`
+	} `json:"user_config"`
+}
+
+var userConfigReference = regexp.MustCompile(`^\$\{user_config\.([^}]+)\}$`)
+
+func detectMCPB(dir string) (*projectInfo, bool) {
+	var manifest mcpbManifest
+	if !readJSONFile(filepath.Join(dir, "manifest.json"), &manifest) {
+		return nil, false
+	}
+	// Other tools use manifest.json too, so only MCPB manifests are detected
+	if manifest.ManifestVersion == "" && manifest.DXTVersion == "" || manifest.Server == nil {
+		return nil, false
+	}
+
+	project := &projectInfo{
+		source:      "manifest.json",
+		name:        manifest.Name,
+		description: manifest.Description,
+		version:     manifest.Version,
+		pkg:         model.Package{RegistryType: model.RegistryTypeMCPB},
+	}
+
+	// The bundle is built next to the manifest, so its hash and file name can be taken from there
+	if bundles, _ := filepath.Glob(filepath.Join(dir, "*.mcpb")); len(bundles) > 0 {
+		if hash, err := fileSHA256(bundles[0]); err == nil {
+			project.pkg.FileSHA256 = hash
+			project.bundle = filepath.Base(bundles[0])
+		}
+	}
+
+	// Environment variables set from user_config are the ones users provide. The rest are fixed
+	// by the bundle, so they are left out.
+	names := make([]string, 0, len(manifest.Server.MCPConfig.Env))
+	for name := range manifest.Server.MCPConfig.Env {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		match := userConfigReference.FindStringSubmatch(manifest.Server.MCPConfig.Env[name])
+		if match == nil {
+			continue
+		}
+		option, ok := manifest.UserConfig[match[1]]
+		if !ok {
+			continue
+		}
+
+		input := model.Input{
+			Description: firstNonEmpty(option.Description, option.Title),
+			IsRequired:  option.Required,
+			IsSecret:    option.Sensitive,
+			Format:      userConfigFormat(option.Type),
+		}
+		if option.Default != nil {
+			input.Default = fmt.Sprint(option.Default)
+		}
+		project.pkg.EnvironmentVariables = append(project.pkg.EnvironmentVariables, model.KeyValueInput{
+			Name:               name,
+			InputWithVariables: model.InputWithVariables{Input: input},
+		})
+	}
+	return project, true
+}
+
+// userConfigFormat maps an MCPB user_config type to an input format
+func userConfigFormat(configType string) model.Format {
+	switch configType {
+	case "number":
+		return model.FormatNumber
+	case "boolean":
+		return model.FormatBoolean
+	case "file", "directory":
+		return model.FormatFilePath
+	default:
+		return model.FormatString
+	}
+}
+
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// dockerfileLabel matches a LABEL key=value pair, with the value optionally quoted
+var dockerfileLabel = regexp.MustCompile(`([A-Za-z0-9._-]+)=("(?:[^"\\]|\\.)*"|\S+)`)
+
+func detectOCI(dir string) (*projectInfo, bool) {
+	file, err := os.Open(filepath.Join(dir, "Dockerfile"))
+	if err != nil {
+		return nil, false
+	}
+	defer file.Close()
+
+	project := &projectInfo{
+		source: "Dockerfile",
+		pkg: model.Package{
+			RegistryType: model.RegistryTypeOCI,
+			RunTimeHint:  "docker",
+		},
+	}
+
+	scanner := bufio.NewScanner(file)
+	var line string
+	for scanner.Scan() {
+		// Instructions continue onto the next line after a trailing backslash
+		line += strings.TrimSpace(scanner.Text())
+		if strings.HasSuffix(line, `\`) {
+			line = strings.TrimSuffix(line, `\`) + " "
+			continue
+		}
+		instruction, arguments, _ := strings.Cut(line, " ")
+		line = ""
+		switch strings.ToUpper(instruction) {
+		case "LABEL":
+			for _, label := range dockerfileLabel.FindAllStringSubmatch(arguments, -1) {
+				value := label[2]
+				if unquoted, err := strconv.Unquote(value); err == nil {
+					value = unquoted
+				}
+				switch label[1] {
+				case "io.modelcontextprotocol.server.name":
+					project.serverName = value
+				case "org.opencontainers.image.version":
+					project.version = value
+				case "org.opencontainers.image.description":
+					project.description = value
+				}
+			}
+		case "EXPOSE":
+			// A server that listens on a port is reached over HTTP rather than stdio
+			fields := strings.Fields(arguments)
+			if len(fields) > 0 && project.pkg.Transport.Type == "" {
+				port, _, _ := strings.Cut(fields[0], "/")
+				project.pkg.Transport = model.Transport{
+					Type: model.TransportTypeStreamableHTTP,
+					URL:  fmt.Sprintf("http://localhost:%s/mcp", port),
+				}
+			}
+		}
+	}
+	return project, true
+}
+
+// detectReadmeServerName finds the "mcp-name: <server name>" marker the registry looks for in
+// PyPI and NuGet package READMEs
+func detectReadmeServerName(dir string) string {
+	mcpName := regexp.MustCompile(`mcp-name:\s*([A-Za-z0-9.-]+/[A-Za-z0-9._-]+)`)
+	for _, readme := range []string{"README.md", "README.rst", "README"} {
+		data, err := os.ReadFile(filepath.Join(dir, readme))
+		if err != nil {
+			continue
+		}
+		if match := mcpName.FindSubmatch(data); match != nil {
+			return string(match[1])
+		}
+	}
+	return ""
+}
+
+// secretEnvName matches environment variable names that usually hold credentials
+var secretEnvName = regexp.MustCompile(`KEY|TOKEN|SECRET|PASSWORD|CREDENTIAL`)
+
+// detectEnvExample reads environment variables from an example .env file. A comment directly
+// above a variable becomes its description, and an example value becomes its default unless
+// the variable looks like a secret.
+func detectEnvExample(dir string) []model.KeyValueInput {
+	var file *os.File
+	for _, name := range []string{".env.example", ".env.sample", ".env.template"} {
+		if opened, err := os.Open(filepath.Join(dir, name)); err == nil {
+			file = opened
+			break
+		}
+	}
+	if file == nil {
+		return nil
+	}
+	defer file.Close()
+
+	var envVars []model.KeyValueInput
+	var comment []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			comment = nil
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			comment = append(comment, strings.TrimSpace(strings.TrimPrefix(line, "#")))
+			continue
+		}
+
+		name, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !ok {
+			comment = nil
+			continue
+		}
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		} else {
+			value = strings.Trim(value, "'")
+		}
+
+		envVars = append(envVars, exampleEnvVar(name, value, strings.Join(comment, " ")))
+		comment = nil
+	}
+	return envVars
+}
+
+// exampleEnvVar describes an environment variable from an example value. The value becomes the
+// default unless the name looks like a secret, as example secrets must not be published.
+func exampleEnvVar(name, value, description string) model.KeyValueInput {
+	input := model.Input{
+		Description: description,
+		IsSecret:    secretEnvName.MatchString(strings.ToUpper(name)),
+		Format:      model.FormatString,
+	}
+	if !input.IsSecret {
+		input.Default = value
+	}
+	input.IsRequired = input.Default == ""
+
+	return model.KeyValueInput{
+		Name:               name,
+		InputWithVariables: model.InputWithVariables{Input: input},
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
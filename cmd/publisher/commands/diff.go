@@ -0,0 +1,340 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/schema"
+)
+
+// diffChange is one difference between the published and local server.json
+type diffChange struct {
+	Path   string `json:"path"`
+	Change string `json:"change"`
+	Old    any    `json:"old,omitempty"`
+	New    any    `json:"new,omitempty"`
+}
+
+// diffResult is the output of the diff command
+type diffResult struct {
+	Name     string       `json:"name"`
+	Against  string       `json:"against"`
+	Changes  []diffChange `json:"changes"`
+	Warnings []string     `json:"warnings"`
+}
+
+// DiffCommand compares a local server.json with a version published to the registry
+func DiffCommand(args []string) error {
+	diffFlags := flag.NewFlagSet("diff", flag.ExitOnError)
+	var options readOptions
+	options.register(diffFlags)
+	var against string
+	diffFlags.StringVar(&against, "against", "latest", "Published version to compare with")
+	serverFile, err := parseWithOptionalArgument(diffFlags, args, "server.json", "mcp-publisher diff [server.json] [--against VERSION|latest] [--json] [--registry URL]")
+	if err != nil {
+		return err
+	}
+
+	serverData, err := os.ReadFile(serverFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s not found. Run 'mcp-publisher init' to create one", serverFile)
+		}
+		return fmt.Errorf("failed to read %s: %w", serverFile, err)
+	}
+	// Published versions are stored upgraded to the current schema, so compare the upgraded document
+	if schema.NeedsUpgrade(jsonSchemaURL(serverData)) {
+		if serverData, _, err = schema.Upgrade(serverData); err != nil {
+			return fmt.Errorf("%s: %w", serverFile, err)
+		}
+	}
+	var local apiv0.ServerJSON
+	if err := json.Unmarshal(serverData, &local); err != nil {
+		return fmt.Errorf("%s: invalid server.json: %w", serverFile, err)
+	}
+
+	client, err := newRegistryClient(options)
+	if err != nil {
+		return err
+	}
+	var published apiv0.ServerResponse
+	err = client.get("v0/servers/"+url.PathEscape(local.Name)+"/versions/"+url.PathEscape(against), &published)
+	var responseErr *responseError
+	if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusNotFound {
+		if against == "latest" {
+			_, _ = fmt.Fprintf(os.Stdout, "%s has not been published to %s yet, so there is nothing to compare with\n", local.Name, client.registryURL)
+			return nil
+		}
+		return fmt.Errorf("version %s of %s is not published to %s", against, local.Name, client.registryURL)
+	}
+	if err != nil {
+		return err
+	}
+
+	result := diffServers(&published.Server, &local)
+	if local.Version == published.Server.Version && len(result.Changes) > 0 {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("version %s is already published. Change version before publishing", local.Version))
+	}
+
+	if options.jsonOutput {
+		return printJSON(result)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Comparing %s with %s %s on %s\n", serverFile, local.Name, published.Server.Version, client.registryURL)
+	if len(result.Changes) == 0 {
+		_, _ = fmt.Fprintln(os.Stdout, "✓ No differences")
+		return nil
+	}
+	_, _ = fmt.Fprintln(os.Stdout)
+	for _, change := range result.Changes {
+		switch change.Change {
+		case "added":
+			_, _ = fmt.Fprintf(os.Stdout, "+ %s%s\n", change.Path, diffScalar(": ", change.New))
+		case "removed":
+			_, _ = fmt.Fprintf(os.Stdout, "- %s%s\n", change.Path, diffScalar(": ", change.Old))
+		default:
+			_, _ = fmt.Fprintf(os.Stdout, "~ %s: %s → %s\n", change.Path, formatDiffValue(change.Old), formatDiffValue(change.New))
+		}
+	}
+	if len(result.Warnings) > 0 {
+		_, _ = fmt.Fprintln(os.Stdout, "\nRisky changes:")
+		for _, warning := range result.Warnings {
+			_, _ = fmt.Fprintf(os.Stdout, "⚠ %s\n", warning)
+		}
+	}
+	return nil
+}
+
+// diffServers compares two versions of a server. Packages, remotes, arguments and named inputs
+// are matched by what identifies them rather than by their position, so reordering them is not
+// a change.
+func diffServers(published, local *apiv0.ServerJSON) *diffResult {
+	result := &diffResult{Name: local.Name, Against: published.Version, Changes: []diffChange{}, Warnings: []string{}}
+	result.diff("", "", toGeneric(published), toGeneric(local))
+	return result
+}
+
+func toGeneric(server *apiv0.ServerJSON) any {
+	data, _ := json.Marshal(server)
+	var generic any
+	_ = json.Unmarshal(data, &generic)
+	return generic
+}
+
+func (r *diffResult) diff(path, field string, before, after any) {
+	beforeMap, beforeIsMap := before.(map[string]any)
+	afterMap, afterIsMap := after.(map[string]any)
+	if beforeIsMap && afterIsMap {
+		keys := make([]string, 0, len(beforeMap)+len(afterMap))
+		for key := range beforeMap {
+			keys = append(keys, key)
+		}
+		for key := range afterMap {
+			if _, ok := beforeMap[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			r.diff(joinDiffPath(path, key), key, beforeMap[key], afterMap[key])
+		}
+		return
+	}
+
+	beforeList, beforeIsList := before.([]any)
+	afterList, afterIsList := after.([]any)
+	if (beforeIsList || before == nil) && (afterIsList || after == nil) && isKeyedList(field) {
+		r.diffKeyed(path, field, beforeList, afterList)
+		return
+	}
+
+	switch {
+	case reflect.DeepEqual(before, after):
+	case before == nil:
+		r.Changes = append(r.Changes, diffChange{Path: path, Change: "added", New: after})
+		r.checkAdded(path, field, after)
+	case after == nil:
+		r.Changes = append(r.Changes, diffChange{Path: path, Change: "removed", Old: before})
+	default:
+		r.Changes = append(r.Changes, diffChange{Path: path, Change: "changed", Old: before, New: after})
+		r.checkChanged(path, field, before, after)
+	}
+}
+
+// isKeyedList reports whether the items of a list field are matched by key
+func isKeyedList(field string) bool {
+	switch field {
+	case "packages", "remotes", "runtimeArguments", "packageArguments", "environmentVariables", "headers", "icons":
+		return true
+	}
+	return false
+}
+
+// diffKey returns the key an item of a list field is matched by
+func diffKey(field string, item map[string]any, positional int) string {
+	text := func(key string) string {
+		value, _ := item[key].(string)
+		return value
+	}
+	switch field {
+	case "packages":
+		identifier := text("identifier")
+		if text("registryType") == model.RegistryTypeOCI {
+			// The tag is the version, so it changes between versions of the same image
+			if at := strings.LastIndex(identifier, "@"); at >= 0 {
+				identifier = identifier[:at]
+			}
+			if colon := strings.LastIndex(identifier, ":"); colon > strings.LastIndex(identifier, "/") {
+				identifier = identifier[:colon]
+			}
+		}
+		return text("registryType") + " " + identifier
+	case "remotes":
+		return text("url")
+	case "icons":
+		return text("src")
+	case "runtimeArguments", "packageArguments":
+		if name := text("name"); name != "" {
+			return name
+		}
+		if hint := text("valueHint"); hint != "" {
+			return hint
+		}
+		return fmt.Sprintf("positional %d", positional)
+	default:
+		return text("name")
+	}
+}
+
+func (r *diffResult) diffKeyed(path, field string, beforeList, afterList []any) {
+	oldKeys, oldItems := keyItems(field, beforeList)
+	newKeys, newItems := keyItems(field, afterList)
+
+	var removed, added []string
+	for _, key := range oldKeys {
+		if _, ok := newItems[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+	for _, key := range newKeys {
+		if _, ok := oldItems[key]; !ok {
+			added = append(added, key)
+		}
+	}
+
+	// A package whose identifier changed, such as an MCPB release URL, is paired with the other
+	// package of its registry type
+	if field == "packages" {
+		for _, newKey := range slices.Clone(added) {
+			registryType, _, _ := strings.Cut(newKey, " ")
+			i := slices.IndexFunc(removed, func(oldKey string) bool { return strings.HasPrefix(oldKey, registryType+" ") })
+			if i < 0 {
+				continue
+			}
+			r.diff(fmt.Sprintf("%s[%s]", path, newKey), "", oldItems[removed[i]], newItems[newKey])
+			removed = slices.Delete(removed, i, i+1)
+			added = slices.DeleteFunc(added, func(key string) bool { return key == newKey })
+		}
+	}
+
+	for _, key := range oldKeys {
+		switch {
+		case slices.Contains(removed, key):
+			r.Changes = append(r.Changes, diffChange{Path: fmt.Sprintf("%s[%s]", path, key), Change: "removed", Old: oldItems[key]})
+			r.checkRemoved(field, key)
+		case newItems[key] != nil:
+			r.diff(fmt.Sprintf("%s[%s]", path, key), "", oldItems[key], newItems[key])
+		}
+	}
+	for _, key := range added {
+		itemPath := fmt.Sprintf("%s[%s]", path, key)
+		r.Changes = append(r.Changes, diffChange{Path: itemPath, Change: "added", New: newItems[key]})
+		r.checkAdded(itemPath, field, newItems[key])
+	}
+}
+
+// keyItems returns the keys of a list's items in order, and the items by key
+func keyItems(field string, list []any) ([]string, map[string]any) {
+	keys := make([]string, 0, len(list))
+	items := make(map[string]any, len(list))
+	positional := 0
+	for _, item := range list {
+		object, _ := item.(map[string]any)
+		if object["type"] == string(model.ArgumentTypePositional) {
+			positional++
+		}
+		key := diffKey(field, object, positional)
+		// Repeated keys, such as a repeated argument, are told apart by their position
+		for n := 2; items[key] != nil; n++ {
+			key = fmt.Sprintf("%s #%d", diffKey(field, object, positional), n)
+		}
+		keys = append(keys, key)
+		items[key] = item
+	}
+	return keys, items
+}
+
+func (r *diffResult) checkRemoved(field, key string) {
+	switch field {
+	case "packages":
+		r.Warnings = append(r.Warnings, fmt.Sprintf("package %s is removed. Clients that install it will not find it in this version", key))
+	case "remotes":
+		r.Warnings = append(r.Warnings, fmt.Sprintf("remote %s is removed. Clients that connect to it will not find it in this version", key))
+	}
+}
+
+func (r *diffResult) checkChanged(path, field string, before, after any) {
+	if field == "type" && (strings.HasSuffix(path, ".transport.type") || strings.HasPrefix(path, "remotes[")) {
+		r.Warnings = append(r.Warnings, fmt.Sprintf("%s changes from %v to %v. Clients configured for the old transport will not connect", path, before, after))
+	}
+}
+
+func (r *diffResult) checkAdded(path, field string, after any) {
+	switch field {
+	case "environmentVariables", "headers", "runtimeArguments", "packageArguments":
+		input, _ := after.(map[string]any)
+		if input["isRequired"] == true && input["default"] == nil && input["value"] == nil {
+			r.Warnings = append(r.Warnings, fmt.Sprintf("%s is a new required input. Existing configurations without it will fail", path))
+		}
+	case "isRequired":
+		// isRequired is omitted when false, so an input becoming required shows as added
+		if after == true {
+			r.Warnings = append(r.Warnings, fmt.Sprintf("%s becomes required. Existing configurations without it will fail", strings.TrimSuffix(path, ".isRequired")))
+		}
+	}
+}
+
+func joinDiffPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// formatDiffValue formats a value as compact JSON
+func formatDiffValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// diffScalar formats a value after a prefix, or returns "" for objects and lists, whose path says enough
+func diffScalar(prefix string, value any) string {
+	switch value.(type) {
+	case map[string]any, []any:
+		return ""
+	}
+	return prefix + formatDiffValue(value)
+}
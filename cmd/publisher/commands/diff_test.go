@@ -0,0 +1,175 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestDiffCommand(t *testing.T) {
+	published := testServerResponse("1.0.0", model.StatusActive, true)
+	published.Server.Packages = []model.Package{
+		{
+			RegistryType: model.RegistryTypeNPM,
+			Identifier:   "@example/weather",
+			Version:      "1.0.0",
+			Transport:    model.Transport{Type: model.TransportTypeStdio},
+			PackageArguments: []model.Argument{
+				{Type: model.ArgumentTypeNamed, Name: "--units", InputWithVariables: model.InputWithVariables{Input: model.Input{Default: "metric"}}},
+				{Type: model.ArgumentTypeNamed, Name: "--verbose"},
+			},
+			EnvironmentVariables: []model.KeyValueInput{
+				{Name: "WEATHER_REGION"},
+				{Name: "WEATHER_API_KEY", InputWithVariables: model.InputWithVariables{Input: model.Input{IsSecret: true}}},
+			},
+		},
+		{
+			RegistryType: model.RegistryTypeOCI,
+			Identifier:   "docker.io/example/weather:1.0.0",
+			Transport:    model.Transport{Type: model.TransportTypeStdio},
+		},
+		{
+			RegistryType: model.RegistryTypePyPI,
+			Identifier:   "weather-mcp",
+			Version:      "1.0.0",
+			Transport:    model.Transport{Type: model.TransportTypeStdio},
+		},
+	}
+	published.Server.Remotes = []model.Transport{{Type: model.TransportTypeSSE, URL: "https://weather.example.com/sse"}}
+
+	var requests []string
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.EscapedPath())
+		switch r.URL.EscapedPath() {
+		case "/v0/servers/io.github.example%2Fweather/versions/latest", "/v0/servers/io.github.example%2Fweather/versions/1.0.0":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(published)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer registry.Close()
+	t.Setenv("HOME", t.TempDir())
+
+	dir := t.TempDir()
+	writeServer := func(name string, server apiv0.ServerJSON) string {
+		data, err := json.Marshal(server)
+		require.NoError(t, err)
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, data, 0600))
+		return path
+	}
+
+	// The local version reorders the packages and arguments, which is not a change
+	local := published.Server
+	local.Version = "1.1.0"
+	local.Packages = []model.Package{
+		{
+			RegistryType: model.RegistryTypeOCI,
+			Identifier:   "docker.io/example/weather:1.1.0",
+			Transport:    model.Transport{Type: model.TransportTypeStreamableHTTP, URL: "http://localhost:8080/mcp"},
+		},
+		{
+			RegistryType: model.RegistryTypeNPM,
+			Identifier:   "@example/weather",
+			Version:      "1.1.0",
+			Transport:    model.Transport{Type: model.TransportTypeStdio},
+			PackageArguments: []model.Argument{
+				{Type: model.ArgumentTypeNamed, Name: "--verbose"},
+				{Type: model.ArgumentTypeNamed, Name: "--units", InputWithVariables: model.InputWithVariables{Input: model.Input{Default: "imperial"}}},
+			},
+			EnvironmentVariables: []model.KeyValueInput{
+				{Name: "WEATHER_API_KEY", InputWithVariables: model.InputWithVariables{Input: model.Input{IsSecret: true, IsRequired: true}}},
+				{Name: "WEATHER_REGION"},
+				{Name: "WEATHER_CACHE", InputWithVariables: model.InputWithVariables{Input: model.Input{IsRequired: true}}},
+			},
+		},
+	}
+	local.Remotes = []model.Transport{{Type: model.TransportTypeStreamableHTTP, URL: "https://weather.example.com/sse"}}
+	changed := writeServer("server.json", local)
+
+	t.Run("semantic diff", func(t *testing.T) {
+		var err error
+		output := captureStdout(t, func() { err = commands.DiffCommand([]string{changed, "--registry", registry.URL}) })
+		require.NoError(t, err)
+		assert.Equal(t, "/v0/servers/io.github.example%2Fweather/versions/latest", requests[len(requests)-1])
+
+		assert.Contains(t, output, "Comparing "+changed+" with io.github.example/weather 1.0.0 on "+registry.URL)
+		assert.Contains(t, output, `~ version: "1.0.0" → "1.1.0"`)
+		assert.Contains(t, output, `~ packages[npm @example/weather].packageArguments[--units].default: "metric" → "imperial"`)
+		assert.Contains(t, output, `~ packages[oci docker.io/example/weather].identifier: "docker.io/example/weather:1.0.0" → "docker.io/example/weather:1.1.0"`)
+		assert.Contains(t, output, `+ packages[npm @example/weather].environmentVariables[WEATHER_API_KEY].isRequired: true`)
+		assert.Contains(t, output, "+ packages[npm @example/weather].environmentVariables[WEATHER_CACHE]\n")
+		assert.Contains(t, output, "- packages[pypi weather-mcp]\n")
+		assert.Contains(t, output, `~ remotes[https://weather.example.com/sse].type: "sse" → "streamable-http"`)
+		assert.NotContains(t, output, "--verbose", "reordered arguments are matched by name")
+		assert.NotContains(t, output, "WEATHER_REGION")
+
+		assert.Contains(t, output, "⚠ package pypi weather-mcp is removed")
+		assert.Contains(t, output, "⚠ packages[oci docker.io/example/weather].transport.type changes from stdio to streamable-http")
+		assert.Contains(t, output, "⚠ remotes[https://weather.example.com/sse].type changes from sse to streamable-http")
+		assert.Contains(t, output, "⚠ packages[npm @example/weather].environmentVariables[WEATHER_API_KEY] becomes required")
+		assert.Contains(t, output, "⚠ packages[npm @example/weather].environmentVariables[WEATHER_CACHE] is a new required input")
+		assert.NotContains(t, output, "already published")
+	})
+
+	t.Run("against a version as JSON", func(t *testing.T) {
+		same := local
+		same.Version = "1.0.0"
+		path := writeServer("same-version.json", same)
+
+		var err error
+		output := captureStdout(t, func() {
+			err = commands.DiffCommand([]string{"--against", "1.0.0", "--json", "--registry", registry.URL, path})
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "/v0/servers/io.github.example%2Fweather/versions/1.0.0", requests[len(requests)-1])
+
+		var result struct {
+			Against  string
+			Changes  []struct{ Path, Change string }
+			Warnings []string
+		}
+		require.NoError(t, json.Unmarshal([]byte(output), &result))
+		assert.Equal(t, "1.0.0", result.Against)
+		assert.Contains(t, result.Changes, struct{ Path, Change string }{"packages[pypi weather-mcp]", "removed"})
+		assert.Contains(t, result.Warnings, "version 1.0.0 is already published. Change version before publishing")
+	})
+
+	t.Run("no differences", func(t *testing.T) {
+		path := writeServer("published.json", published.Server)
+		var err error
+		output := captureStdout(t, func() { err = commands.DiffCommand([]string{path, "--registry", registry.URL}) })
+		require.NoError(t, err)
+		assert.Contains(t, output, "✓ No differences")
+	})
+
+	t.Run("not published", func(t *testing.T) {
+		unpublished := local
+		unpublished.Name = "io.github.example/new"
+		path := writeServer("new.json", unpublished)
+
+		var err error
+		output := captureStdout(t, func() { err = commands.DiffCommand([]string{path, "--registry", registry.URL}) })
+		require.NoError(t, err)
+		assert.Contains(t, output, "io.github.example/new has not been published")
+
+		err = commands.DiffCommand([]string{path, "--against", "1.0.0", "--registry", registry.URL})
+		assert.ErrorContains(t, err, "version 1.0.0 of io.github.example/new is not published")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		err := commands.DiffCommand([]string{filepath.Join(dir, "missing.json"), "--registry", registry.URL})
+		assert.ErrorContains(t, err, "missing.json not found")
+	})
+}
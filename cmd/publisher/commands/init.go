@@ -1,13 +1,18 @@
 package commands
 
 import (
+	"bufio"
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -15,49 +20,99 @@ import (
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
-func InitCommand() error {
+// InitCommand creates a server.json for the project in a directory, filled in from the package
+// metadata, git remote and example environment file found there
+func InitCommand(args []string) error {
+	initFlags := flag.NewFlagSet("init", flag.ExitOnError)
+	var dir, from, entry string
+	var interactive bool
+	initFlags.StringVar(&dir, "dir", ".", "Project directory, such as a package folder in a monorepo")
+	initFlags.StringVar(&from, "from", "", "Client configuration file to import the package from, such as VS Code mcp.json or claude_desktop_config.json")
+	initFlags.StringVar(&entry, "entry", "", "Server entry to import from the --from file (default: its only entry)")
+	initFlags.BoolVar(&interactive, "interactive", false, "Confirm or change each detected value")
+	initFlags.BoolVar(&interactive, "i", false, "Shorthand for --interactive")
+	if err := initFlags.Parse(args); err != nil {
+		return err
+	}
+	if initFlags.NArg() > 0 || (entry != "" && from == "") {
+		return errors.New("usage: mcp-publisher init [--dir DIR] [--from CONFIG_FILE [--entry NAME]] [--interactive]")
+	}
+
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+
 	// Check if server.json already exists
-	if _, err := os.Stat("server.json"); err == nil {
-		return errors.New("server.json already exists")
-	}
-
-	// Try to detect values from environment
-	name := detectServerName()
-	description := detectDescription()
-	version := "1.0.0"
-	repoURL := detectRepoURL()
-	repoSource := "github"
-	if repoURL != "" && !strings.Contains(repoURL, "github.com") {
-		if strings.Contains(repoURL, "gitlab.com") {
-			repoSource = "gitlab"
-		} else {
-			repoSource = "git"
+	serverFile := filepath.Join(dir, "server.json")
+	if _, err := os.Stat(serverFile); err == nil {
+		return fmt.Errorf("%s already exists", serverFile)
+	}
+
+	// Try to detect values from the project
+	project := detectProject(dir)
+	if from != "" {
+		// The package comes from the client configuration, and the rest from the project
+		imported, err := importClientConfig(from, entry)
+		if err != nil {
+			return err
 		}
+		project.source = imported.source
+		project.name = imported.name
+		project.version = cmp.Or(imported.version, project.version)
+		project.bundle = ""
+		project.pkg = imported.pkg
+	}
+	repoURL := detectRepoURL(dir)
+	subfolder := detectSubfolder(dir)
+	name := detectServerName(dir, repoURL, subfolder, project)
+	description := project.description
+	if description == "" {
+		description = "An MCP server that provides [describe what your server does]"
+	}
+	version := project.version
+	if version == "" {
+		version = "1.0.0"
 	}
 
-	packageType := detectPackageType()
-	packageIdentifier := detectPackageIdentifier(name, packageType)
+	pkg := project.pkg
+	pkg.Identifier = packageIdentifier(pkg.RegistryType, pkg.Identifier, name, version, repoURL, project.bundle)
+	if pkg.RegistryType != model.RegistryTypeOCI {
+		// OCI packages carry their version in the image tag instead
+		pkg.Version = version
+	}
 
-	// Create example environment variables
-	envVars := []model.KeyValueInput{
-		{
-			Name: "YOUR_API_KEY",
-			InputWithVariables: model.InputWithVariables{
-				Input: model.Input{
-					Description: "Your API key for the service",
-					IsRequired:  true,
-					IsSecret:    true,
-					Format:      model.FormatString,
+	// Fall back to an example environment variable to show the format
+	if len(pkg.EnvironmentVariables) == 0 && from == "" {
+		pkg.EnvironmentVariables = []model.KeyValueInput{
+			{
+				Name: "YOUR_API_KEY",
+				InputWithVariables: model.InputWithVariables{
+					Input: model.Input{
+						Description: "Your API key for the service",
+						IsRequired:  true,
+						IsSecret:    true,
+						Format:      model.FormatString,
+					},
 				},
 			},
-		},
+		}
 	}
 
 	// Create the server structure
-	server := createServerJSON(
-		name, description, version, repoURL, repoSource,
-		packageType, packageIdentifier, version, envVars,
-	)
+	server := createServerJSON(name, description, version, model.Repository{
+		URL:       repoURL,
+		Source:    repoSource(repoURL),
+		Subfolder: subfolder,
+	}, pkg)
+
+	if project.source != "" {
+		_, _ = fmt.Fprintf(os.Stdout, "Detected %s package from %s\n", pkg.RegistryType, project.source)
+	}
+	if interactive {
+		if err := promptServerJSON(bufio.NewReader(os.Stdin), &server, project.bundle); err != nil {
+			return err
+		}
+	}
 
 	// Write to file
 	jsonData, err := json.MarshalIndent(server, "", "  ")
@@ -65,100 +120,91 @@ func InitCommand() error {
 		return fmt.Errorf("error marshaling JSON: %w", err)
 	}
 
-	err = os.WriteFile("server.json", jsonData, 0600)
+	err = os.WriteFile(serverFile, jsonData, 0600)
 	if err != nil {
 		return fmt.Errorf("error writing file: %w", err)
 	}
 
-	_, _ = fmt.Fprintln(os.Stdout, "Created server.json")
-	_, _ = fmt.Fprintln(os.Stdout, "\nEdit server.json to update:")
+	_, _ = fmt.Fprintf(os.Stdout, "Created %s\n", serverFile)
+	_, _ = fmt.Fprintf(os.Stdout, "\nEdit %s to update:\n", serverFile)
 	_, _ = fmt.Fprintln(os.Stdout, "  • Server name and description")
 	_, _ = fmt.Fprintln(os.Stdout, "  • Package details")
 	_, _ = fmt.Fprintln(os.Stdout, "  • Environment variables")
+	if server.Packages[0].RegistryType == model.RegistryTypeMCPB && server.Packages[0].FileSHA256 == "" {
+		_, _ = fmt.Fprintln(os.Stdout, "  • fileSha256 of your .mcpb bundle (run 'shasum -a 256 your-bundle.mcpb')")
+	}
 	_, _ = fmt.Fprintln(os.Stdout, "\nThen publish with:")
 	_, _ = fmt.Fprintln(os.Stdout, "  mcp-publisher login github  # or your preferred auth method")
-	_, _ = fmt.Fprintln(os.Stdout, "  mcp-publisher publish")
+	if dir != "." {
+		_, _ = fmt.Fprintf(os.Stdout, "  mcp-publisher publish %s\n", serverFile)
+	} else {
+		_, _ = fmt.Fprintln(os.Stdout, "  mcp-publisher publish")
+	}
 
 	return nil
 }
 
-func getNameFromPackageJSON() string {
-	data, err := os.ReadFile("package.json")
-	if err != nil {
-		return ""
-	}
-
-	var pkg map[string]any
-	if err := json.Unmarshal(data, &pkg); err != nil {
-		return ""
-	}
+// placeholderRepoURL stands in for the repository URL when none is detected
+const placeholderRepoURL = "https://github.com/YOUR_USERNAME/YOUR_REPO"
 
-	name, ok := pkg["name"].(string)
-	if !ok || name == "" {
-		return ""
+// runGit runs a git command in dir and returns its trimmed output
+func runGit(dir string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
 	}
+	return strings.TrimSpace(string(output)), nil
+}
 
-	// Convert npm package name to MCP server name
-	// @org/package -> io.npm.org/package
-	if strings.HasPrefix(name, "@") {
-		parts := strings.Split(name[1:], "/")
-		if len(parts) == 2 {
-			return fmt.Sprintf("io.github.%s/%s", parts[0], parts[1])
-		}
+func detectServerName(dir, repoURL, subfolder string, project *projectInfo) string {
+	// A name declared by the project must match, so it is used as is
+	if project.serverName != "" {
+		return project.serverName
 	}
-	return fmt.Sprintf("io.github.<your-username>/%s", name)
-}
 
-func detectServerName() string {
 	// Try to get from git remote
-	repoURL := detectRepoURL()
-	if repoURL != "" {
+	if strings.Contains(repoURL, "github.com") && repoURL != placeholderRepoURL {
 		// Extract owner/repo from GitHub URL
-		if strings.Contains(repoURL, "github.com") {
-			parts := strings.Split(repoURL, "/")
-			if len(parts) >= 5 {
-				owner := parts[3]
-				repo := strings.TrimSuffix(parts[4], ".git")
-				return fmt.Sprintf("io.github.%s/%s", owner, repo)
+		parts := strings.Split(repoURL, "/")
+		if len(parts) >= 5 {
+			owner := parts[3]
+			repo := parts[4]
+			// Servers in a monorepo are named after their folder
+			if subfolder != "" {
+				repo = filepath.Base(subfolder)
 			}
+			return fmt.Sprintf("io.github.%s/%s", owner, repo)
 		}
 	}
 
-	// Try to get from package.json
-	name := getNameFromPackageJSON()
-	if name != "" {
-		return name
+	// Try to get from the package name
+	if name := project.name; name != "" {
+		// Convert npm package name to MCP server name
+		// @org/package -> io.github.org/package
+		if strings.HasPrefix(name, "@") {
+			parts := strings.Split(name[1:], "/")
+			if len(parts) == 2 {
+				return fmt.Sprintf("io.github.%s/%s", parts[0], parts[1])
+			}
+		}
+		return fmt.Sprintf("io.github.<your-username>/%s", name)
 	}
 
-	// Use current directory name as fallback
-	if cwd, err := os.Getwd(); err == nil {
-		return fmt.Sprintf("com.example/%s", filepath.Base(cwd))
+	// Use the directory name as fallback
+	if abs, err := filepath.Abs(dir); err == nil {
+		return fmt.Sprintf("com.example/%s", filepath.Base(abs))
 	}
 
 	return "com.example/my-mcp-server"
 }
 
-func detectDescription() string {
-	// Try to get from package.json
-	if data, err := os.ReadFile("package.json"); err == nil {
-		var pkg map[string]any
-		if json.Unmarshal(data, &pkg) == nil {
-			if desc, ok := pkg["description"].(string); ok && desc != "" {
-				return desc
-			}
-		}
-	}
-
-	return "An MCP server that provides [describe what your server does]"
-}
-
-func detectRepoURL() string {
+func detectRepoURL(dir string) string {
 	// Try git remote
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	cmd := exec.CommandContext(ctx, "git", "remote", "get-url", "origin")
-	if output, err := cmd.Output(); err == nil {
-		url := strings.TrimSpace(string(output))
+	if url, err := runGit(dir, "remote", "get-url", "origin"); err == nil && url != "" {
 		// Convert SSH URL to HTTPS if needed
 		if strings.HasPrefix(url, "git@github.com:") {
 			url = strings.Replace(url, "git@github.com:", "https://github.com/", 1)
@@ -168,174 +214,194 @@ func detectRepoURL() string {
 	}
 
 	// Try package.json repository field
-	if data, err := os.ReadFile("package.json"); err == nil {
-		var pkg map[string]any
-		if json.Unmarshal(data, &pkg) == nil {
-			if repo, ok := pkg["repository"].(map[string]any); ok {
-				if url, ok := repo["url"].(string); ok {
-					return strings.TrimSuffix(url, ".git")
-				}
-			}
-			if repo, ok := pkg["repository"].(string); ok {
-				return strings.TrimSuffix(repo, ".git")
+	var pkg map[string]any
+	if readJSONFile(filepath.Join(dir, "package.json"), &pkg) {
+		if repo, ok := pkg["repository"].(map[string]any); ok {
+			if url, ok := repo["url"].(string); ok {
+				return strings.TrimSuffix(strings.TrimPrefix(url, "git+"), ".git")
 			}
 		}
+		if repo, ok := pkg["repository"].(string); ok {
+			return strings.TrimSuffix(repo, ".git")
+		}
 	}
 
-	return "https://github.com/YOUR_USERNAME/YOUR_REPO"
+	return placeholderRepoURL
 }
 
-func detectPackageType() string {
-	// Check for package.json
-	if _, err := os.Stat("package.json"); err == nil {
-		return model.RegistryTypeNPM
+// detectSubfolder returns the path of dir within its git repository, or "" at the repository root
+func detectSubfolder(dir string) string {
+	prefix, err := runGit(dir, "rev-parse", "--show-prefix")
+	if err != nil {
+		return ""
 	}
+	return strings.TrimSuffix(prefix, "/")
+}
 
-	// Check for pyproject.toml or setup.py
-	if _, err := os.Stat("pyproject.toml"); err == nil {
-		return model.RegistryTypePyPI
-	}
-	if _, err := os.Stat("setup.py"); err == nil {
-		return model.RegistryTypePyPI
+func repoSource(repoURL string) string {
+	switch {
+	case repoURL == "" || strings.Contains(repoURL, "github.com"):
+		return "github"
+	case strings.Contains(repoURL, "gitlab.com"):
+		return "gitlab"
+	default:
+		return "git"
 	}
+}
 
-	// Check for Dockerfile
-	if _, err := os.Stat("Dockerfile"); err == nil {
-		return model.RegistryTypeOCI
+// packageIdentifier returns the detected identifier in the form the package type needs, or a
+// placeholder derived from the server name
+func packageIdentifier(packageType, detected, serverName, version, repoURL, bundle string) string {
+	owner, repo := "your-org", "your-package"
+	if namespace, name, ok := strings.Cut(serverName, "/"); ok {
+		repo = name
+		if strings.HasPrefix(namespace, "io.github.") && !strings.Contains(namespace, "<") {
+			owner = strings.TrimPrefix(namespace, "io.github.")
+		}
 	}
 
-	// Default to npm as most common
-	return model.RegistryTypeNPM
-}
-
-func detectPackageIdentifier(serverName string, packageType string) string {
 	switch packageType {
 	case model.RegistryTypeNPM:
-		// Try to get from package.json
-		if data, err := os.ReadFile("package.json"); err == nil {
-			var pkg map[string]any
-			if json.Unmarshal(data, &pkg) == nil {
-				if name, ok := pkg["name"].(string); ok && name != "" {
-					return name
-				}
-			}
+		if detected != "" {
+			return detected
 		}
 		// Convert server name to npm package name
-		if strings.HasPrefix(serverName, "io.github.") {
-			parts := strings.Split(serverName, "/")
-			if len(parts) == 2 {
-				owner := strings.TrimPrefix(parts[0], "io.github.")
-				return fmt.Sprintf("@%s/%s", owner, parts[1])
-			}
+		if owner != "your-org" {
+			return fmt.Sprintf("@%s/%s", owner, repo)
 		}
 		return "@your-org/your-package"
 
-	case model.RegistryTypePyPI:
-		// Try to get from pyproject.toml or setup.py
-		if data, err := os.ReadFile("pyproject.toml"); err == nil {
-			// Simple extraction - could be improved with proper TOML parser
-			lines := strings.Split(string(data), "\n")
-			for _, line := range lines {
-				if strings.HasPrefix(line, "name") && strings.Contains(line, "=") {
-					parts := strings.Split(line, "=")
-					if len(parts) >= 2 {
-						name := strings.Trim(parts[1], " \"'")
-						if name != "" {
-							return name
-						}
-					}
-				}
-			}
+	case model.RegistryTypeOCI:
+		// OCI packages use canonical references: registry/namespace/image:tag
+		if detected == "" {
+			detected = fmt.Sprintf("docker.io/%s/%s", owner, repo)
 		}
-		return "your-package"
+		if !strings.Contains(detected[strings.LastIndex(detected, "/")+1:], ":") {
+			detected += ":" + version
+		}
+		return detected
 
-	case model.RegistryTypeOCI:
-		// Use a sensible default
-		if strings.Contains(serverName, "/") {
-			parts := strings.Split(serverName, "/")
-			return parts[len(parts)-1]
+	case model.RegistryTypeMCPB:
+		// MCPB packages are downloaded from a release asset
+		if bundle == "" {
+			bundle = fmt.Sprintf("%s-%s.mcpb", repo, version)
+		}
+		if !strings.HasPrefix(repoURL, "https://github.com/") {
+			repoURL = placeholderRepoURL
 		}
-		return "your-image"
+		return fmt.Sprintf("%s/releases/download/v%s/%s", repoURL, version, bundle)
 
 	default:
+		if detected != "" {
+			return detected
+		}
 		return "your-package"
 	}
 }
 
-func createServerJSON(
-	name, description, version, repoURL, repoSource,
-	packageType, packageIdentifier, packageVersion string,
-	envVars []model.KeyValueInput,
-) apiv0.ServerJSON {
-	// Create package based on type
-	var pkg model.Package
+func createServerJSON(name, description, version string, repository model.Repository, pkg model.Package) apiv0.ServerJSON {
+	// Create server structure
+	return apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        name,
+		Description: description,
+		Repository:  repository,
+		Version:     version,
+		Packages:    []model.Package{pkg},
+	}
+}
+
+var (
+	initPackageTypes   = []string{model.RegistryTypeNPM, model.RegistryTypePyPI, model.RegistryTypeNuGet, model.RegistryTypeOCI, model.RegistryTypeMCPB}
+	initTransportTypes = []string{model.TransportTypeStdio, model.TransportTypeStreamableHTTP, model.TransportTypeSSE}
+)
 
-	switch packageType {
-	case model.RegistryTypeNPM:
-		pkg = model.Package{
-			RegistryType:         model.RegistryTypeNPM,
-			Identifier:           packageIdentifier,
-			Version:              packageVersion,
-			EnvironmentVariables: envVars,
-			Transport: model.Transport{
-				Type: model.TransportTypeStdio,
-			},
+// promptServerJSON asks for each value of server, offering the detected one as the default
+func promptServerJSON(in *bufio.Reader, server *apiv0.ServerJSON, bundle string) error {
+	pkg := &server.Packages[0]
+	var err error
+
+	if server.Name, err = prompt(in, "Server name", server.Name); err != nil {
+		return err
+	}
+	if server.Description, err = prompt(in, "Description", server.Description); err != nil {
+		return err
+	}
+	previousVersion := server.Version
+	if server.Version, err = prompt(in, "Version", server.Version); err != nil {
+		return err
+	}
+	if server.Repository.URL, err = prompt(in, "Repository URL", server.Repository.URL); err != nil {
+		return err
+	}
+	server.Repository.Source = repoSource(server.Repository.URL)
+
+	packageType, err := prompt(in, "Package type ("+strings.Join(initPackageTypes, ", ")+")", pkg.RegistryType)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(initPackageTypes, packageType) {
+		return fmt.Errorf("unknown package type %q: use %s", packageType, strings.Join(initPackageTypes, ", "))
+	}
+
+	// The identifier depends on the type, name and version, so it is worked out again for the answers
+	detected := pkg.Identifier
+	if packageType != pkg.RegistryType {
+		*pkg = model.Package{
+			RegistryType:         packageType,
+			Transport:            model.Transport{Type: model.TransportTypeStdio},
+			EnvironmentVariables: pkg.EnvironmentVariables,
 		}
-	case model.RegistryTypePyPI:
-		pkg = model.Package{
-			RegistryType:         model.RegistryTypePyPI,
-			Identifier:           packageIdentifier,
-			Version:              packageVersion,
-			EnvironmentVariables: envVars,
-			Transport: model.Transport{
-				Type: model.TransportTypeStdio,
-			},
+		detected = ""
+	}
+	if packageType == model.RegistryTypeOCI || packageType == model.RegistryTypeMCPB {
+		detected = ""
+	}
+	identifier := packageIdentifier(packageType, detected, server.Name, server.Version, server.Repository.URL, bundle)
+	if pkg.Identifier, err = prompt(in, "Package identifier", identifier); err != nil {
+		return err
+	}
+	if packageType != model.RegistryTypeOCI {
+		if pkg.Version == "" || pkg.Version == previousVersion {
+			pkg.Version = server.Version
 		}
-	case model.RegistryTypeOCI:
-		// OCI packages use canonical references: registry/namespace/image:tag
-		// Format: docker.io/username/image:version
-		canonicalRef := fmt.Sprintf("docker.io/%s:%s", packageIdentifier, packageVersion)
-		pkg = model.Package{
-			RegistryType: model.RegistryTypeOCI,
-			Identifier:   canonicalRef,
-			// No Version field for OCI - it's embedded in the canonical reference
-			EnvironmentVariables: envVars,
-			Transport: model.Transport{
-				Type: model.TransportTypeStdio,
-			},
+		if pkg.Version, err = prompt(in, "Package version", pkg.Version); err != nil {
+			return err
 		}
-	case "url":
-		pkg = model.Package{
-			RegistryType:         "url",
-			Identifier:           packageIdentifier,
-			Version:              packageVersion,
-			EnvironmentVariables: envVars,
-			Transport: model.Transport{
-				Type: model.TransportTypeStdio,
-			},
+	}
+
+	transport, err := prompt(in, "Transport ("+strings.Join(initTransportTypes, ", ")+")", pkg.Transport.Type)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(initTransportTypes, transport) {
+		return fmt.Errorf("unknown transport %q: use %s", transport, strings.Join(initTransportTypes, ", "))
+	}
+	pkg.Transport.Type = transport
+	if transport == model.TransportTypeStdio {
+		pkg.Transport.URL = ""
+	} else {
+		url := pkg.Transport.URL
+		if url == "" {
+			url = "http://localhost:8080/mcp"
 		}
-	default:
-		pkg = model.Package{
-			RegistryType:         packageType,
-			Identifier:           packageIdentifier,
-			Version:              packageVersion,
-			EnvironmentVariables: envVars,
-			Transport: model.Transport{
-				Type: model.TransportTypeStdio,
-			},
+		if pkg.Transport.URL, err = prompt(in, "Transport URL", url); err != nil {
+			return err
 		}
 	}
 
-	// Create server structure
-	return apiv0.ServerJSON{
-		Schema:      model.CurrentSchemaURL,
-		Name:        name,
-		Description: description,
-		Repository: model.Repository{
-			URL:    repoURL,
-			Source: repoSource,
-		},
-		Version:  version,
-		Packages: []model.Package{pkg},
+	return nil
+}
+
+// prompt asks for a value, returning the default when the answer is empty
+func prompt(in *bufio.Reader, label, defaultValue string) (string, error) {
+	_, _ = fmt.Fprintf(os.Stdout, "%s [%s]: ", label, defaultValue)
+	answer, err := in.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+	if answer = strings.TrimSpace(answer); answer != "" {
+		return answer, nil
 	}
+	return defaultValue, nil
 }
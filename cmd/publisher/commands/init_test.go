@@ -0,0 +1,498 @@
+package commands_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// runInit runs init on a directory of project files and returns the server.json it writes
+func runInit(t *testing.T, files map[string]string, args ...string) (apiv0.ServerJSON, string) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+
+	var err error
+	output := captureStdout(t, func() { err = commands.InitCommand(append([]string{"--dir", dir}, args...)) })
+	require.NoError(t, err)
+
+	var server apiv0.ServerJSON
+	data, err := os.ReadFile(filepath.Join(dir, "server.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &server))
+	require.Len(t, server.Packages, 1)
+	return server, output
+}
+
+func TestInitCommand_NPM(t *testing.T) {
+	server, output := runInit(t, map[string]string{
+		"package.json": `{
+  "name": "@example/weather",
+  "version": "2.1.0",
+  "description": "Weather forecasts",
+  "mcpName": "io.github.someone/weather",
+  "bin": {"weather": "dist/index.js"},
+  "repository": {"type": "git", "url": "git+https://github.com/someone/weather.git"}
+}`,
+		".env.example": `# Key for the forecast API
+WEATHER_API_KEY=your-key-here
+
+# Units to report in
+UNITS="metric"
+export REGION=
+`,
+	})
+
+	assert.Contains(t, output, "Detected npm package from package.json")
+	assert.Equal(t, "io.github.someone/weather", server.Name)
+	assert.Equal(t, "Weather forecasts", server.Description)
+	assert.Equal(t, "2.1.0", server.Version)
+	assert.Equal(t, model.Repository{URL: "https://github.com/someone/weather", Source: "github"}, server.Repository)
+
+	pkg := server.Packages[0]
+	assert.Equal(t, model.RegistryTypeNPM, pkg.RegistryType)
+	assert.Equal(t, "@example/weather", pkg.Identifier)
+	assert.Equal(t, "2.1.0", pkg.Version)
+	assert.Equal(t, "npx", pkg.RunTimeHint)
+	assert.Equal(t, model.TransportTypeStdio, pkg.Transport.Type)
+
+	require.Len(t, pkg.EnvironmentVariables, 3)
+	apiKey := pkg.EnvironmentVariables[0]
+	assert.Equal(t, "WEATHER_API_KEY", apiKey.Name)
+	assert.Equal(t, "Key for the forecast API", apiKey.Description)
+	assert.True(t, apiKey.IsSecret)
+	assert.True(t, apiKey.IsRequired)
+	assert.Empty(t, apiKey.Default, "example values of secrets are not defaults")
+
+	units := pkg.EnvironmentVariables[1]
+	assert.Equal(t, "UNITS", units.Name)
+	assert.Equal(t, "metric", units.Default)
+	assert.False(t, units.IsSecret)
+	assert.False(t, units.IsRequired)
+
+	region := pkg.EnvironmentVariables[2]
+	assert.Equal(t, "REGION", region.Name)
+	assert.True(t, region.IsRequired)
+}
+
+func TestInitCommand_PyPI(t *testing.T) {
+	t.Run("pyproject.toml", func(t *testing.T) {
+		server, _ := runInit(t, map[string]string{
+			"pyproject.toml": `[build-system]
+requires = ["hatchling"]
+build-backend = "hatchling.build"
+
+[project]
+name = "weather-mcp"  # the distribution name
+version = '0.3.1'
+description = """
+Weather forecasts \
+for MCP clients"""
+dependencies = [
+    "mcp>=1.0",
+    "httpx",
+]
+authors = [{ name = "Someone", email = "someone@example.com" }]
+
+[project.scripts]
+weather-mcp = "weather_mcp:main"
+
+[[tool.hatch.envs]]
+name = "not-the-project"
+`,
+			"README.md": "# Weather\n\n<!-- mcp-name: io.github.someone/weather -->\n",
+		})
+
+		assert.Equal(t, "io.github.someone/weather", server.Name)
+		assert.Equal(t, "Weather forecasts for MCP clients", server.Description)
+		assert.Equal(t, "0.3.1", server.Version)
+
+		pkg := server.Packages[0]
+		assert.Equal(t, model.RegistryTypePyPI, pkg.RegistryType)
+		assert.Equal(t, "weather-mcp", pkg.Identifier)
+		assert.Equal(t, "0.3.1", pkg.Version)
+		assert.Equal(t, "uvx", pkg.RunTimeHint)
+	})
+
+	t.Run("poetry", func(t *testing.T) {
+		server, _ := runInit(t, map[string]string{
+			"pyproject.toml": `[tool.poetry]
+name = 'weather-poetry'
+version = "1.2.0"
+description = "Weather via Poetry"
+`,
+		})
+
+		pkg := server.Packages[0]
+		assert.Equal(t, model.RegistryTypePyPI, pkg.RegistryType)
+		assert.Equal(t, "weather-poetry", pkg.Identifier)
+		assert.Equal(t, "1.2.0", pkg.Version)
+		assert.Equal(t, "Weather via Poetry", server.Description)
+		assert.Empty(t, pkg.RunTimeHint)
+	})
+
+	t.Run("setup.py", func(t *testing.T) {
+		server, _ := runInit(t, map[string]string{
+			"setup.py": `from setuptools import setup
+
+setup(
+    name="weather-setup",
+    version="0.9.0",
+    entry_points={"console_scripts": ["weather=weather:main"]},
+)
+`,
+		})
+
+		pkg := server.Packages[0]
+		assert.Equal(t, "weather-setup", pkg.Identifier)
+		assert.Equal(t, "0.9.0", pkg.Version)
+		assert.Equal(t, "uvx", pkg.RunTimeHint)
+	})
+}
+
+func TestInitCommand_NuGet(t *testing.T) {
+	server, output := runInit(t, map[string]string{
+		"Weather.Server.csproj": `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <OutputType>Exe</OutputType>
+    <PackAsTool>true</PackAsTool>
+  </PropertyGroup>
+  <PropertyGroup>
+    <PackageId>Someone.Weather</PackageId>
+    <Version>1.4.0</Version>
+    <Description>Weather forecasts for .NET</Description>
+  </PropertyGroup>
+</Project>`,
+	})
+
+	assert.Contains(t, output, "Detected nuget package from Weather.Server.csproj")
+	assert.Equal(t, "Weather forecasts for .NET", server.Description)
+	assert.Equal(t, "1.4.0", server.Version)
+
+	pkg := server.Packages[0]
+	assert.Equal(t, model.RegistryTypeNuGet, pkg.RegistryType)
+	assert.Equal(t, "Someone.Weather", pkg.Identifier)
+	assert.Equal(t, "1.4.0", pkg.Version)
+	assert.Equal(t, "dnx", pkg.RunTimeHint)
+}
+
+func TestInitCommand_MCPB(t *testing.T) {
+	bundle := "bundle contents"
+	hash := sha256.Sum256([]byte(bundle))
+
+	server, output := runInit(t, map[string]string{
+		"manifest.json": `{
+  "manifest_version": "0.2",
+  "name": "weather",
+  "version": "0.5.0",
+  "description": "Weather bundle",
+  "server": {
+    "type": "node",
+    "entry_point": "server/index.js",
+    "mcp_config": {
+      "command": "node",
+      "args": ["${__dirname}/server/index.js"],
+      "env": {
+        "WEATHER_API_KEY": "${user_config.api_key}",
+        "CACHE_DIR": "${user_config.cache_dir}",
+        "NODE_ENV": "production"
+      }
+    }
+  },
+  "user_config": {
+    "api_key": {"type": "string", "title": "API key", "sensitive": true, "required": true},
+    "cache_dir": {"type": "directory", "title": "Cache", "description": "Where forecasts are cached", "default": "/tmp/weather"}
+  }
+}`,
+		"package.json": `{"name": "weather-bundle", "version": "0.0.1"}`,
+		"weather.mcpb": bundle,
+	})
+
+	assert.Contains(t, output, "Detected mcpb package from manifest.json")
+	assert.NotContains(t, output, "fileSha256")
+	assert.Equal(t, "0.5.0", server.Version)
+
+	pkg := server.Packages[0]
+	assert.Equal(t, model.RegistryTypeMCPB, pkg.RegistryType)
+	assert.Equal(t, "https://github.com/YOUR_USERNAME/YOUR_REPO/releases/download/v0.5.0/weather.mcpb", pkg.Identifier)
+	assert.Equal(t, hex.EncodeToString(hash[:]), pkg.FileSHA256)
+
+	// Only variables set from user_config are inputs
+	require.Len(t, pkg.EnvironmentVariables, 2)
+	assert.Equal(t, "CACHE_DIR", pkg.EnvironmentVariables[0].Name)
+	assert.Equal(t, "Where forecasts are cached", pkg.EnvironmentVariables[0].Description)
+	assert.Equal(t, model.FormatFilePath, pkg.EnvironmentVariables[0].Format)
+	assert.Equal(t, "/tmp/weather", pkg.EnvironmentVariables[0].Default)
+	assert.Equal(t, "WEATHER_API_KEY", pkg.EnvironmentVariables[1].Name)
+	assert.Equal(t, "API key", pkg.EnvironmentVariables[1].Description)
+	assert.True(t, pkg.EnvironmentVariables[1].IsSecret)
+	assert.True(t, pkg.EnvironmentVariables[1].IsRequired)
+}
+
+func TestInitCommand_OCI(t *testing.T) {
+	server, _ := runInit(t, map[string]string{
+		"Dockerfile": `FROM node:22-alpine
+LABEL io.modelcontextprotocol.server.name="io.github.someone/weather" \
+      org.opencontainers.image.version=3.0.0
+EXPOSE 3000/tcp
+CMD ["node", "server.js"]
+`,
+	})
+
+	assert.Equal(t, "io.github.someone/weather", server.Name)
+	assert.Equal(t, "3.0.0", server.Version)
+
+	pkg := server.Packages[0]
+	assert.Equal(t, model.RegistryTypeOCI, pkg.RegistryType)
+	assert.Equal(t, "docker.io/someone/weather:3.0.0", pkg.Identifier)
+	assert.Empty(t, pkg.Version)
+	assert.Equal(t, "docker", pkg.RunTimeHint)
+	assert.Equal(t, model.Transport{Type: model.TransportTypeStreamableHTTP, URL: "http://localhost:3000/mcp"}, pkg.Transport)
+
+	// Without an example .env file, an example variable shows the format
+	require.Len(t, pkg.EnvironmentVariables, 1)
+	assert.Equal(t, "YOUR_API_KEY", pkg.EnvironmentVariables[0].Name)
+}
+
+func TestInitCommand_Monorepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"remote", "add", "origin", "git@github.com:someone/servers.git"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		require.NoError(t, cmd.Run())
+	}
+	dir := filepath.Join(repo, "src", "weather")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"name": "weather", "version": "1.0.0", "bin": "index.js"}`), 0600))
+
+	var err error
+	output := captureStdout(t, func() { err = commands.InitCommand([]string{"--dir", dir}) })
+	require.NoError(t, err)
+	assert.Contains(t, output, "mcp-publisher publish "+filepath.Join(dir, "server.json"))
+
+	data, err := os.ReadFile(filepath.Join(dir, "server.json"))
+	require.NoError(t, err)
+	var server apiv0.ServerJSON
+	require.NoError(t, json.Unmarshal(data, &server))
+
+	assert.Equal(t, "io.github.someone/weather", server.Name)
+	assert.Equal(t, model.Repository{URL: "https://github.com/someone/servers", Source: "github", Subfolder: "src/weather"}, server.Repository)
+	assert.Equal(t, "weather", server.Packages[0].Identifier)
+
+	_, err = os.Stat(filepath.Join(repo, "server.json"))
+	assert.True(t, os.IsNotExist(err), "server.json is written to --dir only")
+}
+
+func TestInitCommand_Interactive(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	// Keep the name, change the version, switch to OCI and keep its identifier, then pick a transport
+	_, err = writer.WriteString("\nBetter weather\n2.0.0\nhttps://gitlab.com/someone/weather\noci\n\nstreamable-http\n\n")
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	stdin := os.Stdin
+	os.Stdin = reader
+	defer func() { os.Stdin = stdin }()
+
+	server, output := runInit(t, map[string]string{
+		"package.json": `{"name": "@someone/weather", "version": "1.0.0", "description": "Weather"}`,
+	}, "--interactive")
+
+	assert.Contains(t, output, "Server name [io.github.someone/weather]: ")
+	assert.Contains(t, output, "Package identifier [docker.io/someone/weather:2.0.0]: ")
+
+	assert.Equal(t, "io.github.someone/weather", server.Name)
+	assert.Equal(t, "Better weather", server.Description)
+	assert.Equal(t, "2.0.0", server.Version)
+	assert.Equal(t, model.Repository{URL: "https://gitlab.com/someone/weather", Source: "gitlab"}, server.Repository)
+
+	pkg := server.Packages[0]
+	assert.Equal(t, model.RegistryTypeOCI, pkg.RegistryType)
+	assert.Equal(t, "docker.io/someone/weather:2.0.0", pkg.Identifier)
+	assert.Empty(t, pkg.Version)
+	assert.Equal(t, model.Transport{Type: model.TransportTypeStreamableHTTP, URL: "http://localhost:8080/mcp"}, pkg.Transport)
+}
+
+func TestInitCommand_Errors(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "server.json"), []byte("{}"), 0600))
+
+	err := commands.InitCommand([]string{"--dir", dir})
+	assert.ErrorContains(t, err, "server.json already exists")
+
+	err = commands.InitCommand([]string{"--dir", filepath.Join(dir, "missing")})
+	assert.ErrorContains(t, err, "is not a directory")
+}
+
+func TestInitCommand_FromClientConfig(t *testing.T) {
+	configDir := t.TempDir()
+	writeConfig := func(name, content string) string {
+		path := filepath.Join(configDir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		return path
+	}
+
+	claudeDesktop := writeConfig("claude_desktop_config.json", `{
+  "mcpServers": {
+    "filesystem": {
+      "command": "npx",
+      "args": ["-y", "@modelcontextprotocol/server-filesystem@2025.8.21", "--root", "/data", "/tmp"],
+      "env": {"LOG_LEVEL": "debug", "OPENAI_API_KEY": "sk-real-secret"}
+    },
+    "github": {
+      "command": "docker",
+      "args": ["run", "-i", "--rm", "-e", "GITHUB_PERSONAL_ACCESS_TOKEN", "-e", "GITHUB_TOOLSETS=repos", "ghcr.io/github/github-mcp-server:0.13.0", "stdio"],
+      "env": {"GITHUB_PERSONAL_ACCESS_TOKEN": "ghp_real"}
+    },
+    "remote": {"url": "https://example.com/mcp"}
+  }
+}`)
+
+	t.Run("npx", func(t *testing.T) {
+		server, output := runInit(t, nil, "--from", claudeDesktop, "--entry", "filesystem")
+		assert.Contains(t, output, `Detected npm package from server "filesystem" in claude_desktop_config.json`)
+		assert.Equal(t, "io.github.modelcontextprotocol/server-filesystem", server.Name)
+		assert.Equal(t, "2025.8.21", server.Version)
+
+		pkg := server.Packages[0]
+		assert.Equal(t, model.RegistryTypeNPM, pkg.RegistryType)
+		assert.Equal(t, "@modelcontextprotocol/server-filesystem", pkg.Identifier)
+		assert.Equal(t, "2025.8.21", pkg.Version)
+		assert.Equal(t, "npx", pkg.RunTimeHint)
+		assert.Empty(t, pkg.RuntimeArguments, "clients add -y themselves")
+		require.Len(t, pkg.PackageArguments, 2)
+		assert.Equal(t, model.ArgumentTypeNamed, pkg.PackageArguments[0].Type)
+		assert.Equal(t, "--root", pkg.PackageArguments[0].Name)
+		assert.Equal(t, "/data", pkg.PackageArguments[0].Value)
+		assert.Equal(t, model.ArgumentTypePositional, pkg.PackageArguments[1].Type)
+		assert.Equal(t, "/tmp", pkg.PackageArguments[1].Value)
+
+		require.Len(t, pkg.EnvironmentVariables, 2)
+		assert.Equal(t, "LOG_LEVEL", pkg.EnvironmentVariables[0].Name)
+		assert.Equal(t, "debug", pkg.EnvironmentVariables[0].Default)
+		assert.Equal(t, "OPENAI_API_KEY", pkg.EnvironmentVariables[1].Name)
+		assert.True(t, pkg.EnvironmentVariables[1].IsSecret)
+		assert.True(t, pkg.EnvironmentVariables[1].IsRequired)
+		assert.NotContains(t, output, "sk-real-secret")
+	})
+
+	t.Run("docker", func(t *testing.T) {
+		server, _ := runInit(t, nil, "--from", claudeDesktop, "--entry", "github")
+		assert.Equal(t, "0.13.0", server.Version)
+
+		pkg := server.Packages[0]
+		assert.Equal(t, model.RegistryTypeOCI, pkg.RegistryType)
+		assert.Equal(t, "ghcr.io/github/github-mcp-server:0.13.0", pkg.Identifier)
+		assert.Empty(t, pkg.Version)
+		assert.Equal(t, "docker", pkg.RunTimeHint)
+
+		// Variables are forwarded by name, and their values come from the environment variables
+		require.Len(t, pkg.RuntimeArguments, 2)
+		assert.Equal(t, "-e", pkg.RuntimeArguments[0].Name)
+		assert.Equal(t, "GITHUB_PERSONAL_ACCESS_TOKEN", pkg.RuntimeArguments[0].Value)
+		assert.Equal(t, "GITHUB_TOOLSETS", pkg.RuntimeArguments[1].Value)
+		require.Len(t, pkg.PackageArguments, 1)
+		assert.Equal(t, "stdio", pkg.PackageArguments[0].Value)
+
+		require.Len(t, pkg.EnvironmentVariables, 2)
+		assert.Equal(t, "GITHUB_PERSONAL_ACCESS_TOKEN", pkg.EnvironmentVariables[0].Name)
+		assert.True(t, pkg.EnvironmentVariables[0].IsSecret)
+		assert.Empty(t, pkg.EnvironmentVariables[0].Default)
+		assert.Equal(t, "GITHUB_TOOLSETS", pkg.EnvironmentVariables[1].Name)
+		assert.Equal(t, "repos", pkg.EnvironmentVariables[1].Default)
+	})
+
+	t.Run("VS Code inputs", func(t *testing.T) {
+		vscode := writeConfig("mcp.json", `{
+  // Servers for this workspace
+  "inputs": [
+    {"type": "promptString", "id": "api-key", "description": "Weather API key", "password": true},
+  ],
+  "servers": {
+    "weather": {
+      "type": "stdio",
+      "command": "uvx",
+      "args": ["--python", "3.12", "weather-mcp==0.3.1", "--token", "${input:api-key}"],
+      "env": {"WEATHER_KEY": "${input:api-key}"}
+    }
+  }
+}`)
+		server, _ := runInit(t, nil, "--from", vscode)
+
+		pkg := server.Packages[0]
+		assert.Equal(t, model.RegistryTypePyPI, pkg.RegistryType)
+		assert.Equal(t, "weather-mcp", pkg.Identifier)
+		assert.Equal(t, "0.3.1", pkg.Version)
+		assert.Equal(t, "uvx", pkg.RunTimeHint)
+		require.Len(t, pkg.RuntimeArguments, 1)
+		assert.Equal(t, "--python", pkg.RuntimeArguments[0].Name)
+		assert.Equal(t, "3.12", pkg.RuntimeArguments[0].Value)
+
+		require.Len(t, pkg.PackageArguments, 1)
+		token := pkg.PackageArguments[0]
+		assert.Equal(t, "--token", token.Name)
+		assert.Empty(t, token.Value)
+		assert.True(t, token.IsSecret)
+		assert.True(t, token.IsRequired)
+		assert.Equal(t, "Weather API key", token.Description)
+
+		require.Len(t, pkg.EnvironmentVariables, 1)
+		assert.Equal(t, "Weather API key", pkg.EnvironmentVariables[0].Description)
+		assert.True(t, pkg.EnvironmentVariables[0].IsSecret)
+		assert.Empty(t, pkg.EnvironmentVariables[0].Default)
+	})
+
+	t.Run("dnx on Windows", func(t *testing.T) {
+		path := writeConfig("windows.json", `{"mcpServers": {"weather": {"command": "cmd", "args": ["/c", "dnx", "Someone.Weather", "--version", "1.4.0", "--yes", "--", "--units", "metric"]}}}`)
+		server, _ := runInit(t, nil, "--from", path)
+
+		pkg := server.Packages[0]
+		assert.Equal(t, model.RegistryTypeNuGet, pkg.RegistryType)
+		assert.Equal(t, "Someone.Weather", pkg.Identifier)
+		assert.Equal(t, "1.4.0", pkg.Version)
+		assert.Empty(t, pkg.RuntimeArguments)
+		require.Len(t, pkg.PackageArguments, 1)
+		assert.Equal(t, "--units", pkg.PackageArguments[0].Name)
+		assert.Equal(t, "metric", pkg.PackageArguments[0].Value)
+		assert.Empty(t, pkg.EnvironmentVariables, "imported servers get no example variable")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		dir := t.TempDir()
+		tests := []struct {
+			args []string
+			want string
+		}{
+			{[]string{"--from", claudeDesktop}, "has several servers. Choose one with --entry: filesystem, github, remote"},
+			{[]string{"--from", claudeDesktop, "--entry", "missing"}, `no server named "missing"`},
+			{[]string{"--from", claudeDesktop, "--entry", "remote"}, `server "remote" has no command`},
+			{[]string{"--from", writeConfig("node.json", `{"mcpServers": {"local": {"command": "node", "args": ["index.js"]}}}`)}, `cannot tell the package type from command "node"`},
+			{[]string{"--entry", "github"}, "usage: mcp-publisher init"},
+		}
+		for _, tt := range tests {
+			err := commands.InitCommand(append([]string{"--dir", dir}, tt.args...))
+			assert.ErrorContains(t, err, tt.want)
+		}
+	})
+}
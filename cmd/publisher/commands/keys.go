@@ -0,0 +1,202 @@
+package commands
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/pkg/domainkey"
+)
+
+// DefaultKeyFile is where keygen writes the private key, and where verify-domain reads it from
+const DefaultKeyFile = "mcp-publisher.key"
+
+// maxKeyResponseSize matches the limit the registry applies when fetching the HTTP key record
+const maxKeyResponseSize = 4096
+
+// KeygenCommand writes a new Ed25519 private key for DNS and HTTP authentication, and prints the
+// records that publish its public key
+func KeygenCommand(args []string) error {
+	keygenFlags := flag.NewFlagSet("keygen", flag.ExitOnError)
+	var keyFile, domain string
+	var force bool
+	keygenFlags.StringVar(&keyFile, "output", DefaultKeyFile, "File to write the private key to")
+	keygenFlags.StringVar(&domain, "domain", "", "Domain to print the records for (default: a placeholder)")
+	keygenFlags.BoolVar(&force, "force", false, "Overwrite the key file if it exists")
+	if err := keygenFlags.Parse(args); err != nil {
+		return err
+	}
+	if keygenFlags.NArg() > 0 {
+		return errors.New("usage: mcp-publisher keygen [--output FILE] [--domain DOMAIN] [--force]")
+	}
+
+	seed, err := domainkey.GenerateSeed()
+	if err != nil {
+		return err
+	}
+	privateKey, err := domainkey.PrivateKeyFromSeed(seed)
+	if err != nil {
+		return err
+	}
+
+	fileFlags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if force {
+		fileFlags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	file, err := os.OpenFile(keyFile, fileFlags, 0600)
+	if err != nil {
+		if os.IsExist(err) {
+			return fmt.Errorf("%s already exists. Use --force to replace it, or --output to write another file", keyFile)
+		}
+		return fmt.Errorf("failed to write key file: %w", err)
+	}
+	if _, err := file.WriteString(seed + "\n"); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write key file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write key file: %w", err)
+	}
+
+	record := domainkey.Record(privateKey.Public().(ed25519.PublicKey))
+	_, _ = fmt.Fprintf(os.Stdout, "✓ Wrote private key to %s. Keep it secret: anyone with it can publish under your domain.\n\n", keyFile)
+	if domain == "" {
+		domain = "example.com"
+		_, _ = fmt.Fprintf(os.Stdout, "No --domain given, so %s stands in for your domain below.\n\n", domain)
+	}
+	_, _ = fmt.Fprintf(os.Stdout, "For DNS authentication, add this TXT record:\n  %s. IN TXT \"%s\"\n\n", domain, record)
+	_, _ = fmt.Fprintf(os.Stdout, "For HTTP authentication, serve this as text/plain at https://%s%s:\n  %s\n\n", domain, domainkey.WellKnownPath, record)
+	_, _ = fmt.Fprintf(os.Stdout, "Then check the record with 'mcp-publisher verify-domain --method dns --domain %s' and log in with:\n", domain)
+	_, _ = fmt.Fprintf(os.Stdout, "  mcp-publisher login dns --domain %s --private-key \"$(cat %s)\"\n", domain, keyFile)
+	return nil
+}
+
+// VerifyDomainCommand checks that a domain publishes the public key of a private key, the way the
+// registry checks it on login
+func VerifyDomainCommand(args []string) error {
+	verifyFlags := flag.NewFlagSet("verify-domain", flag.ExitOnError)
+	var method, domain, keyFile, privateKey string
+	verifyFlags.StringVar(&method, "method", "dns", "Authentication method to check: dns or http")
+	verifyFlags.StringVar(&domain, "domain", "", "Domain to check")
+	verifyFlags.StringVar(&keyFile, "key-file", "", "File holding the private key (default: $"+PrivateKeyEnvVar+", or "+DefaultKeyFile+")")
+	verifyFlags.StringVar(&privateKey, "private-key", "", "Private key (64-char hex)")
+	if err := verifyFlags.Parse(args); err != nil {
+		return err
+	}
+	if domain == "" || verifyFlags.NArg() > 0 {
+		return errors.New("usage: mcp-publisher verify-domain --domain DOMAIN [--method dns|http] [--key-file FILE | --private-key HEX]")
+	}
+	if method != "dns" && method != "http" {
+		return fmt.Errorf("unknown method %q: use dns or http", method)
+	}
+
+	seed, err := readPrivateKey(privateKey, keyFile)
+	if err != nil {
+		return err
+	}
+	key, err := domainkey.PrivateKeyFromSeed(seed)
+	if err != nil {
+		return fmt.Errorf("invalid private key: %w", err)
+	}
+	publicKey := key.Public().(ed25519.PublicKey)
+	record := domainkey.Record(publicKey)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var records []string
+	var where string
+	switch method {
+	case "dns":
+		where = "the DNS TXT records of " + domain
+		records, err = net.DefaultResolver.LookupTXT(ctx, domain)
+		if err != nil {
+			return fmt.Errorf("failed to look up %s: %w\nAdd this TXT record:\n  %s. IN TXT \"%s\"", where, err, domain, record)
+		}
+	case "http":
+		where = "https://" + domain + domainkey.WellKnownPath
+		body, err := fetchKeyRecord(ctx, where)
+		if err != nil {
+			return fmt.Errorf("failed to fetch %s: %w\nServe this as text/plain:\n  %s", where, err, record)
+		}
+		records = []string{body}
+	}
+
+	keys := domainkey.ParseMCPKeysFromStrings(records)
+	for _, found := range keys {
+		if found.Equal(publicKey) {
+			_, _ = fmt.Fprintf(os.Stdout, "✓ %s has the public key of your private key\n", where)
+			_, _ = fmt.Fprintf(os.Stdout, "You can log in with 'mcp-publisher login %s --domain %s'\n", method, domain)
+			return nil
+		}
+	}
+
+	if len(keys) == 0 {
+		return fmt.Errorf("no MCP key record found in %s. Add:\n  %s", where, record)
+	}
+	return fmt.Errorf("%s has %d MCP key record(s), but none matches your private key. Add:\n  %s", where, len(keys), record)
+}
+
+// readPrivateKey returns the hex seed given on the command line, from a key file, or from the environment
+func readPrivateKey(privateKey, keyFile string) (string, error) {
+	if privateKey != "" {
+		return privateKey, nil
+	}
+	if keyFile == "" {
+		if fromEnv := os.Getenv(PrivateKeyEnvVar); fromEnv != "" {
+			return fromEnv, nil
+		}
+		keyFile = DefaultKeyFile
+	}
+
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%s not found. Run 'mcp-publisher keygen' to create a key, or give it with --key-file or --private-key", keyFile)
+		}
+		return "", fmt.Errorf("failed to read key file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// fetchKeyRecord fetches the HTTP key record without following redirects, as the registry does
+func fetchKeyRecord(ctx context.Context, url string) (string, error) {
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "text/plain")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("server returned status %d (redirects are not followed)", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxKeyResponseSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(body) > maxKeyResponseSize {
+		return "", fmt.Errorf("response is larger than %d bytes", maxKeyResponseSize)
+	}
+	return strings.TrimSpace(string(body)), nil
+}
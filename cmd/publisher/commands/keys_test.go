@@ -0,0 +1,74 @@
+package commands_test
+
+import (
+	"crypto/ed25519"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	"github.com/modelcontextprotocol/registry/pkg/domainkey"
+)
+
+func TestKeygenCommand(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "example.key")
+
+	var err error
+	output := captureStdout(t, func() { err = commands.KeygenCommand([]string{"--output", keyFile, "--domain", "example.com"}) })
+	require.NoError(t, err)
+
+	info, err := os.Stat(keyFile)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	seed, err := os.ReadFile(keyFile)
+	require.NoError(t, err)
+	privateKey, err := domainkey.PrivateKeyFromSeed(string(seed))
+	require.NoError(t, err)
+	record := domainkey.Record(privateKey.Public().(ed25519.PublicKey))
+
+	assert.Contains(t, output, `example.com. IN TXT "`+record+`"`)
+	assert.Contains(t, output, "https://example.com/.well-known/mcp-registry-auth:\n  "+record+"\n")
+	assert.Contains(t, output, `mcp-publisher login dns --domain example.com --private-key "$(cat `+keyFile+`)"`)
+
+	// The printed record parses the way the registry parses it
+	printed := regexp.MustCompile(`v=MCPv1; k=ed25519; p=\S+`).FindAllString(output, -1)
+	keys := domainkey.ParseMCPKeysFromStrings(printed)
+	require.Len(t, keys, 2)
+	assert.True(t, keys[0].Equal(privateKey.Public()))
+
+	t.Run("does not overwrite a key", func(t *testing.T) {
+		err := commands.KeygenCommand([]string{"--output", keyFile})
+		assert.ErrorContains(t, err, "already exists")
+
+		unchanged, err := os.ReadFile(keyFile)
+		require.NoError(t, err)
+		assert.Equal(t, seed, unchanged)
+
+		captureStdout(t, func() { require.NoError(t, commands.KeygenCommand([]string{"--output", keyFile, "--force"})) })
+		replaced, err := os.ReadFile(keyFile)
+		require.NoError(t, err)
+		assert.NotEqual(t, seed, replaced)
+	})
+}
+
+func TestVerifyDomainCommand_Arguments(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(commands.PrivateKeyEnvVar, "")
+
+	err := commands.VerifyDomainCommand(nil)
+	assert.ErrorContains(t, err, "usage: mcp-publisher verify-domain --domain DOMAIN")
+
+	err = commands.VerifyDomainCommand([]string{"--domain", "example.com", "--method", "github"})
+	assert.ErrorContains(t, err, `unknown method "github"`)
+
+	err = commands.VerifyDomainCommand([]string{"--domain", "example.com"})
+	assert.ErrorContains(t, err, "mcp-publisher.key not found. Run 'mcp-publisher keygen'")
+
+	err = commands.VerifyDomainCommand([]string{"--domain", "example.com", "--private-key", "abcd"})
+	assert.ErrorContains(t, err, "invalid private key")
+}
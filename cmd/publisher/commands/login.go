@@ -1,13 +1,12 @@
 package commands
 
 import (
+	"cmp"
 	"context"
-	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"os"
-	"path/filepath"
 
 	"github.com/modelcontextprotocol/registry/cmd/publisher/auth"
 )
@@ -15,53 +14,69 @@ import (
 const (
 	DefaultRegistryURL = "https://registry.modelcontextprotocol.io"
 	TokenFileName      = ".mcp_publisher_token" //nolint:gosec // Not a credential, just a filename
+	// PrivateKeyEnvVar holds the dns and http private key, so expired tokens can be refreshed without --private-key
+	PrivateKeyEnvVar = "MCP_PUBLISHER_PRIVATE_KEY" //nolint:gosec // Not a credential, just a variable name
 )
 
+// errNotAuthenticated is returned by loadToken when login has not saved a token
+var errNotAuthenticated = errors.New("not authenticated. Run 'mcp-publisher login <method>' first")
+
 func LoginCommand(args []string) error {
 	if len(args) < 1 {
-		return errors.New("authentication method required\n\nUsage: mcp-publisher login <method>\n\nMethods:\n  github        Interactive GitHub authentication\n  github-oidc   GitHub Actions OIDC authentication\n  dns           DNS-based authentication (requires --domain and --private-key)\n  http          HTTP-based authentication (requires --domain and --private-key)\n  none          Anonymous authentication (for testing)")
+		return errors.New("authentication method required\n\nUsage: mcp-publisher login <method> [--profile NAME] [--registry URL]\n\nMethods:\n  github        Interactive GitHub authentication\n  github-oidc   GitHub Actions OIDC authentication\n  oidc          Authentication with any OIDC issuer the registry trusts (requires --issuer)\n  dns           DNS-based authentication (requires --domain and --private-key)\n  http          HTTP-based authentication (requires --domain and --private-key)\n  none          Anonymous authentication (for testing)")
 	}
 
 	method := args[0]
 
 	// Parse remaining flags based on method
 	loginFlags := flag.NewFlagSet("login", flag.ExitOnError)
-	var domain string
+	var params Profile
 	var privateKey string
-	var registryURL string
+	var profileName string
 
-	loginFlags.StringVar(&registryURL, "registry", DefaultRegistryURL, "Registry URL")
+	loginFlags.StringVar(&params.Registry, "registry", "", "Registry URL (default: the profile's registry, or "+DefaultRegistryURL+")")
+	loginFlags.StringVar(&profileName, "profile", "", "Profile to save the token in (default: $"+ProfileEnvVar+", or the current profile)")
 
-	if method == "dns" || method == "http" {
-		loginFlags.StringVar(&domain, "domain", "", "Domain name")
-		loginFlags.StringVar(&privateKey, "private-key", "", "Private key (64-char hex)")
+	switch method {
+	case "dns", "http":
+		loginFlags.StringVar(&params.Domain, "domain", "", "Domain name")
+		loginFlags.StringVar(&privateKey, "private-key", "", "Private key (64-char hex, default: $"+PrivateKeyEnvVar+")")
+	case "oidc":
+		loginFlags.StringVar(&params.Issuer, "issuer", "", "OIDC issuer URL")
+		loginFlags.StringVar(&params.ClientID, "client-id", "", "OAuth client ID for the device flow")
+		loginFlags.StringVar(&params.IDTokenEnv, "id-token-from-env", "", "Read the ID token from this environment variable instead of running the device flow")
 	}
 
 	if err := loginFlags.Parse(args[1:]); err != nil {
 		return err
 	}
 
-	// Create auth provider based on method
-	var authProvider auth.Provider
-	switch method {
-	case "github":
-		authProvider = auth.NewGitHubATProvider(true, registryURL)
-	case "github-oidc":
-		authProvider = auth.NewGitHubOIDCProvider(registryURL)
-	case "dns":
-		if domain == "" || privateKey == "" {
-			return errors.New("dns authentication requires --domain and --private-key")
-		}
-		authProvider = auth.NewDNSProvider(registryURL, domain, privateKey)
-	case "http":
-		if domain == "" || privateKey == "" {
-			return errors.New("http authentication requires --domain and --private-key")
-		}
-		authProvider = auth.NewHTTPProvider(registryURL, domain, privateKey)
-	case "none":
-		authProvider = auth.NewNoneProvider(registryURL)
-	default:
-		return fmt.Errorf("unknown authentication method: %s\nFor a list of available methods, run: mcp-publisher login", method)
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	profileName = config.profileName(profileName)
+	profile := config.profile(profileName)
+
+	// Parameters not given are taken from an earlier login of the profile with the same method
+	params.Method = method
+	if params.Registry == "" {
+		params.Registry = profile.registryURL()
+	}
+	if profile.Method == method {
+		params.Domain = cmp.Or(params.Domain, profile.Domain)
+		params.Issuer = cmp.Or(params.Issuer, profile.Issuer)
+		params.ClientID = cmp.Or(params.ClientID, profile.ClientID)
+		params.IDTokenEnv = cmp.Or(params.IDTokenEnv, profile.IDTokenEnv)
+	}
+
+	if privateKey == "" {
+		privateKey = os.Getenv(PrivateKeyEnvVar)
+	}
+
+	authProvider, err := newAuthProvider(&params, privateKey, true)
+	if err != nil {
+		return err
 	}
 
 	// Perform login
@@ -78,28 +93,47 @@ func LoginCommand(args []string) error {
 		return fmt.Errorf("failed to get token: %w", err)
 	}
 
-	// Save token to file
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
-	}
-
-	tokenPath := filepath.Join(homeDir, TokenFileName)
-	tokenData := map[string]string{
-		"token":    token,
-		"method":   method,
-		"registry": registryURL,
+	params.Token = token
+	params.ExpiresAt = tokenExpiry(token)
+	*profile = params
+	if err := config.save(); err != nil {
+		return err
 	}
 
-	jsonData, err := json.Marshal(tokenData)
-	if err != nil {
-		return fmt.Errorf("failed to marshal token data: %w", err)
-	}
+	_, _ = fmt.Fprintf(os.Stdout, "✓ Successfully logged in to %s (profile %s)\n", params.Registry, profileName)
+	return nil
+}
 
-	if err := os.WriteFile(tokenPath, jsonData, 0600); err != nil {
-		return fmt.Errorf("failed to save token: %w", err)
+// newAuthProvider creates the auth provider for the login method and parameters saved in a profile.
+// forceLogin makes the github method run the device flow again rather than reuse the saved GitHub token.
+func newAuthProvider(params *Profile, privateKey string, forceLogin bool) (auth.Provider, error) {
+	registryURL := params.registryURL()
+	switch params.Method {
+	case "github":
+		return auth.NewGitHubATProvider(forceLogin, registryURL), nil
+	case "github-oidc":
+		return auth.NewGitHubOIDCProvider(registryURL), nil
+	case "oidc":
+		if params.Issuer == "" {
+			return nil, errors.New("oidc authentication requires --issuer")
+		}
+		if params.ClientID == "" && params.IDTokenEnv == "" {
+			return nil, errors.New("oidc authentication requires --client-id for the device flow, or --id-token-from-env")
+		}
+		return auth.NewOIDCProvider(registryURL, params.Issuer, params.ClientID, params.IDTokenEnv), nil
+	case "dns":
+		if params.Domain == "" || privateKey == "" {
+			return nil, errors.New("dns authentication requires --domain and --private-key")
+		}
+		return auth.NewDNSProvider(registryURL, params.Domain, privateKey), nil
+	case "http":
+		if params.Domain == "" || privateKey == "" {
+			return nil, errors.New("http authentication requires --domain and --private-key")
+		}
+		return auth.NewHTTPProvider(registryURL, params.Domain, privateKey), nil
+	case "none":
+		return auth.NewNoneProvider(registryURL), nil
+	default:
+		return nil, fmt.Errorf("unknown authentication method: %s\nFor a list of available methods, run: mcp-publisher login", params.Method)
 	}
-
-	_, _ = fmt.Fprintln(os.Stdout, "✓ Successfully logged in")
-	return nil
 }
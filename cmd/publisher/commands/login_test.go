@@ -0,0 +1,129 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+)
+
+// newFakeIssuer returns an OIDC issuer supporting the device flow, which issues idToken once the
+// token endpoint has been polled a second time
+func newFakeIssuer(t *testing.T, clientID, idToken string) *httptest.Server {
+	t.Helper()
+	polls := 0
+	var issuer *httptest.Server
+	issuer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"issuer":                        issuer.URL,
+				"device_authorization_endpoint": issuer.URL + "/device",
+				"token_endpoint":                issuer.URL + "/token",
+			})
+		case "/device":
+			assert.Equal(t, clientID, r.PostFormValue("client_id"))
+			assert.Equal(t, "openid", r.PostFormValue("scope"))
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"device_code":      "device-code",
+				"user_code":        "ABCD-EFGH",
+				"verification_uri": issuer.URL + "/activate",
+				"expires_in":       60,
+				"interval":         1,
+			})
+		case "/token":
+			assert.Equal(t, "urn:ietf:params:oauth:grant-type:device_code", r.PostFormValue("grant_type"))
+			assert.Equal(t, "device-code", r.PostFormValue("device_code"))
+			polls++
+			if polls == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "authorization_pending"})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"id_token": idToken, "token_type": "Bearer"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(issuer.Close)
+	return issuer
+}
+
+// newOIDCRegistry returns a registry exchanging idToken for a registry token
+func newOIDCRegistry(t *testing.T, idToken string) *httptest.Server {
+	t.Helper()
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var input struct {
+			OIDCToken string `json:"oidc_token"`
+		}
+		if r.URL.Path != "/v0/auth/oidc" || json.NewDecoder(r.Body).Decode(&input) != nil || input.OIDCToken != idToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"registry_token": "registry-token", "expires_at": 0})
+	}))
+	t.Cleanup(registry.Close)
+	return registry
+}
+
+func TestLoginCommand_OIDC(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(commands.ProfileEnvVar, "")
+
+	savedProfile := func() commands.Profile {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(home, commands.ConfigFileName))
+		require.NoError(t, err)
+		var config struct {
+			Profiles map[string]commands.Profile `json:"profiles"`
+		}
+		require.NoError(t, json.Unmarshal(data, &config))
+		return config.Profiles["enterprise"]
+	}
+
+	t.Run("device flow", func(t *testing.T) {
+		issuer := newFakeIssuer(t, "publisher-cli", "device-id-token")
+		registry := newOIDCRegistry(t, "device-id-token")
+
+		var err error
+		output := captureStdout(t, func() {
+			err = commands.LoginCommand([]string{"oidc", "--issuer", issuer.URL, "--client-id", "publisher-cli", "--registry", registry.URL, "--profile", "enterprise"})
+		})
+		require.NoError(t, err)
+		assert.Contains(t, output, "Go to: "+issuer.URL+"/activate")
+		assert.Contains(t, output, "Enter code: ABCD-EFGH")
+		assert.Equal(t, commands.Profile{Registry: registry.URL, Method: "oidc", Issuer: issuer.URL, ClientID: "publisher-cli", Token: "registry-token"}, savedProfile())
+	})
+
+	t.Run("ID token from environment", func(t *testing.T) {
+		registry := newOIDCRegistry(t, "ci-id-token")
+		t.Setenv("CI_ID_TOKEN", "ci-id-token")
+
+		captureStdout(t, func() {
+			require.NoError(t, commands.LoginCommand([]string{"oidc", "--issuer", "https://issuer.example.com", "--id-token-from-env", "CI_ID_TOKEN", "--registry", registry.URL, "--profile", "enterprise"}))
+		})
+		assert.Equal(t, commands.Profile{Registry: registry.URL, Method: "oidc", Issuer: "https://issuer.example.com", ClientID: "publisher-cli", IDTokenEnv: "CI_ID_TOKEN", Token: "registry-token"}, savedProfile())
+
+		t.Setenv("CI_ID_TOKEN", "")
+		var err error
+		captureStdout(t, func() {
+			err = commands.LoginCommand([]string{"oidc", "--registry", registry.URL, "--profile", "enterprise"})
+		})
+		assert.ErrorContains(t, err, "CI_ID_TOKEN environment variable is empty", "parameters are reused from the profile")
+	})
+
+	t.Run("requires an issuer", func(t *testing.T) {
+		err := commands.LoginCommand([]string{"oidc", "--client-id", "publisher-cli", "--profile", "other"})
+		assert.ErrorContains(t, err, "oidc authentication requires --issuer")
+	})
+}
@@ -1,43 +1,61 @@
 package commands
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 )
 
-func LogoutCommand() error {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+func LogoutCommand(args []string) error {
+	logoutFlags := flag.NewFlagSet("logout", flag.ExitOnError)
+	var profileName string
+	logoutFlags.StringVar(&profileName, "profile", "", "Profile to log out of (default: $"+ProfileEnvVar+", or the current profile)")
+	if err := logoutFlags.Parse(args); err != nil {
+		return err
 	}
 
-	tokenPath := filepath.Join(homeDir, TokenFileName)
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	profileName = config.profileName(profileName)
 
-	// Check if token file exists
-	if _, err := os.Stat(tokenPath); os.IsNotExist(err) {
+	profile, ok := config.Profiles[profileName]
+	if !ok || profile.Token == "" {
 		_, _ = fmt.Fprintln(os.Stdout, "Not logged in")
 		return nil
 	}
 
-	// Remove token file
-	if err := os.Remove(tokenPath); err != nil {
-		return fmt.Errorf("failed to remove token: %w", err)
+	// The profile keeps its registry and login method so logging in again is quicker
+	profile.Token = ""
+	profile.ExpiresAt = time.Time{}
+	if err := config.save(); err != nil {
+		return err
 	}
 
-	// Also clean up legacy token files if they exist
-	legacyFiles := []string{
-		".mcpregistry_github_token",
-		".mcpregistry_registry_token",
-	}
+	if profileName == DefaultProfile {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to get home directory: %w", err)
+		}
+
+		// Also clean up token files from earlier versions if they exist
+		legacyFiles := []string{
+			TokenFileName,
+			".mcpregistry_github_token",
+			".mcpregistry_registry_token",
+		}
 
-	for _, file := range legacyFiles {
-		path := filepath.Join(homeDir, file)
-		if _, err := os.Stat(path); err == nil {
-			os.Remove(path) // Ignore errors for legacy files
+		for _, file := range legacyFiles {
+			path := filepath.Join(homeDir, file)
+			if _, err := os.Stat(path); err == nil {
+				os.Remove(path) // Ignore errors for legacy files
+			}
 		}
 	}
 
-	_, _ = fmt.Fprintln(os.Stdout, "✓ Successfully logged out")
+	_, _ = fmt.Fprintf(os.Stdout, "✓ Successfully logged out of profile %s\n", profileName)
 	return nil
 }
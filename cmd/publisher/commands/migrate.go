@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/schema"
+)
+
+// MigrateCommand rewrites server.json files written against earlier schema versions to the current schema
+func MigrateCommand(args []string) error {
+	files := args
+	if len(files) == 0 {
+		files = []string{"server.json"}
+	}
+
+	for _, file := range files {
+		if strings.HasPrefix(file, "-") {
+			return fmt.Errorf("unknown flag: %s\n\nUsage: mcp-publisher migrate [server.json ...]", file)
+		}
+		if err := migrateFile(file); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+func migrateFile(file string) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var serverJSON apiv0.ServerJSON
+	if err := json.Unmarshal(data, &serverJSON); err != nil {
+		return fmt.Errorf("invalid server.json: %w", err)
+	}
+	if !schema.NeedsUpgrade(serverJSON.Schema) {
+		if schema.Version(serverJSON.Schema) == model.CurrentSchemaVersion {
+			_, _ = fmt.Fprintf(os.Stdout, "✓ %s already uses schema version %s\n", file, model.CurrentSchemaVersion)
+			return nil
+		}
+		return fmt.Errorf("cannot migrate from schema %q; set $schema to %s", serverJSON.Schema, model.CurrentSchemaURL)
+	}
+
+	upgraded, version, err := schema.Upgrade(data)
+	if err != nil {
+		return err
+	}
+
+	// Round-trip through ServerJSON so the file keeps the usual field order
+	serverJSON = apiv0.ServerJSON{}
+	if err := json.Unmarshal(upgraded, &serverJSON); err != nil {
+		return fmt.Errorf("failed to read upgraded server.json: %w", err)
+	}
+	migrated, err := json.MarshalIndent(serverJSON, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode server.json: %w", err)
+	}
+	if err := os.WriteFile(file, append(migrated, '\n'), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "✓ Migrated %s from schema version %s to %s\n", file, version, model.CurrentSchemaVersion)
+	return nil
+}
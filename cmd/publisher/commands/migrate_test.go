@@ -0,0 +1,61 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestMigrateCommand(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("rewrites earlier schema versions", func(t *testing.T) {
+		file := filepath.Join(dir, "server.json")
+		require.NoError(t, os.WriteFile(file, []byte(`{
+			"$schema": "https://static.modelcontextprotocol.io/schemas/2025-07-09/server.schema.json",
+			"name": "io.github.example/weather",
+			"description": "Weather server",
+			"status": "active",
+			"version": "1.0.0",
+			"packages": [{"registry_type": "npm", "identifier": "@example/weather", "version": "1.0.0", "transport": {"type": "stdio"}}]
+		}`), 0o600))
+
+		require.NoError(t, commands.MigrateCommand([]string{file}))
+
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		var serverJSON apiv0.ServerJSON
+		require.NoError(t, json.Unmarshal(data, &serverJSON))
+		assert.Equal(t, model.CurrentSchemaURL, serverJSON.Schema)
+		require.Len(t, serverJSON.Packages, 1)
+		assert.Equal(t, model.RegistryTypeNPM, serverJSON.Packages[0].RegistryType)
+		assert.NotContains(t, string(data), "status")
+	})
+
+	t.Run("leaves current files alone", func(t *testing.T) {
+		file := filepath.Join(dir, "current.json")
+		original := `{"$schema": "` + model.CurrentSchemaURL + `", "name": "io.github.example/weather"}`
+		require.NoError(t, os.WriteFile(file, []byte(original), 0o600))
+
+		require.NoError(t, commands.MigrateCommand([]string{file}))
+
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, original, string(data))
+	})
+
+	t.Run("rejects unknown schemas", func(t *testing.T) {
+		file := filepath.Join(dir, "custom.json")
+		require.NoError(t, os.WriteFile(file, []byte(`{"$schema": "https://example.com/custom.schema.json"}`), 0o600))
+
+		assert.ErrorContains(t, commands.MigrateCommand([]string{file}), "cannot migrate")
+	})
+}
@@ -0,0 +1,266 @@
+package commands
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	// ConfigFileName is the publisher config file in the home directory, holding every profile
+	ConfigFileName = ".mcp_publisher_config.json"
+	// ProfileEnvVar selects the profile when --profile is not given
+	ProfileEnvVar = "MCP_PUBLISHER_PROFILE"
+	// DefaultProfile is used when no profile is selected
+	DefaultProfile = "default"
+)
+
+// Profile is a registry the publisher logs in to, with the login parameters and token saved for it
+type Profile struct {
+	Registry string `json:"registry"`
+	Method   string `json:"method,omitempty"`
+	// Domain is the domain used by dns and http logins. Private keys are never saved.
+	Domain string `json:"domain,omitempty"`
+	// Issuer, ClientID and IDTokenEnv are the parameters of oidc logins
+	Issuer     string    `json:"issuer,omitempty"`
+	ClientID   string    `json:"clientId,omitempty"`
+	IDTokenEnv string    `json:"idTokenFromEnv,omitempty"`
+	Token      string    `json:"token,omitempty"`
+	ExpiresAt  time.Time `json:"expiresAt,omitzero"`
+}
+
+// publisherConfig is the contents of the config file
+type publisherConfig struct {
+	Current  string              `json:"current,omitempty"`
+	Profiles map[string]*Profile `json:"profiles"`
+}
+
+func configPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ConfigFileName), nil
+}
+
+// loadConfig reads the config file, returning an empty config if there is none yet.
+// A token saved by earlier versions in the legacy token file is read as the default profile.
+func loadConfig() (*publisherConfig, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+
+	config := &publisherConfig{Profiles: map[string]*Profile{}}
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, config); err != nil {
+			return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+		if config.Profiles == nil {
+			config.Profiles = map[string]*Profile{}
+		}
+	case !os.IsNotExist(err):
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if _, ok := config.Profiles[DefaultProfile]; !ok {
+		legacy, err := loadLegacyToken()
+		if err != nil {
+			return nil, err
+		}
+		if legacy != nil {
+			config.Profiles[DefaultProfile] = legacy
+		}
+	}
+	return config, nil
+}
+
+// loadLegacyToken reads the single token file written by earlier versions, returning nil if there is none
+func loadLegacyToken() (*Profile, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	tokenData, err := os.ReadFile(filepath.Join(homeDir, TokenFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read token: %w", err)
+	}
+
+	var tokenInfo map[string]string
+	if err := json.Unmarshal(tokenData, &tokenInfo); err != nil {
+		return nil, fmt.Errorf("invalid token data: %w", err)
+	}
+	return &Profile{
+		Registry:  tokenInfo["registry"],
+		Method:    tokenInfo["method"],
+		Token:     tokenInfo["token"],
+		ExpiresAt: tokenExpiry(tokenInfo["token"]),
+	}, nil
+}
+
+// save writes the config file, readable only by the user as it holds tokens
+func (c *publisherConfig) save() error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	return nil
+}
+
+// profileName returns the selected profile: the --profile flag, then the environment variable,
+// then the current profile set with 'mcp-publisher profiles use'
+func (c *publisherConfig) profileName(flagValue string) string {
+	switch {
+	case flagValue != "":
+		return flagValue
+	case os.Getenv(ProfileEnvVar) != "":
+		return os.Getenv(ProfileEnvVar)
+	case c.Current != "":
+		return c.Current
+	default:
+		return DefaultProfile
+	}
+}
+
+// profile returns a profile, creating it if it does not exist yet
+func (c *publisherConfig) profile(name string) *Profile {
+	profile, ok := c.Profiles[name]
+	if !ok {
+		profile = &Profile{}
+		c.Profiles[name] = profile
+	}
+	return profile
+}
+
+// registryURL returns the profile's registry, or the official registry if it has none
+func (p *Profile) registryURL() string {
+	if p.Registry == "" {
+		return DefaultRegistryURL
+	}
+	return p.Registry
+}
+
+// loadToken returns the token saved by login for a profile and the registry it was issued by.
+// The profile is selected as described on profileName. When the profile is not logged in the
+// error is errNotAuthenticated, and the profile's registry is still returned.
+func loadToken(profileFlag string) (token, registryURL string, err error) {
+	config, err := loadConfig()
+	if err != nil {
+		return "", "", err
+	}
+
+	name := config.profileName(profileFlag)
+	profile, ok := config.Profiles[name]
+	if !ok {
+		profile = &Profile{}
+	}
+	if profile.Token == "" {
+		if name != DefaultProfile {
+			return "", profile.registryURL(), fmt.Errorf("%w for profile %q", errNotAuthenticated, name)
+		}
+		return "", profile.registryURL(), errNotAuthenticated
+	}
+	return profile.Token, profile.registryURL(), nil
+}
+
+// tokenExpiry returns when a registry JWT expires, or the zero time if it cannot tell
+func tokenExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+	var claims struct {
+		ExpiresAt int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.ExpiresAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.ExpiresAt, 0).UTC()
+}
+
+// ProfilesCommand lists the saved profiles, or switches the current one
+func ProfilesCommand(args []string) error {
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case len(args) == 0 || (len(args) == 1 && args[0] == "list"):
+		return printProfiles(config)
+	case len(args) == 2 && args[0] == "use":
+		name := args[1]
+		if _, ok := config.Profiles[name]; !ok {
+			return fmt.Errorf("no profile named %q. Run 'mcp-publisher login <method> --profile %s' to create it", name, name)
+		}
+		config.Current = name
+		if err := config.save(); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(os.Stdout, "✓ Now using profile %s (%s)\n", name, config.Profiles[name].registryURL())
+		return nil
+	default:
+		return errors.New("usage: mcp-publisher profiles [list | use <name>]")
+	}
+}
+
+func printProfiles(config *publisherConfig) error {
+	if len(config.Profiles) == 0 {
+		_, _ = fmt.Fprintln(os.Stdout, "No profiles yet. Run 'mcp-publisher login <method>' to create one")
+		return nil
+	}
+
+	names := make([]string, 0, len(config.Profiles))
+	for name := range config.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	selected := config.profileName("")
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "\tPROFILE\tREGISTRY\tMETHOD\tLOGGED IN")
+	for _, name := range names {
+		profile := config.Profiles[name]
+		marker := ""
+		if name == selected {
+			marker = "*"
+		}
+		loggedIn := "no"
+		switch {
+		case profile.Token != "" && !profile.ExpiresAt.IsZero() && time.Now().After(profile.ExpiresAt):
+			loggedIn = "expired"
+		case profile.Token != "":
+			loggedIn = "yes"
+		}
+		method := profile.Method
+		if method == "" {
+			method = "-"
+		}
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", marker, name, profile.registryURL(), method, loggedIn)
+	}
+	return writer.Flush()
+}
@@ -0,0 +1,135 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// newProfileRegistry returns a registry issuing token for anonymous logins, which records the
+// token each server list request was sent with
+func newProfileRegistry(t *testing.T, token string, authorizations *[]string) *httptest.Server {
+	t.Helper()
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v0/auth/none":
+			_ = json.NewEncoder(w).Encode(map[string]any{"registry_token": token, "expires_at": 0})
+		case "/v0/servers":
+			*authorizations = append(*authorizations, r.Header.Get("Authorization"))
+			_ = json.NewEncoder(w).Encode(apiv0.ServerListResponse{})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(registry.Close)
+	return registry
+}
+
+func TestProfiles(t *testing.T) {
+	var productionRequests, stagingRequests []string
+	production := newProfileRegistry(t, "production-token", &productionRequests)
+	staging := newProfileRegistry(t, "staging-token", &stagingRequests)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(commands.ProfileEnvVar, "")
+
+	search := func(args ...string) {
+		t.Helper()
+		captureStdout(t, func() { require.NoError(t, commands.SearchCommand(append([]string{"weather"}, args...))) })
+	}
+
+	// Logging in to staging does not replace the production token
+	captureStdout(t, func() { require.NoError(t, commands.LoginCommand([]string{"none", "--registry", production.URL})) })
+	captureStdout(t, func() {
+		require.NoError(t, commands.LoginCommand([]string{"none", "--registry", staging.URL, "--profile", "staging"}))
+	})
+
+	configData, err := os.ReadFile(filepath.Join(home, commands.ConfigFileName))
+	require.NoError(t, err)
+	var config struct {
+		Profiles map[string]commands.Profile `json:"profiles"`
+	}
+	require.NoError(t, json.Unmarshal(configData, &config))
+	assert.Equal(t, commands.Profile{Registry: production.URL, Method: "none", Token: "production-token"}, config.Profiles["default"])
+	assert.Equal(t, commands.Profile{Registry: staging.URL, Method: "none", Token: "staging-token"}, config.Profiles["staging"])
+	info, err := os.Stat(filepath.Join(home, commands.ConfigFileName))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	t.Run("list", func(t *testing.T) {
+		var err error
+		output := captureStdout(t, func() { err = commands.ProfilesCommand(nil) })
+		require.NoError(t, err)
+		assert.Regexp(t, `\*\s+default\s+`+production.URL+`\s+none\s+yes`, output)
+		assert.Regexp(t, `\n\s+staging\s+`+staging.URL+`\s+none\s+yes`, output)
+	})
+
+	t.Run("flag and environment variable select the profile", func(t *testing.T) {
+		search()
+		assert.Equal(t, []string{"Bearer production-token"}, productionRequests)
+
+		search("--profile", "staging")
+		assert.Equal(t, []string{"Bearer staging-token"}, stagingRequests)
+
+		t.Setenv(commands.ProfileEnvVar, "staging")
+		search()
+		assert.Len(t, stagingRequests, 2)
+		search("--profile", "default")
+		assert.Len(t, productionRequests, 2)
+	})
+
+	t.Run("use switches the current profile", func(t *testing.T) {
+		var err error
+		output := captureStdout(t, func() { err = commands.ProfilesCommand([]string{"use", "staging"}) })
+		require.NoError(t, err)
+		assert.Contains(t, output, "Now using profile staging")
+
+		search()
+		assert.Len(t, stagingRequests, 3)
+
+		err = commands.ProfilesCommand([]string{"use", "missing"})
+		assert.ErrorContains(t, err, `no profile named "missing"`)
+	})
+
+	t.Run("logout clears only the selected profile", func(t *testing.T) {
+		captureStdout(t, func() { require.NoError(t, commands.LogoutCommand([]string{"--profile", "staging"})) })
+
+		search()
+		assert.Equal(t, "", stagingRequests[len(stagingRequests)-1], "the profile registry is kept without a token")
+
+		serverFile := filepath.Join(home, "server.json")
+		require.NoError(t, os.WriteFile(serverFile, []byte(`{"name": "io.github.example/weather"}`), 0600))
+		err := commands.PublishCommand([]string{serverFile, "--profile", "staging"})
+		assert.ErrorContains(t, err, `not authenticated`)
+
+		search("--profile", "default")
+		assert.Equal(t, "Bearer production-token", productionRequests[len(productionRequests)-1])
+	})
+}
+
+func TestProfiles_LegacyTokenFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(commands.ProfileEnvVar, "")
+	tokenData, err := json.Marshal(map[string]string{"token": "legacy-token", "method": "github", "registry": "https://staging.example.com"})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(home, commands.TokenFileName), tokenData, 0600))
+
+	output := captureStdout(t, func() { require.NoError(t, commands.ProfilesCommand([]string{"list"})) })
+	assert.Regexp(t, `\*\s+default\s+https://staging\.example\.com\s+github\s+yes`, output)
+
+	captureStdout(t, func() { require.NoError(t, commands.LogoutCommand(nil)) })
+	assert.NoFileExists(t, filepath.Join(home, commands.TokenFileName))
+	output = captureStdout(t, func() { require.NoError(t, commands.ProfilesCommand(nil)) })
+	assert.Regexp(t, `default\s+https://staging\.example\.com\s+github\s+no`, output)
+}
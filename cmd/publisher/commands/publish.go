@@ -4,32 +4,36 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
-	"path/filepath"
 	"strings"
 
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/schema"
 )
 
 func PublishCommand(args []string) error {
-	// Check for server.json file
-	serverFile := "server.json"
-	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
-		serverFile = args[0]
+	publishFlags := flag.NewFlagSet("publish", flag.ExitOnError)
+	var dryRun bool
+	var profile string
+	publishFlags.BoolVar(&dryRun, "dry-run", false, "Run every publish check and print the report without publishing")
+	publishFlags.StringVar(&profile, "profile", "", "Profile to publish with (default: $"+ProfileEnvVar+", or the current profile)")
+	serverFile, err := parseWithOptionalArgument(publishFlags, args, "server.json", "mcp-publisher publish [server.json] [--dry-run] [--profile NAME]")
+	if err != nil {
+		return err
 	}
 
 	// Read server.json
 	serverData, err := os.ReadFile(serverFile)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("server.json not found. Run 'mcp-publisher init' to create one")
+			return fmt.Errorf("%s not found. Run 'mcp-publisher init' to create one", serverFile)
 		}
-		return fmt.Errorf("failed to read server.json: %w", err)
+		return fmt.Errorf("failed to read %s: %w", serverFile, err)
 	}
 
 	// Validate JSON
@@ -38,46 +42,49 @@ func PublishCommand(args []string) error {
 		return fmt.Errorf("invalid server.json: %w", err)
 	}
 
-	// Check for deprecated schema and recommend migration
-	// Allow empty schema (will use default) but reject old schemas
+	// Earlier schema versions are upgraded by the registry on publish, so only recommend migrating.
+	// Allow empty schema (will use default) but reject schemas the registry cannot upgrade.
 	if serverJSON.Schema != "" && !strings.Contains(serverJSON.Schema, model.CurrentSchemaVersion) {
-		return fmt.Errorf(`deprecated schema detected: %s.
+		if !schema.NeedsUpgrade(serverJSON.Schema) {
+			return fmt.Errorf(`unsupported schema detected: %s.
 
 Migrate to the current schema format for new servers.
 
 📋 Migration checklist: https://github.com/modelcontextprotocol/registry/blob/main/docs/reference/server-json/CHANGELOG.md#migration-checklist-for-publishers
 📖 Full changelog with examples: https://github.com/modelcontextprotocol/registry/blob/main/docs/reference/server-json/CHANGELOG.md`, serverJSON.Schema)
+		}
+		_, _ = fmt.Fprintf(os.Stdout, "Note: %s uses schema version %s, which the registry will upgrade to %s. Run 'mcp-publisher migrate %s' to update the file.\n",
+			serverFile, schema.Version(serverJSON.Schema), model.CurrentSchemaVersion, serverFile)
 	}
 
-	// Load saved token
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
-	}
-
-	tokenPath := filepath.Join(homeDir, TokenFileName)
-	tokenData, err := os.ReadFile(tokenPath)
+	// Load saved token, which is refreshed if it has expired
+	creds, err := loadCredentials(profile)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return errors.New("not authenticated. Run 'mcp-publisher login <method>' first")
+		return err
+	}
+	registryURL := creds.registryURL()
+	ctx := context.Background()
+
+	if dryRun {
+		_, _ = fmt.Fprintf(os.Stdout, "Checking against %s (dry run)...\n", registryURL)
+		var report *apiv0.PublishReport
+		err := creds.withToken(ctx, func(token string) (err error) {
+			report, err = dryRunPublish(registryURL, serverData, token)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("dry run failed: %w", err)
 		}
-		return fmt.Errorf("failed to read token: %w", err)
-	}
-
-	var tokenInfo map[string]string
-	if err := json.Unmarshal(tokenData, &tokenInfo); err != nil {
-		return fmt.Errorf("invalid token data: %w", err)
-	}
-
-	token := tokenInfo["token"]
-	registryURL := tokenInfo["registry"]
-	if registryURL == "" {
-		registryURL = DefaultRegistryURL
+		return printPublishReport(report)
 	}
 
 	// Publish to registry
 	_, _ = fmt.Fprintf(os.Stdout, "Publishing to %s...\n", registryURL)
-	response, err := publishToRegistry(registryURL, serverData, token)
+	var response *apiv0.ServerResponse
+	err = creds.withToken(ctx, func(token string) (err error) {
+		response, err = publishToRegistry(registryURL, serverData, token)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("publish failed: %w", err)
 	}
@@ -88,7 +95,58 @@ Migrate to the current schema format for new servers.
 	return nil
 }
 
+// printPublishReport prints each check of a dry-run report, returning an error if any check failed
+func printPublishReport(report *apiv0.PublishReport) error {
+	failed := 0
+	for _, check := range report.Checks {
+		switch check.Status {
+		case apiv0.CheckStatusPass:
+			_, _ = fmt.Fprintf(os.Stdout, "✓ %s\n", check.Name)
+		case apiv0.CheckStatusSkip:
+			_, _ = fmt.Fprintf(os.Stdout, "- %s (skipped: %s)\n", check.Name, check.Message)
+		default:
+			failed++
+			_, _ = fmt.Fprintf(os.Stdout, "✗ %s: %s\n", check.Name, check.Message)
+		}
+	}
+
+	if failed > 0 || !report.Valid {
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+
+	_, _ = fmt.Fprintln(os.Stdout, "✓ All checks passed. Run without --dry-run to publish")
+	return nil
+}
+
+func dryRunPublish(registryURL string, serverData []byte, token string) (*apiv0.PublishReport, error) {
+	body, err := postToPublishEndpoint(registryURL, "v0/publish?dry_run=true", serverData, token)
+	if err != nil {
+		return nil, err
+	}
+
+	var report apiv0.PublishReport
+	if err := json.Unmarshal(body, &report); err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
 func publishToRegistry(registryURL string, serverData []byte, token string) (*apiv0.ServerResponse, error) {
+	body, err := postToPublishEndpoint(registryURL, "v0/publish", serverData, token)
+	if err != nil {
+		return nil, err
+	}
+
+	var serverResponse apiv0.ServerResponse
+	if err := json.Unmarshal(body, &serverResponse); err != nil {
+		return nil, err
+	}
+
+	return &serverResponse, nil
+}
+
+func postToPublishEndpoint(registryURL, endpoint string, serverData []byte, token string) ([]byte, error) {
 	// Parse the server JSON data
 	var serverJSON apiv0.ServerJSON
 	err := json.Unmarshal(serverData, &serverJSON)
@@ -106,7 +164,7 @@ func publishToRegistry(registryURL string, serverData []byte, token string) (*ap
 	if !strings.HasSuffix(registryURL, "/") {
 		registryURL += "/"
 	}
-	publishURL := registryURL + "v0/publish"
+	publishURL := registryURL + endpoint
 
 	// Create and send request
 	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, publishURL, bytes.NewBuffer(jsonData))
@@ -130,13 +188,8 @@ func publishToRegistry(registryURL string, serverData []byte, token string) (*ap
 	}
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("server returned status %d: %s", resp.StatusCode, body)
+		return nil, &responseError{StatusCode: resp.StatusCode, Body: body}
 	}
 
-	var serverResponse apiv0.ServerResponse
-	if err := json.Unmarshal(body, &serverResponse); err != nil {
-		return nil, err
-	}
-
-	return &serverResponse, nil
+	return body, nil
 }
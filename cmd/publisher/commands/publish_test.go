@@ -2,6 +2,8 @@ package commands_test
 
 import (
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -38,10 +40,15 @@ func TestPublishCommand_DeprecatedSchema(t *testing.T) {
 		errorSubstr string
 	}{
 		{
-			name:        "deprecated 2025-07-09 schema should show warning",
+			name:        "earlier 2025-07-09 schema is left for the registry to upgrade",
 			schema:      "https://static.modelcontextprotocol.io/schemas/2025-07-09/server.schema.json",
+			expectError: false,
+		},
+		{
+			name:        "unknown 2025-01-27 schema should fail",
+			schema:      "https://static.modelcontextprotocol.io/schemas/2025-01-27/server.schema.json",
 			expectError: true,
-			errorSubstr: "deprecated schema detected",
+			errorSubstr: "unsupported schema detected",
 		},
 		{
 			name:        "current 2025-10-17 schema should pass validation",
@@ -57,7 +64,7 @@ func TestPublishCommand_DeprecatedSchema(t *testing.T) {
 			name:        "custom schema without 2025-07-09 should pass validation",
 			schema:      "https://example.com/custom.schema.json",
 			expectError: true,
-			errorSubstr: "deprecated schema detected",
+			errorSubstr: "unsupported schema detected",
 		},
 	}
 
@@ -101,7 +108,7 @@ func TestPublishCommand_DeprecatedSchema(t *testing.T) {
 
 			if tt.expectError {
 				if err == nil {
-					t.Errorf("Expected error for deprecated schema, but got none")
+					t.Errorf("Expected error for unsupported schema, but got none")
 					return
 				}
 				if !strings.Contains(err.Error(), tt.errorSubstr) {
@@ -115,9 +122,9 @@ func TestPublishCommand_DeprecatedSchema(t *testing.T) {
 					t.Errorf("Expected error to contain changelog link")
 				}
 			} else {
-				// For non-deprecated schemas, we expect the command to fail at auth step, not schema validation
-				if err != nil && strings.Contains(err.Error(), "deprecated schema detected") {
-					t.Errorf("Unexpected deprecated schema error for schema '%s': %v", tt.schema, err)
+				// For supported schemas, we expect the command to fail at auth step, not schema validation
+				if err != nil && strings.Contains(err.Error(), "unsupported schema detected") {
+					t.Errorf("Unexpected unsupported schema error for schema '%s': %v", tt.schema, err)
 				}
 
 				// We expect auth errors for valid schemas since we don't have a token
@@ -131,3 +138,65 @@ func TestPublishCommand_DeprecatedSchema(t *testing.T) {
 		})
 	}
 }
+
+func TestPublishCommand_DryRun(t *testing.T) {
+	var requestedURL string
+	report := apiv0.PublishReport{
+		Valid: false,
+		Checks: []apiv0.PublishCheck{
+			{Name: "namespace-permission", Status: apiv0.CheckStatusPass},
+			{Name: "version-uniqueness", Status: apiv0.CheckStatusFail, Message: "version 1.0.0 has already been published"},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedURL = r.URL.String()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(report)
+	}))
+	defer server.Close()
+
+	// Point the token file at the fake registry
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	tokenData, err := json.Marshal(map[string]string{"token": "test-token", "method": "none", "registry": server.URL})
+	if err != nil {
+		t.Fatalf("Failed to marshal token: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(homeDir, commands.TokenFileName), tokenData, 0o600); err != nil {
+		t.Fatalf("Failed to write token file: %v", err)
+	}
+
+	serverData, err := json.Marshal(apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/test-server",
+		Description: "A test server",
+		Version:     "1.0.0",
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal server.json: %v", err)
+	}
+	serverFile := filepath.Join(t.TempDir(), "server.json")
+	if err := os.WriteFile(serverFile, serverData, 0o600); err != nil {
+		t.Fatalf("Failed to write server.json: %v", err)
+	}
+
+	err = commands.PublishCommand([]string{serverFile, "--dry-run"})
+	if err == nil || !strings.Contains(err.Error(), "1 check(s) failed") {
+		t.Errorf("Expected failing checks error, got: %v", err)
+	}
+	if requestedURL != "/v0/publish?dry_run=true" {
+		t.Errorf("Expected dry run request, got: %s", requestedURL)
+	}
+
+	// The file can also follow the flags
+	report.Valid = true
+	report.Checks = report.Checks[:1]
+	if err := commands.PublishCommand([]string{"--dry-run", serverFile}); err != nil {
+		t.Errorf("Expected dry run to pass, got: %v", err)
+	}
+
+	err = commands.PublishCommand([]string{"--dry-run", serverFile, "other.json"})
+	if err == nil || !strings.Contains(err.Error(), "usage: mcp-publisher publish") {
+		t.Errorf("Expected usage error for extra arguments, got: %v", err)
+	}
+}
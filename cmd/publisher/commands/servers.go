@@ -0,0 +1,365 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// readOptions are the flags shared by the commands that read from the registry
+type readOptions struct {
+	registryURL string
+	jsonOutput  bool
+	profile     string
+}
+
+func (o *readOptions) register(flags *flag.FlagSet) {
+	flags.StringVar(&o.registryURL, "registry", "", "Registry URL (default: the registry you logged in to, or "+DefaultRegistryURL+")")
+	flags.StringVar(&o.profile, "profile", "", "Profile whose registry and token to use (default: $"+ProfileEnvVar+", or the current profile)")
+	flags.BoolVar(&o.jsonOutput, "json", false, "Print JSON instead of a table")
+}
+
+// SearchCommand lists the latest version of servers whose name contains a search term
+func SearchCommand(args []string) error {
+	searchFlags := flag.NewFlagSet("search", flag.ExitOnError)
+	var options readOptions
+	options.register(searchFlags)
+	var limit int
+	searchFlags.IntVar(&limit, "limit", 30, "Maximum number of servers to list")
+
+	term, err := parseWithArgument(searchFlags, args, "mcp-publisher search <term> [--limit N] [--json] [--registry URL]")
+	if err != nil {
+		return err
+	}
+	if limit < 1 {
+		return errors.New("--limit must be at least 1")
+	}
+
+	client, err := newRegistryClient(options)
+	if err != nil {
+		return err
+	}
+
+	var servers []apiv0.ServerResponse
+	cursor := ""
+	for len(servers) < limit {
+		query := url.Values{"search": {term}, "version": {"latest"}, "limit": {strconv.Itoa(min(limit-len(servers), 100))}}
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+		var page apiv0.ServerListResponse
+		if err := client.get("v0/servers?"+query.Encode(), &page); err != nil {
+			return err
+		}
+		servers = append(servers, page.Servers...)
+		cursor = page.Metadata.NextCursor
+		if cursor == "" || len(page.Servers) == 0 {
+			break
+		}
+	}
+
+	if options.jsonOutput {
+		return printJSON(servers)
+	}
+	if len(servers) == 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "No servers found matching %q\n", term)
+		return nil
+	}
+	return printServerTable(servers, true)
+}
+
+// ShowCommand prints a server version, or its latest version
+func ShowCommand(args []string) error {
+	showFlags := flag.NewFlagSet("show", flag.ExitOnError)
+	var options readOptions
+	options.register(showFlags)
+
+	reference, err := parseWithArgument(showFlags, args, "mcp-publisher show <name>[@version] [--json] [--registry URL]")
+	if err != nil {
+		return err
+	}
+	name, version := reference, "latest"
+	if at := strings.LastIndex(reference, "@"); at > 0 {
+		name, version = reference[:at], reference[at+1:]
+	}
+
+	client, err := newRegistryClient(options)
+	if err != nil {
+		return err
+	}
+
+	var server apiv0.ServerResponse
+	if err := client.get("v0/servers/"+url.PathEscape(name)+"/versions/"+url.PathEscape(version), &server); err != nil {
+		return err
+	}
+
+	if options.jsonOutput {
+		return printJSON(server)
+	}
+	printServerDetails(&server)
+	return nil
+}
+
+// VersionsCommand lists every published version of a server
+func VersionsCommand(args []string) error {
+	versionsFlags := flag.NewFlagSet("versions", flag.ExitOnError)
+	var options readOptions
+	options.register(versionsFlags)
+
+	name, err := parseWithArgument(versionsFlags, args, "mcp-publisher versions <name> [--json] [--registry URL]")
+	if err != nil {
+		return err
+	}
+
+	client, err := newRegistryClient(options)
+	if err != nil {
+		return err
+	}
+
+	var versions apiv0.ServerListResponse
+	if err := client.get("v0/servers/"+url.PathEscape(name)+"/versions", &versions); err != nil {
+		return err
+	}
+
+	if options.jsonOutput {
+		return printJSON(versions.Servers)
+	}
+	return printServerTable(versions.Servers, false)
+}
+
+// parseWithArgument parses flags given before or after a single required argument
+func parseWithArgument(flags *flag.FlagSet, args []string, usage string) (string, error) {
+	argument, err := parseWithOptionalArgument(flags, args, "", usage)
+	if err != nil {
+		return "", err
+	}
+	if argument == "" {
+		return "", fmt.Errorf("usage: %s", usage)
+	}
+	return argument, nil
+}
+
+// parseWithOptionalArgument parses flags given before or after a single optional argument,
+// returning defaultArgument when it is not given
+func parseWithOptionalArgument(flags *flag.FlagSet, args []string, defaultArgument, usage string) (string, error) {
+	var argument string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		argument, args = args[0], args[1:]
+	}
+	if err := flags.Parse(args); err != nil {
+		return "", err
+	}
+	remaining := flags.Args()
+	if argument == "" && len(remaining) > 0 {
+		argument, remaining = remaining[0], remaining[1:]
+	}
+	if len(remaining) > 0 {
+		return "", fmt.Errorf("usage: %s", usage)
+	}
+	if argument == "" {
+		return defaultArgument, nil
+	}
+	return argument, nil
+}
+
+// registryClient sends requests to the registry, with the saved token if there is one so non-public
+// namespaces the publisher can see are included
+type registryClient struct {
+	registryURL string
+	token       string
+	// credentials, when set, provide the token instead, refreshing it when it has expired
+	credentials *credentials
+	httpClient  *http.Client
+}
+
+func newRegistryClient(options readOptions) (*registryClient, error) {
+	token, registryURL, err := loadToken(options.profile)
+	if err != nil && !errors.Is(err, errNotAuthenticated) {
+		return nil, err
+	}
+	if options.registryURL != "" && options.registryURL != registryURL {
+		// The saved token was issued by a different registry
+		registryURL, token = options.registryURL, ""
+	}
+	return registryClientFor(registryURL, token), nil
+}
+
+// registryClientFor returns a client for a registry, sending token when it is not empty
+func registryClientFor(registryURL, token string) *registryClient {
+	if !strings.HasSuffix(registryURL, "/") {
+		registryURL += "/"
+	}
+	return &registryClient{registryURL: registryURL, token: token, httpClient: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// responseErrorBody is the part of an RFC 9457 problem details response worth showing
+type responseErrorBody struct {
+	Detail string `json:"detail"`
+	Errors []struct {
+		Location string `json:"location"`
+		Message  string `json:"message"`
+	} `json:"errors"`
+}
+
+// responseError is returned for registry responses other than 200 OK
+type responseError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *responseError) Error() string {
+	if e.StatusCode == http.StatusNotFound {
+		return "server not found"
+	}
+	var problem responseErrorBody
+	if err := json.Unmarshal(e.Body, &problem); err == nil && problem.Detail != "" {
+		message := fmt.Sprintf("server returned status %d: %s", e.StatusCode, problem.Detail)
+		for _, detail := range problem.Errors {
+			if detail.Location != "" {
+				message += fmt.Sprintf("\n  %s: %s", detail.Location, detail.Message)
+			} else {
+				message += "\n  " + detail.Message
+			}
+		}
+		return message
+	}
+	return fmt.Sprintf("server returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// get fetches a registry endpoint and decodes the JSON response into out
+func (c *registryClient) get(endpoint string, out any) error {
+	return c.do(http.MethodGet, endpoint, nil, out)
+}
+
+// do sends a request to a registry endpoint, with body encoded as JSON when given,
+// and decodes the JSON response into out
+func (c *registryClient) do(method, endpoint string, body, out any) error {
+	if c.credentials == nil {
+		return c.send(method, endpoint, c.token, body, out)
+	}
+	return c.credentials.withToken(context.Background(), func(token string) error {
+		return c.send(method, endpoint, token, body, out)
+	})
+}
+
+// send sends a single request to a registry endpoint with token
+func (c *registryClient) send(method, endpoint, token string, body, out any) error {
+	var requestBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error serializing request: %w", err)
+		}
+		requestBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), method, c.registryURL+endpoint, requestBody)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return &responseError{StatusCode: resp.StatusCode, Body: responseBody}
+	}
+
+	if err := json.Unmarshal(responseBody, out); err != nil {
+		return fmt.Errorf("invalid response from registry: %w", err)
+	}
+	return nil
+}
+
+func printJSON(value any) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// printServerTable prints one row per server version, with its official registry metadata
+func printServerTable(servers []apiv0.ServerResponse, withName bool) error {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if withName {
+		_, _ = fmt.Fprint(writer, "NAME\t")
+	}
+	_, _ = fmt.Fprintln(writer, "VERSION\tSTATUS\tLATEST\tPUBLISHED")
+
+	for _, server := range servers {
+		if withName {
+			_, _ = fmt.Fprintf(writer, "%s\t", server.Server.Name)
+		}
+		status, latest, published := officialSummary(server.Meta.Official)
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", server.Server.Version, status, latest, published)
+	}
+	return writer.Flush()
+}
+
+// printServerDetails prints a server version as labelled fields
+func printServerDetails(server *apiv0.ServerResponse) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	field := func(label, value string) {
+		if value != "" {
+			_, _ = fmt.Fprintf(writer, "%s:\t%s\n", label, value)
+		}
+	}
+
+	status, latest, published := officialSummary(server.Meta.Official)
+	field("Name", server.Server.Name)
+	field("Title", server.Server.Title)
+	field("Version", server.Server.Version)
+	field("Description", server.Server.Description)
+	field("Status", status)
+	field("Latest", latest)
+	field("Published", published)
+	if official := server.Meta.Official; official != nil && !official.UpdatedAt.IsZero() {
+		field("Updated", official.UpdatedAt.Format(time.RFC3339))
+	}
+	field("Repository", server.Server.Repository.URL)
+	field("Website", server.Server.WebsiteURL)
+	for i, pkg := range server.Server.Packages {
+		field(fmt.Sprintf("Package %d", i), fmt.Sprintf("%s %s %s (%s)", pkg.RegistryType, pkg.Identifier, pkg.Version, pkg.Transport.Type))
+	}
+	for i, remote := range server.Server.Remotes {
+		field(fmt.Sprintf("Remote %d", i), fmt.Sprintf("%s (%s)", remote.URL, remote.Type))
+	}
+	_ = writer.Flush()
+}
+
+// officialSummary formats the status, isLatest and publishedAt of the official registry metadata
+func officialSummary(official *apiv0.RegistryExtensions) (status, latest, published string) {
+	if official == nil {
+		return "-", "-", "-"
+	}
+	latest = "no"
+	if official.IsLatest {
+		latest = "yes"
+	}
+	return string(official.Status), latest, official.PublishedAt.Format(time.RFC3339)
+}
@@ -0,0 +1,101 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func testServerResponse(version string, status model.Status, isLatest bool) apiv0.ServerResponse {
+	return apiv0.ServerResponse{
+		Server: apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.example/weather",
+			Description: "Weather server",
+			Version:     version,
+		},
+		Meta: apiv0.ResponseMeta{Official: &apiv0.RegistryExtensions{
+			Status:      status,
+			PublishedAt: time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC),
+			IsLatest:    isLatest,
+		}},
+	}
+}
+
+func TestReadCommands(t *testing.T) {
+	var requests []string
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI())
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.EscapedPath() {
+		case "/v0/servers":
+			_ = json.NewEncoder(w).Encode(apiv0.ServerListResponse{
+				Servers:  []apiv0.ServerResponse{testServerResponse("1.1.0", model.StatusActive, true)},
+				Metadata: apiv0.Metadata{Count: 1},
+			})
+		case "/v0/servers/io.github.example%2Fweather/versions":
+			_ = json.NewEncoder(w).Encode(apiv0.ServerListResponse{
+				Servers: []apiv0.ServerResponse{
+					testServerResponse("1.0.0", model.StatusDeprecated, false),
+					testServerResponse("1.1.0", model.StatusActive, true),
+				},
+				Metadata: apiv0.Metadata{Count: 2},
+			})
+		case "/v0/servers/io.github.example%2Fweather/versions/1.0.0":
+			_ = json.NewEncoder(w).Encode(testServerResponse("1.0.0", model.StatusDeprecated, false))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer registry.Close()
+	t.Setenv("HOME", t.TempDir())
+
+	t.Run("search", func(t *testing.T) {
+		var err error
+		output := captureStdout(t, func() { err = commands.SearchCommand([]string{"weather", "--registry", registry.URL, "--limit", "5"}) })
+		require.NoError(t, err)
+		assert.Equal(t, "/v0/servers?limit=5&search=weather&version=latest", requests[len(requests)-1])
+		assert.Contains(t, output, "NAME")
+		assert.Regexp(t, `io\.github\.example/weather\s+1\.1\.0\s+active\s+yes\s+2025-10-01T12:00:00Z`, output)
+	})
+
+	t.Run("show a version", func(t *testing.T) {
+		var err error
+		output := captureStdout(t, func() {
+			err = commands.ShowCommand([]string{"io.github.example/weather@1.0.0", "--registry", registry.URL})
+		})
+		require.NoError(t, err)
+		assert.Regexp(t, `Status:\s+deprecated`, output)
+		assert.Regexp(t, `Latest:\s+no`, output)
+	})
+
+	t.Run("versions as JSON", func(t *testing.T) {
+		var err error
+		output := captureStdout(t, func() {
+			err = commands.VersionsCommand([]string{"--json", "--registry", registry.URL, "io.github.example/weather"})
+		})
+		require.NoError(t, err)
+		var servers []apiv0.ServerResponse
+		require.NoError(t, json.Unmarshal([]byte(output), &servers))
+		require.Len(t, servers, 2)
+		assert.True(t, servers[1].Meta.Official.IsLatest)
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		err := commands.ShowCommand([]string{"io.github.example/missing", "--registry", registry.URL})
+		assert.ErrorContains(t, err, "server not found")
+	})
+
+	t.Run("missing argument", func(t *testing.T) {
+		assert.ErrorContains(t, commands.VersionsCommand([]string{"--registry", registry.URL}), "usage: mcp-publisher versions")
+	})
+}
@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// statusChange describes a status command
+type statusChange struct {
+	command string
+	status  model.Status
+	verb    string
+	warning string
+}
+
+var (
+	deprecateChange = statusChange{
+		command: "deprecate",
+		status:  model.StatusDeprecated,
+		verb:    "Deprecate",
+		warning: "Registry users will see it marked as deprecated.",
+	}
+	undeprecateChange = statusChange{
+		command: "undeprecate",
+		status:  model.StatusActive,
+		verb:    "Undeprecate",
+	}
+	deleteChange = statusChange{
+		command: "delete",
+		status:  model.StatusDeleted,
+		verb:    "Delete",
+		warning: "Deleted versions cannot be restored.",
+	}
+)
+
+// DeprecateCommand marks a server version as deprecated
+func DeprecateCommand(args []string) error {
+	return changeStatus(deprecateChange, args)
+}
+
+// UndeprecateCommand makes a deprecated server version active again
+func UndeprecateCommand(args []string) error {
+	return changeStatus(undeprecateChange, args)
+}
+
+// DeleteCommand marks a server version as deleted. Only registry admins can delete servers.
+func DeleteCommand(args []string) error {
+	return changeStatus(deleteChange, args)
+}
+
+func changeStatus(change statusChange, args []string) error {
+	statusFlags := flag.NewFlagSet(change.command, flag.ExitOnError)
+	var reason string
+	var yes bool
+	var profile string
+	statusFlags.StringVar(&reason, "reason", "", "Why the status is being changed, shown to registry users")
+	statusFlags.BoolVar(&yes, "yes", false, "Do not ask for confirmation")
+	statusFlags.StringVar(&profile, "profile", "", "Profile to use (default: $"+ProfileEnvVar+", or the current profile)")
+
+	reference, err := parseWithArgument(statusFlags, args, "mcp-publisher "+change.command+" <name>@<version> [--reason TEXT] [--yes] [--profile NAME]")
+	if err != nil {
+		return err
+	}
+	at := strings.LastIndex(reference, "@")
+	if at <= 0 || at == len(reference)-1 {
+		return fmt.Errorf("a version is required, as in %s@1.0.0", strings.TrimSuffix(reference, "@"))
+	}
+	name, version := reference[:at], reference[at+1:]
+
+	creds, err := loadCredentials(profile)
+	if err != nil {
+		return err
+	}
+	registryURL := creds.registryURL()
+	client := registryClientFor(registryURL, "")
+	client.credentials = creds
+
+	// Fetch the current entry, which is submitted unchanged along with the new status
+	endpoint := "v0/servers/" + url.PathEscape(name) + "/versions/" + url.PathEscape(version)
+	var current apiv0.ServerResponse
+	if err := client.get(endpoint, &current); err != nil {
+		return err
+	}
+
+	if official := current.Meta.Official; official != nil {
+		switch {
+		case official.Status == model.StatusDeleted:
+			return fmt.Errorf("%s@%s is deleted, and deleted versions cannot be changed", name, version)
+		case official.Status == change.status && reason == official.StatusMessage:
+			_, _ = fmt.Fprintf(os.Stdout, "%s@%s is already %s\n", name, version, change.status)
+			return nil
+		}
+	}
+
+	if !yes {
+		prompt := fmt.Sprintf("%s %s@%s on %s?", change.verb, name, version, registryURL)
+		if change.warning != "" {
+			prompt += " " + change.warning
+		}
+		if !confirm(prompt) {
+			return errors.New("aborted")
+		}
+	}
+
+	query := url.Values{"status": {string(change.status)}}
+	if reason != "" {
+		query.Set("status_message", reason)
+	}
+	var updated apiv0.ServerResponse
+	if err := client.do(http.MethodPut, endpoint+"?"+query.Encode(), current.Server, &updated); err != nil {
+		var respErr *responseError
+		if errors.As(err, &respErr) && respErr.StatusCode == http.StatusForbidden {
+			return fmt.Errorf("you do not have edit permission for %s. Changing a server's status needs a registry admin token", name)
+		}
+		return fmt.Errorf("%s failed: %w", change.command, err)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "✓ %s@%s is now %s\n", name, version, change.status)
+	if updated.Meta.Official != nil && updated.Meta.Official.StatusMessage != "" {
+		_, _ = fmt.Fprintf(os.Stdout, "  Reason: %s\n", updated.Meta.Official.StatusMessage)
+	}
+	return nil
+}
+
+// confirm asks a yes/no question on stdin. Anything but yes, including no answer at all, means no.
+func confirm(prompt string) bool {
+	_, _ = fmt.Fprintf(os.Stdout, "%s [y/N]: ", prompt)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,103 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestStatusCommands(t *testing.T) {
+	current := testServerResponse("1.0.0", model.StatusActive, false)
+	var edits []string
+	var editedServer apiv0.ServerJSON
+	forbidden := false
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/v0/servers/io.github.example%2Fweather/versions/1.0.0" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			if forbidden {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			edits = append(edits, r.URL.RawQuery)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&editedServer))
+			updated := current
+			official := *current.Meta.Official
+			official.Status = model.Status(r.URL.Query().Get("status"))
+			official.StatusMessage = r.URL.Query().Get("status_message")
+			updated.Meta.Official = &official
+			_ = json.NewEncoder(w).Encode(updated)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(current)
+	}))
+	defer registry.Close()
+
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	tokenData, err := json.Marshal(map[string]string{"token": "test-token", "method": "none", "registry": registry.URL})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(homeDir, commands.TokenFileName), tokenData, 0o600))
+
+	t.Run("deprecate with a reason", func(t *testing.T) {
+		var err error
+		output := captureStdout(t, func() {
+			err = commands.DeprecateCommand([]string{"io.github.example/weather@1.0.0", "--reason", "Use 2.0.0", "--yes"})
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"status=deprecated&status_message=Use+2.0.0"}, edits)
+		assert.Equal(t, current.Server, editedServer)
+		assert.Contains(t, output, "is now deprecated")
+		assert.Contains(t, output, "Reason: Use 2.0.0")
+	})
+
+	t.Run("declined confirmation", func(t *testing.T) {
+		edits = nil
+		reader, writer, err := os.Pipe()
+		require.NoError(t, err)
+		_, err = writer.WriteString("n\n")
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+		stdin := os.Stdin
+		os.Stdin = reader
+		defer func() { os.Stdin = stdin }()
+
+		captureStdout(t, func() { err = commands.DeleteCommand([]string{"io.github.example/weather@1.0.0"}) })
+		assert.EqualError(t, err, "aborted")
+		assert.Empty(t, edits)
+	})
+
+	t.Run("already in the requested status", func(t *testing.T) {
+		edits = nil
+		var err error
+		output := captureStdout(t, func() { err = commands.UndeprecateCommand([]string{"io.github.example/weather@1.0.0", "--yes"}) })
+		require.NoError(t, err)
+		assert.Contains(t, output, "is already active")
+		assert.Empty(t, edits)
+	})
+
+	t.Run("without edit permission", func(t *testing.T) {
+		forbidden = true
+		defer func() { forbidden = false }()
+		err := commands.DeleteCommand([]string{"io.github.example/weather@1.0.0", "--yes"})
+		assert.ErrorContains(t, err, "needs a registry admin token")
+	})
+
+	t.Run("version is required", func(t *testing.T) {
+		assert.ErrorContains(t, commands.DeprecateCommand([]string{"io.github.example/weather"}), "a version is required")
+	})
+}
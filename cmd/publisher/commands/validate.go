@@ -0,0 +1,179 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/schema"
+	"github.com/modelcontextprotocol/registry/pkg/validators"
+)
+
+// ValidateCommand checks server.json locally with the rules the registry applies on publish. It needs no token.
+func ValidateCommand(args []string) error {
+	validateFlags := flag.NewFlagSet("validate", flag.ExitOnError)
+	var checkPackages bool
+	validateFlags.BoolVar(&checkPackages, "check-packages", false, "Also check that each package exists and references this server (needs network access)")
+	serverFile, err := parseWithOptionalArgument(validateFlags, args, "server.json", "mcp-publisher validate [server.json] [--check-packages]")
+	if err != nil {
+		return err
+	}
+
+	serverData, err := os.ReadFile(serverFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s not found. Run 'mcp-publisher init' to create one", serverFile)
+		}
+		return fmt.Errorf("failed to read %s: %w", serverFile, err)
+	}
+
+	locations := newJSONLocations(serverData)
+
+	// Earlier schema versions are upgraded by the registry on publish, so check the upgraded document
+	data := serverData
+	if schemaURL := jsonSchemaURL(serverData); schema.NeedsUpgrade(schemaURL) {
+		if data, _, err = schema.Upgrade(serverData); err != nil {
+			return fmt.Errorf("%s: %w", serverFile, err)
+		}
+		_, _ = fmt.Fprintf(os.Stdout, "Note: %s uses schema version %s, which the registry will upgrade to %s. Run 'mcp-publisher migrate %s' to update the file.\n",
+			serverFile, schema.Version(schemaURL), model.CurrentSchemaVersion, serverFile)
+	}
+
+	var serverJSON apiv0.ServerJSON
+	if err := json.Unmarshal(data, &serverJSON); err != nil {
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntaxErr):
+			// The offset is just past the offending character
+			return fmt.Errorf("%s:%s: invalid JSON: %w", serverFile, locations.position(syntaxErr.Offset-1), err)
+		case errors.As(err, &typeErr) && typeErr.Field != "":
+			return fmt.Errorf("%s:%s: invalid server.json: %w", serverFile, locations.locate("/"+strings.ReplaceAll(typeErr.Field, ".", "/")), err)
+		}
+		return fmt.Errorf("%s: invalid server.json: %w", serverFile, err)
+	}
+
+	result := validators.CheckJSONSchema(&serverJSON)
+	result.Merge(validators.CheckServerJSON(&serverJSON))
+	if checkPackages {
+		result.Merge(validators.CheckPackages(context.Background(), &serverJSON))
+	}
+
+	for _, issue := range result.Issues {
+		path := issue.Path
+		if path == "" {
+			path = "/"
+		}
+		_, _ = fmt.Fprintf(os.Stdout, "%s:%s: %s: %s: %s (%s)\n",
+			serverFile, locations.locate(issue.Path), issue.Severity, path, issue.Message, issue.Code)
+	}
+
+	if !result.Valid() {
+		return fmt.Errorf("%d problem(s) found in %s", len(result.Issues), serverFile)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "✓ %s is valid\n", serverFile)
+	return nil
+}
+
+// jsonSchemaURL returns the $schema of a server.json document, or "" if it has none
+func jsonSchemaURL(data []byte) string {
+	var document struct {
+		Schema string `json:"$schema"`
+	}
+	_ = json.Unmarshal(data, &document)
+	return document.Schema
+}
+
+// jsonLocations finds where each value of a JSON document is, by JSON Pointer
+type jsonLocations struct {
+	data    []byte
+	offsets map[string]int64
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// newJSONLocations records the offset of every value in a JSON document. Object members are located
+// at their key, so findings point at the line a publisher would edit. Invalid documents are
+// recorded up to the first error.
+func newJSONLocations(data []byte) *jsonLocations {
+	locations := &jsonLocations{data: data, offsets: map[string]int64{}}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	var walk func(pointer string, offset int64) error
+	walk = func(pointer string, offset int64) error {
+		locations.offsets[pointer] = offset
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch token {
+		case json.Delim('{'):
+			for decoder.More() {
+				keyOffset := locations.skipSeparators(decoder.InputOffset())
+				key, err := decoder.Token()
+				if err != nil {
+					return err
+				}
+				name, _ := key.(string)
+				if err := walk(pointer+"/"+pointerEscaper.Replace(name), keyOffset); err != nil {
+					return err
+				}
+			}
+		case json.Delim('['):
+			for i := 0; decoder.More(); i++ {
+				if err := walk(pointer+"/"+strconv.Itoa(i), locations.skipSeparators(decoder.InputOffset())); err != nil {
+					return err
+				}
+			}
+		default:
+			return nil
+		}
+		// Consume the closing delimiter
+		_, err = decoder.Token()
+		return err
+	}
+	_ = walk("", locations.skipSeparators(0))
+
+	return locations
+}
+
+// skipSeparators returns the offset of the next token at or after offset
+func (l *jsonLocations) skipSeparators(offset int64) int64 {
+	for offset < int64(len(l.data)) && strings.ContainsRune(" \t\r\n,:", rune(l.data[offset])) {
+		offset++
+	}
+	return offset
+}
+
+// locate returns the line:column of the value at a JSON Pointer, or of its closest ancestor
+// when the value is missing from the document
+func (l *jsonLocations) locate(pointer string) string {
+	for {
+		if offset, ok := l.offsets[pointer]; ok {
+			return l.position(offset)
+		}
+		index := strings.LastIndex(pointer, "/")
+		if index < 0 {
+			return l.position(0)
+		}
+		pointer = pointer[:index]
+	}
+}
+
+// position returns the 1-based line:column of a byte offset
+func (l *jsonLocations) position(offset int64) string {
+	offset = min(max(offset, 0), int64(len(l.data)))
+	before := l.data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n')
+	return fmt.Sprintf("%d:%d", line, column)
+}
@@ -0,0 +1,93 @@
+package commands_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// captureStdout returns what fn writes to stdout
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = writer
+	defer func() { os.Stdout = stdout }()
+
+	fn()
+	require.NoError(t, writer.Close())
+	output, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	return string(output)
+}
+
+func TestValidateCommand(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("valid server.json", func(t *testing.T) {
+		file := filepath.Join(dir, "valid.json")
+		require.NoError(t, os.WriteFile(file, []byte(`{
+  "$schema": "`+model.CurrentSchemaURL+`",
+  "name": "io.github.example/weather",
+  "description": "Weather server",
+  "version": "1.0.0",
+  "packages": [{"registryType": "npm", "identifier": "@example/weather", "version": "1.0.0", "transport": {"type": "stdio"}}]
+}`), 0o600))
+
+		var err error
+		output := captureStdout(t, func() { err = commands.ValidateCommand([]string{file}) })
+		require.NoError(t, err)
+		assert.Contains(t, output, "is valid")
+	})
+
+	t.Run("reports every problem with its location", func(t *testing.T) {
+		file := filepath.Join(dir, "invalid.json")
+		require.NoError(t, os.WriteFile(file, []byte(`{
+  "$schema": "`+model.CurrentSchemaURL+`",
+  "name": "io.github.example/weather",
+  "description": "Weather server",
+  "version": "latest",
+  "remotes": [
+    {"type": "streamable-http", "url": "https://other.org/mcp"}
+  ]
+}`), 0o600))
+
+		var err error
+		output := captureStdout(t, func() { err = commands.ValidateCommand([]string{file}) })
+		assert.ErrorContains(t, err, "2 problem(s) found")
+		assert.Contains(t, output, file+":5:3: error: /version: version string 'latest' is reserved")
+		assert.Contains(t, output, file+":7:33: error: /remotes/0/url: ")
+		assert.Contains(t, output, "(namespace_mismatch)")
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		file := filepath.Join(dir, "broken.json")
+		require.NoError(t, os.WriteFile(file, []byte("{\n  \"name\": \n}"), 0o600))
+		assert.ErrorContains(t, commands.ValidateCommand([]string{file}), file+":3:1: invalid JSON")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		assert.ErrorContains(t, commands.ValidateCommand([]string{filepath.Join(dir, "missing.json")}), "not found")
+	})
+
+	t.Run("file after flags", func(t *testing.T) {
+		file := filepath.Join(dir, "invalid.json")
+		var err error
+		output := captureStdout(t, func() { err = commands.ValidateCommand([]string{"--check-packages", file}) })
+		assert.ErrorContains(t, err, "2 problem(s) found in "+file)
+		assert.NotContains(t, output, "is valid")
+	})
+
+	t.Run("extra arguments", func(t *testing.T) {
+		err := commands.ValidateCommand([]string{filepath.Join(dir, "valid.json"), filepath.Join(dir, "invalid.json")})
+		assert.ErrorContains(t, err, "usage: mcp-publisher validate")
+	})
+}
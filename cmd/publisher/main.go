@@ -30,13 +30,37 @@ func main() {
 	var err error
 	switch os.Args[1] {
 	case "init":
-		err = commands.InitCommand()
+		err = commands.InitCommand(os.Args[2:])
 	case "login":
 		err = commands.LoginCommand(os.Args[2:])
+	case "keygen":
+		err = commands.KeygenCommand(os.Args[2:])
+	case "verify-domain":
+		err = commands.VerifyDomainCommand(os.Args[2:])
 	case "logout":
-		err = commands.LogoutCommand()
+		err = commands.LogoutCommand(os.Args[2:])
 	case "publish":
 		err = commands.PublishCommand(os.Args[2:])
+	case "search":
+		err = commands.SearchCommand(os.Args[2:])
+	case "show":
+		err = commands.ShowCommand(os.Args[2:])
+	case "versions":
+		err = commands.VersionsCommand(os.Args[2:])
+	case "deprecate":
+		err = commands.DeprecateCommand(os.Args[2:])
+	case "undeprecate":
+		err = commands.UndeprecateCommand(os.Args[2:])
+	case "delete":
+		err = commands.DeleteCommand(os.Args[2:])
+	case "profiles":
+		err = commands.ProfilesCommand(os.Args[2:])
+	case "diff":
+		err = commands.DiffCommand(os.Args[2:])
+	case "validate":
+		err = commands.ValidateCommand(os.Args[2:])
+	case "migrate":
+		err = commands.MigrateCommand(os.Args[2:])
 	case "--version", "-v", "version":
 		log.Printf("mcp-publisher %s (commit: %s, built: %s)", Version, GitCommit, BuildTime)
 		return
@@ -63,8 +87,20 @@ func printUsage() {
 	_, _ = fmt.Fprintln(os.Stdout, "Commands:")
 	_, _ = fmt.Fprintln(os.Stdout, "  init          Create a server.json file template")
 	_, _ = fmt.Fprintln(os.Stdout, "  login         Authenticate with the registry")
+	_, _ = fmt.Fprintln(os.Stdout, "  keygen        Generate a key for DNS or HTTP authentication")
+	_, _ = fmt.Fprintln(os.Stdout, "  verify-domain Check a domain publishes your key for DNS or HTTP authentication")
 	_, _ = fmt.Fprintln(os.Stdout, "  logout        Clear saved authentication")
+	_, _ = fmt.Fprintln(os.Stdout, "  profiles      List saved registry profiles, or switch profile")
 	_, _ = fmt.Fprintln(os.Stdout, "  publish       Publish server.json to the registry")
+	_, _ = fmt.Fprintln(os.Stdout, "  search        Search published servers by name")
+	_, _ = fmt.Fprintln(os.Stdout, "  show          Show a published server version")
+	_, _ = fmt.Fprintln(os.Stdout, "  versions      List the published versions of a server")
+	_, _ = fmt.Fprintln(os.Stdout, "  deprecate     Mark a published server version as deprecated")
+	_, _ = fmt.Fprintln(os.Stdout, "  undeprecate   Make a deprecated server version active again")
+	_, _ = fmt.Fprintln(os.Stdout, "  delete        Delete a published server version (admins only)")
+	_, _ = fmt.Fprintln(os.Stdout, "  diff          Compare server.json with its published version")
+	_, _ = fmt.Fprintln(os.Stdout, "  validate      Check server.json locally without publishing")
+	_, _ = fmt.Fprintln(os.Stdout, "  migrate       Upgrade server.json to the current schema version")
 	_, _ = fmt.Fprintln(os.Stdout)
 	_, _ = fmt.Fprintln(os.Stdout, "Use 'mcp-publisher <command> --help' for more information about a command.")
 }
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// runImport implements `registry import`, printing the import report as JSON.
+// It exits non-zero if the import could not be read or any entry failed.
+func runImport(args []string) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "Report what would be imported without writing anything")
+	existing := flags.String("existing", string(importer.ExistingSkip), "What to do with versions that already exist: skip, overwrite or fail")
+	preserveTimestamps := flags.Bool("preserve-timestamps", false, "Keep status and timestamps from registry-format input")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: registry import [flags] <path|url|->")
+		fmt.Fprintln(flags.Output(), "\nImports servers from a JSON array, NDJSON or registry list response (optionally gzipped).")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	policy, err := importer.ParseExistingPolicy(*existing)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	cfg := config.NewConfig()
+	db, err := database.NewPostgreSQL(ctx, cfg.DatabaseURL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to PostgreSQL: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	importerService := importer.NewService(service.NewRegistryService(db, cfg))
+	report, importErr := importerService.Import(ctx, flags.Arg(0), importer.Options{
+		Existing:           policy,
+		PreserveTimestamps: *preserveTimestamps,
+		DryRun:             *dryRun,
+	})
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write report: %v\n", err)
+		return 1
+	}
+
+	if importErr != nil {
+		fmt.Fprintln(os.Stderr, importErr)
+		return 1
+	}
+	if len(report.Failed) > 0 {
+		return 1
+	}
+	return 0
+}
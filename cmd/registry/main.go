@@ -16,6 +16,7 @@ import (
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/mirror"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
@@ -34,6 +35,16 @@ var (
 )
 
 func main() {
+	// Run subcommands before parsing the server's own flags
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "import":
+			os.Exit(runImport(os.Args[2:]))
+		case "static-export":
+			os.Exit(runStaticExport(os.Args[2:]))
+		}
+	}
+
 	// Parse command line flags
 	showVersion := flag.Bool("version", false, "Display version information")
 	flag.Parse()
@@ -57,6 +68,12 @@ func main() {
 	// Initialize configuration
 	cfg := config.NewConfig()
 
+	// Reject settings that would otherwise only fail part-way through startup
+	if cfg.SyncFrom != "" && cfg.SyncInterval <= 0 {
+		log.Printf("Invalid sync interval %s: MCP_REGISTRY_SYNC_INTERVAL must be positive", cfg.SyncInterval)
+		os.Exit(1)
+	}
+
 	// Create a context with timeout for PostgreSQL connection
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -79,18 +96,6 @@ func main() {
 
 	registryService = service.NewRegistryService(db, cfg)
 
-	// Import seed data if seed source is provided
-	if cfg.SeedFrom != "" {
-		log.Printf("Importing data from %s...", cfg.SeedFrom)
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-		defer cancel()
-
-		importerService := importer.NewService(registryService)
-		if err := importerService.ImportFromPath(ctx, cfg.SeedFrom); err != nil {
-			log.Printf("Failed to import seed data: %v", err)
-		}
-	}
-
 	shutdownTelemetry, metrics, err := telemetry.InitMetrics(cfg.Version)
 	if err != nil {
 		log.Printf("Failed to initialize metrics: %v", err)
@@ -113,6 +118,30 @@ func main() {
 	// Initialize HTTP server
 	server := api.NewServer(cfg, registryService, metrics, versionInfo)
 
+	// Background work (import jobs and upstream sync) stops when the server shuts down
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+
+	// Import jobs run in the background, so seeding does not delay startup
+	importJobs := importer.NewJobManager(backgroundCtx, importer.NewService(registryService))
+	server.RegisterImportJobs(importJobs)
+	if cfg.SeedFrom != "" {
+		job := importJobs.Start(cfg.SeedFrom, importer.Options{Existing: importer.ExistingSkip})
+		log.Printf("Importing data from %s (import job %s)", cfg.SeedFrom, job.ID)
+	}
+
+	// Continuously sync from an upstream registry if configured
+	if cfg.SyncFrom != "" {
+		log.Printf("Syncing from %s every %s", cfg.SyncFrom, cfg.SyncInterval)
+		syncer, err := mirror.NewSyncer(db, cfg.SyncFrom, cfg.SyncInterval, metrics)
+		if err != nil {
+			log.Printf("Failed to create syncer: %v", err)
+			return
+		}
+		server.RegisterSyncStatus(syncer)
+		go syncer.Run(backgroundCtx)
+	}
+
 	// Start server in a goroutine so it doesn't block signal handling
 	go func() {
 		if err := server.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -127,6 +156,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down server...")
+	stopBackground()
 
 	// Create context with timeout for shutdown
 	sctx, scancel := context.WithTimeout(context.Background(), 10*time.Second)
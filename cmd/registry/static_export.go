@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/staticexport"
+)
+
+// runStaticExport implements `registry static-export`, writing the read API as static files.
+// Namespaces hidden from anonymous readers are left out.
+func runStaticExport(args []string) int {
+	flags := flag.NewFlagSet("static-export", flag.ContinueOnError)
+	pageSize := flags.Int("page-size", staticexport.DefaultPageSize, "Number of servers per list page (1-100)")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: registry static-export [flags] <output-dir>")
+		fmt.Fprintln(flags.Output(), "\nWrites /v0/servers pages and per-server and per-version JSON files for serving from a CDN.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if flags.NArg() != 1 || *pageSize < 1 || *pageSize > 100 {
+		flags.Usage()
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	cfg := config.NewConfig()
+	db, err := database.NewPostgreSQL(ctx, cfg.DatabaseURL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to PostgreSQL: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	result, err := staticexport.Export(ctx, service.NewRegistryService(db, cfg), flags.Arg(0), staticexport.Options{
+		PageSize:         *pageSize,
+		HiddenNamespaces: auth.Reader{}.HiddenNamespaces(cfg.NamespaceVisibility),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Static export failed: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Exported %d servers (%d versions, %d list pages) to %s\n", result.Servers, result.Versions, result.Pages, flags.Arg(0))
+	return 0
+}
@@ -3,7 +3,6 @@ package auth
 import (
 	"context"
 	"crypto/ed25519"
-	"encoding/base64"
 	"encoding/hex"
 	"fmt"
 	"regexp"
@@ -12,6 +11,7 @@ import (
 
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/pkg/domainkey"
 )
 
 // SignatureTokenExchangeInput represents the common input structure for token exchange
@@ -143,7 +143,7 @@ func (h *CoreAuthHandler) ExchangeToken(
 		return nil, fmt.Errorf("failed to fetch keys: %w", err)
 	}
 
-	publicKeys := ParseMCPKeysFromStrings(keyStrings)
+	publicKeys := domainkey.ParseMCPKeysFromStrings(keyStrings)
 	if len(publicKeys) == 0 {
 		switch authMethod {
 		case auth.MethodHTTP:
@@ -165,30 +165,6 @@ func (h *CoreAuthHandler) ExchangeToken(
 	return h.CreateJWTClaimsAndToken(ctx, authMethod, domain, permissions)
 }
 
-func ParseMCPKeysFromStrings(inputs []string) []ed25519.PublicKey {
-	var publicKeys []ed25519.PublicKey
-	mcpPattern := regexp.MustCompile(`v=MCPv1;\s*k=ed25519;\s*p=([A-Za-z0-9+/=]+)`)
-
-	for _, input := range inputs {
-		matches := mcpPattern.FindStringSubmatch(input)
-		if len(matches) == 2 {
-			// Decode base64 public key
-			publicKeyBytes, err := base64.StdEncoding.DecodeString(matches[1])
-			if err != nil {
-				continue // Skip invalid keys
-			}
-
-			if len(publicKeyBytes) != ed25519.PublicKeySize {
-				continue // Skip invalid key sizes
-			}
-
-			publicKeys = append(publicKeys, ed25519.PublicKey(publicKeyBytes))
-		}
-	}
-
-	return publicKeys
-}
-
 // ReverseString reverses a domain string (example.com -> com.example)
 func ReverseString(domain string) string {
 	parts := strings.Split(domain, ".")
@@ -12,6 +12,7 @@ import (
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/pkg/domainkey"
 )
 
 // MaxKeyResponseSize is the maximum size of the response body from the HTTP endpoint.
@@ -54,7 +55,7 @@ func NewDefaultHTTPKeyFetcherWithClient(client *http.Client) *DefaultHTTPKeyFetc
 
 // FetchKey fetches the public key from the well-known HTTP endpoint
 func (f *DefaultHTTPKeyFetcher) FetchKey(ctx context.Context, domain string) (string, error) {
-	url := fmt.Sprintf("https://%s/.well-known/mcp-registry-auth", domain)
+	url := fmt.Sprintf("https://%s%s", domain, domainkey.WellKnownPath)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -238,29 +238,23 @@ func (h *OIDCHandler) buildPermissions(_ *OIDCClaims) []auth.Permission {
 	var permissions []auth.Permission
 
 	// Parse permission patterns from configuration
-	if h.config.OIDCPublishPerms != "" {
-		for _, pattern := range strings.Split(h.config.OIDCPublishPerms, ",") {
-			pattern = strings.TrimSpace(pattern)
-			if pattern != "" {
-				permissions = append(permissions, auth.Permission{
-					Action:          auth.PermissionActionPublish,
-					ResourcePattern: pattern,
-				})
-			}
-		}
-	}
+	permissions = appendPermissionPatterns(permissions, auth.PermissionActionPublish, h.config.OIDCPublishPerms)
+	permissions = appendPermissionPatterns(permissions, auth.PermissionActionEdit, h.config.OIDCEditPerms)
+	permissions = appendPermissionPatterns(permissions, auth.PermissionActionRead, h.config.OIDCReadPerms)
 
-	if h.config.OIDCEditPerms != "" {
-		for _, pattern := range strings.Split(h.config.OIDCEditPerms, ",") {
-			pattern = strings.TrimSpace(pattern)
-			if pattern != "" {
-				permissions = append(permissions, auth.Permission{
-					Action:          auth.PermissionActionEdit,
-					ResourcePattern: pattern,
-				})
-			}
+	return permissions
+}
+
+// appendPermissionPatterns grants the action on each pattern in a comma-separated list
+func appendPermissionPatterns(permissions []auth.Permission, action auth.PermissionAction, patterns string) []auth.Permission {
+	for _, pattern := range strings.Split(patterns, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern != "" {
+			permissions = append(permissions, auth.Permission{
+				Action:          action,
+				ResourcePattern: pattern,
+			})
 		}
 	}
-
 	return permissions
 }
@@ -0,0 +1,79 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/clientconfig"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// ClientConfigInput represents the input for getting client configuration for a server version
+type ClientConfigInput struct {
+	Authorization string `header:"Authorization" doc:"Optional Registry JWT token, needed to see servers in non-public namespaces" required:"false"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version       string `path:"version" doc:"URL-encoded server version, or 'latest'" example:"1.0.0"`
+	Format        string `query:"format" doc:"Client configuration format" default:"generic" enum:"vscode,claude-desktop,generic"`
+	Package       string `query:"package" doc:"Index of the package to configure (defaults to the first package)" required:"false" pattern:"^[0-9]+$" example:"0"`
+	Remote        string `query:"remote" doc:"Index of the remote to configure, instead of a package" required:"false" pattern:"^[0-9]+$" example:"0"`
+}
+
+// RegisterClientConfigEndpoint registers the client configuration endpoint with a custom path prefix
+func RegisterClientConfigEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	var jwtManager *auth.JWTManager
+	if len(cfg.NamespaceVisibility) > 0 {
+		jwtManager = auth.NewJWTManager(cfg)
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-client-config" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/config",
+		Summary:     "Get MCP client configuration",
+		Description: "Get a ready-to-paste MCP client configuration for a package or remote of a server version. " +
+			"Inputs the user has to provide (required values without a default, and secrets) are left as placeholders.",
+		Tags: []string{"servers"},
+	}, func(ctx context.Context, input *ClientConfigInput) (*Response[any], error) {
+		serverResponse, err := getReadableServer(ctx, registry, jwtManager, cfg, input.Authorization, input.ServerName, input.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		target, err := parseConfigTarget(input)
+		if err != nil {
+			return nil, err
+		}
+
+		rendered, err := clientconfig.Render(&serverResponse.Server, clientconfig.Format(input.Format), target)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Cannot generate client configuration: " + err.Error())
+		}
+
+		return &Response[any]{Body: rendered}, nil
+	})
+}
+
+func parseConfigTarget(input *ClientConfigInput) (clientconfig.Target, error) {
+	var target clientconfig.Target
+	if input.Package != "" {
+		index, err := strconv.Atoi(input.Package)
+		if err != nil {
+			return target, huma.Error400BadRequest("Invalid package index", err)
+		}
+		target.Package = &index
+	}
+	if input.Remote != "" {
+		index, err := strconv.Atoi(input.Remote)
+		if err != nil {
+			return target, huma.Error400BadRequest("Invalid remote index", err)
+		}
+		target.Remote = &index
+	}
+	return target, nil
+}
@@ -0,0 +1,89 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestClientConfigEndpoint(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{EnableRegistryValidation: false}
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.example/weather",
+		Description: "Weather server",
+		Version:     "1.0.0",
+		Packages: []model.Package{{
+			RegistryType: model.RegistryTypeNPM,
+			Identifier:   "@example/weather",
+			Version:      "1.0.0",
+			Transport:    model.Transport{Type: model.TransportTypeStdio},
+			EnvironmentVariables: []model.KeyValueInput{
+				{Name: "API_KEY", InputWithVariables: model.InputWithVariables{Input: model.Input{IsRequired: true, IsSecret: true}}},
+			},
+		}},
+		Remotes: []model.Transport{{Type: model.TransportTypeStreamableHTTP, URL: "https://weather.example.com/mcp"}},
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterClientConfigEndpoint(api, "/v0", registryService, cfg)
+
+	basePath := "/v0/servers/" + url.PathEscape("io.github.example/weather") + "/versions/"
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("vscode package config", func(t *testing.T) {
+		w := get(basePath + "1.0.0/config?format=vscode")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.JSONEq(t, `{
+			"inputs": [{"type": "promptString", "id": "API_KEY", "password": true}],
+			"servers": {"weather": {"type": "stdio", "command": "npx", "args": ["-y", "@example/weather@1.0.0"], "env": {"API_KEY": "${input:API_KEY}"}}}
+		}`, w.Body.String())
+	})
+
+	t.Run("latest remote in the generic format", func(t *testing.T) {
+		w := get(basePath + "latest/config?remote=0")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "streamable-http", body["transport"])
+		assert.Equal(t, "https://weather.example.com/mcp", body["url"])
+	})
+
+	t.Run("invalid target", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, get(basePath+"1.0.0/config?package=3").Code)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnprocessableEntity, get(basePath+"1.0.0/config?format=unknown").Code)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, get(basePath+"9.9.9/config").Code)
+	})
+}
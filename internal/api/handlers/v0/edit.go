@@ -22,6 +22,7 @@ type EditServerInput struct {
 	ServerName    string           `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
 	Version       string           `path:"version" doc:"URL-encoded version to edit" example:"1.0.0"`
 	Status        string           `query:"status" doc:"New status for the server (active, deprecated, deleted)" required:"false" enum:"active,deprecated,deleted"`
+	StatusMessage string           `query:"status_message" doc:"Why the status is being changed, shown as statusMessage in the official metadata. Requires status." required:"false" maxLength:"500" example:"Use 2.0.0, which fixes a security issue"`
 	Body          apiv0.ServerJSON `body:""`
 }
 
@@ -91,6 +92,10 @@ func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.Reg
 			return nil, huma.Error400BadRequest("Version in request body must match URL path parameter")
 		}
 
+		if input.StatusMessage != "" && input.Status == "" {
+			return nil, huma.Error400BadRequest("status_message can only be given with status")
+		}
+
 		// Handle status changes with proper permission validation
 		if input.Status != "" {
 			newStatus := model.Status(input.Status)
@@ -112,12 +117,12 @@ func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.Reg
 		if input.Status != "" {
 			statusPtr = &input.Status
 		}
-		updatedServer, err := registry.UpdateServer(ctx, serverName, version, &input.Body, statusPtr)
+		updatedServer, err := registry.UpdateServer(ctx, serverName, version, &input.Body, statusPtr, input.StatusMessage)
 		if err != nil {
 			if errors.Is(err, database.ErrNotFound) {
 				return nil, huma.Error404NotFound("Server not found")
 			}
-			return nil, huma.Error400BadRequest("Failed to edit server", err)
+			return nil, badRequestError("Failed to edit server", err)
 		}
 
 		return &Response[apiv0.ServerResponse]{
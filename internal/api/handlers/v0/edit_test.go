@@ -87,7 +87,7 @@ func TestEditServerEndpoint(t *testing.T) {
 	require.NoError(t, err)
 
 	// Set the server to deleted status
-	_, err = registryService.UpdateServer(context.Background(), deletedServer.Name, deletedServer.Version, deletedServer, stringPtr(string(model.StatusDeleted)))
+	_, err = registryService.UpdateServer(context.Background(), deletedServer.Name, deletedServer.Version, deletedServer, stringPtr(string(model.StatusDeleted)), "")
 	require.NoError(t, err)
 
 	// Create a server with build metadata for URL encoding test
@@ -113,6 +113,7 @@ func TestEditServerEndpoint(t *testing.T) {
 		authHeader     string
 		requestBody    apiv0.ServerJSON
 		statusParam    string
+		statusMessage  string
 		expectedStatus int
 		expectedError  string
 		checkResult    func(*testing.T, *apiv0.ServerResponse)
@@ -173,6 +174,53 @@ func TestEditServerEndpoint(t *testing.T) {
 				assert.Equal(t, model.StatusDeprecated, resp.Meta.Official.Status)
 			},
 		},
+		{
+			name:       "status change with a status message",
+			serverName: "io.github.testuser/editable-server",
+			version:    "1.0.0",
+			authClaims: &auth.JWTClaims{
+				AuthMethod:        auth.MethodGitHubAT,
+				AuthMethodSubject: "testuser",
+				Permissions: []auth.Permission{
+					{Action: auth.PermissionActionEdit, ResourcePattern: "io.github.testuser/*"},
+				},
+			},
+			requestBody: apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "io.github.testuser/editable-server",
+				Description: "Server with status change",
+				Version:     "1.0.0",
+			},
+			statusParam:    "deprecated",
+			statusMessage:  "Use 2.0.0 instead",
+			expectedStatus: http.StatusOK,
+			checkResult: func(t *testing.T, resp *apiv0.ServerResponse) {
+				t.Helper()
+				assert.Equal(t, model.StatusDeprecated, resp.Meta.Official.Status)
+				assert.Equal(t, "Use 2.0.0 instead", resp.Meta.Official.StatusMessage)
+			},
+		},
+		{
+			name:       "status message without a status",
+			serverName: "io.github.testuser/editable-server",
+			version:    "1.0.0",
+			authClaims: &auth.JWTClaims{
+				AuthMethod:        auth.MethodGitHubAT,
+				AuthMethodSubject: "testuser",
+				Permissions: []auth.Permission{
+					{Action: auth.PermissionActionEdit, ResourcePattern: "io.github.testuser/*"},
+				},
+			},
+			requestBody: apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "io.github.testuser/editable-server",
+				Description: "Server with status change",
+				Version:     "1.0.0",
+			},
+			statusMessage:  "Use 2.0.0 instead",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "status_message can only be given with status",
+		},
 		{
 			name:           "missing authorization header",
 			serverName:     "io.github.testuser/editable-server",
@@ -381,8 +429,15 @@ func TestEditServerEndpoint(t *testing.T) {
 			encodedServerName := url.PathEscape(tc.serverName)
 			encodedVersion := url.PathEscape(tc.version)
 			requestURL := "/v0/servers/" + encodedServerName + "/versions/" + encodedVersion
+			query := url.Values{}
 			if tc.statusParam != "" {
-				requestURL += "?status=" + tc.statusParam
+				query.Set("status", tc.statusParam)
+			}
+			if tc.statusMessage != "" {
+				query.Set("status_message", tc.statusMessage)
+			}
+			if len(query) > 0 {
+				requestURL += "?" + query.Encode()
 			}
 
 			req := httptest.NewRequest(http.MethodPut, requestURL, bytes.NewReader(requestBody))
@@ -461,7 +516,7 @@ func TestEditServerEndpointEdgeCases(t *testing.T) {
 				Name:        server.name,
 				Description: "Test server for editing",
 				Version:     server.version,
-			}, stringPtr(string(server.status)))
+			}, stringPtr(string(server.status)), "")
 			require.NoError(t, err)
 		}
 	}
@@ -0,0 +1,134 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/importer"
+)
+
+// maxImportUploadBytes bounds the size of an uploaded import file
+const maxImportUploadBytes = 256 << 20
+
+// ImportForm is the multipart form accepted by the start import endpoint
+type ImportForm struct {
+	File               huma.FormFile `form:"file" contentType:"application/octet-stream" doc:"Seed file to import: a JSON array, NDJSON or registry list response, optionally gzipped"`
+	Source             string        `form:"source" doc:"URL to import from instead of uploading a file (seed file or registry /v0/servers URL)"`
+	Existing           string        `form:"existing" enum:"skip,overwrite,fail" default:"skip" doc:"What to do with versions that already exist"`
+	PreserveTimestamps bool          `form:"preserveTimestamps" doc:"Keep status and timestamps from registry-format input"`
+	DryRun             bool          `form:"dryRun" doc:"Report what would be imported without writing anything"`
+}
+
+// StartImportInput represents the input for starting an import job
+type StartImportInput struct {
+	Authorization string                              `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+	RawBody       huma.MultipartFormFiles[ImportForm] `contentType:"multipart/form-data"`
+}
+
+// GetImportInput represents the input for getting an import job
+type GetImportInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+	ID            string `path:"id" doc:"Import job ID"`
+}
+
+// RegisterImportEndpoints registers the admin import job endpoints with a custom path prefix
+func RegisterImportEndpoints(api huma.API, pathPrefix string, jobs *importer.JobManager, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID:  "start-import" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:       http.MethodPost,
+		Path:         pathPrefix + "/admin/imports",
+		Summary:      "Start import",
+		Description:  "Import servers from an uploaded file or a URL as a background job (admin only).",
+		Tags:         []string{"admin"},
+		MaxBodyBytes: maxImportUploadBytes,
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+		DefaultStatus: http.StatusAccepted,
+	}, func(ctx context.Context, input *StartImportInput) (*Response[importer.Job], error) {
+		if err := requireImportPermission(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		form := input.RawBody.Data()
+		if form.File.IsSet == (form.Source != "") {
+			return nil, huma.Error400BadRequest("Provide either a file or a source URL")
+		}
+
+		opts := importer.Options{
+			Existing:           importer.ExistingSkip,
+			PreserveTimestamps: form.PreserveTimestamps,
+			DryRun:             form.DryRun,
+		}
+		if form.Existing != "" {
+			policy, err := importer.ParseExistingPolicy(form.Existing)
+			if err != nil {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			opts.Existing = policy
+		}
+
+		if form.Source != "" {
+			// Only URLs are accepted, so admins cannot read arbitrary files from the server
+			if !strings.HasPrefix(form.Source, "http://") && !strings.HasPrefix(form.Source, "https://") {
+				return nil, huma.Error400BadRequest("Source must be an http or https URL")
+			}
+			return &Response[importer.Job]{Body: jobs.Start(form.Source, opts)}, nil
+		}
+
+		defer form.File.Close()
+		upload, err := importer.SpoolUpload(form.File)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to store uploaded file", err)
+		}
+		return &Response[importer.Job]{Body: jobs.StartReader(form.File.Filename, upload, opts)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-import" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/imports/{id}",
+		Summary:     "Get import",
+		Description: "Get the progress and per-server outcomes of an import job (admin only).",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *GetImportInput) (*Response[importer.Job], error) {
+		if err := requireImportPermission(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		job, ok := jobs.Get(input.ID)
+		if !ok {
+			return nil, huma.Error404NotFound("Import job not found")
+		}
+		return &Response[importer.Job]{Body: job}, nil
+	})
+}
+
+// requireImportPermission checks for a token with edit permission on every server,
+// since imports can create or overwrite any server
+func requireImportPermission(ctx context.Context, jwtManager *auth.JWTManager, authorization string) error {
+	const bearerPrefix = "Bearer "
+	if len(authorization) < len(bearerPrefix) || !strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		return huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
+	}
+
+	claims, err := jwtManager.ValidateToken(ctx, authorization[len(bearerPrefix):])
+	if err != nil {
+		return huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+	}
+
+	if !jwtManager.HasPermission("*", auth.PermissionActionEdit, claims.Permissions) {
+		return huma.Error403Forbidden("You do not have permission to import servers")
+	}
+	return nil
+}
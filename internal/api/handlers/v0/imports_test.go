@@ -0,0 +1,132 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+func TestImportEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+	jobs := importer.NewJobManager(context.Background(), importer.NewService(registryService))
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterImportEndpoints(api, "/v0", jobs, cfg)
+
+	jwtManager := auth.NewJWTManager(cfg)
+	tokenFor := func(pattern string) string {
+		tokenResponse, err := jwtManager.GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod: auth.MethodNone,
+			Permissions: []auth.Permission{
+				{Action: auth.PermissionActionEdit, ResourcePattern: pattern},
+			},
+		})
+		require.NoError(t, err)
+		return tokenResponse.RegistryToken
+	}
+
+	startImport := func(token string, fields map[string]string, file string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		for key, value := range fields {
+			require.NoError(t, writer.WriteField(key, value))
+		}
+		if file != "" {
+			part, err := writer.CreateFormFile("file", "seed.ndjson")
+			require.NoError(t, err)
+			_, err = part.Write([]byte(file))
+			require.NoError(t, err)
+		}
+		require.NoError(t, writer.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/v0/admin/imports", &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	getImport := func(token, id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v0/admin/imports/"+id, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	adminToken := tokenFor("*")
+	seed := `{"$schema":"https://static.modelcontextprotocol.io/schemas/2025-10-17/server.schema.json","name":"com.example/imported","description":"Imported","version":"1.0.0"}` + "\n"
+
+	t.Run("uploaded file is imported in the background", func(t *testing.T) {
+		rr := startImport(adminToken, map[string]string{"existing": "skip"}, seed)
+		require.Equal(t, http.StatusAccepted, rr.Code, rr.Body.String())
+
+		var started importer.Job
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &started))
+		assert.Equal(t, "seed.ndjson", started.Source)
+
+		var job importer.Job
+		require.Eventually(t, func() bool {
+			rr := getImport(adminToken, started.ID)
+			require.Equal(t, http.StatusOK, rr.Code)
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &job))
+			return job.State != importer.JobRunning
+		}, 5*time.Second, 20*time.Millisecond)
+
+		assert.Equal(t, importer.JobSucceeded, job.State)
+		assert.Equal(t, []importer.ReportEntry{{Name: "com.example/imported", Version: "1.0.0"}}, job.Report.Created)
+
+		_, err := registryService.GetServerByNameAndVersion(context.Background(), "com.example/imported", "1.0.0")
+		require.NoError(t, err)
+	})
+
+	t.Run("requires either a file or a source", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, startImport(adminToken, nil, "").Code)
+		assert.Equal(t, http.StatusBadRequest, startImport(adminToken, map[string]string{"source": "https://example.com/seed.json"}, seed).Code)
+	})
+
+	t.Run("rejects local paths as sources", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, startImport(adminToken, map[string]string{"source": "/etc/passwd"}, "").Code)
+	})
+
+	t.Run("requires global edit permission", func(t *testing.T) {
+		scopedToken := tokenFor("com.example/*")
+		assert.Equal(t, http.StatusForbidden, startImport(scopedToken, nil, seed).Code)
+		assert.Equal(t, http.StatusForbidden, getImport(scopedToken, "anything").Code)
+		assert.Equal(t, http.StatusUnauthorized, startImport("invalid", nil, seed).Code)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, getImport(adminToken, "missing").Code)
+	})
+}
@@ -0,0 +1,76 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/pkg/launch"
+)
+
+// LaunchRequest selects a package and supplies the values of its inputs
+type LaunchRequest struct {
+	Package int                 `json:"package,omitempty" doc:"Index of the package to launch" minimum:"0" default:"0"`
+	Inputs  map[string][]string `json:"inputs,omitempty" doc:"Input values by input ID: environment variable names, argument value hints or names without leading dashes, and template variable names. Repeated arguments accept several values."`
+}
+
+// LaunchInput represents the input for rendering how to launch a package
+type LaunchInput struct {
+	Authorization string        `header:"Authorization" doc:"Optional Registry JWT token, needed to see servers in non-public namespaces" required:"false"`
+	ServerName    string        `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version       string        `path:"version" doc:"URL-encoded server version, or 'latest'" example:"1.0.0"`
+	Body          LaunchRequest `body:""`
+}
+
+// RegisterLaunchEndpoint registers the launch command endpoint with a custom path prefix
+func RegisterLaunchEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	var jwtManager *auth.JWTManager
+	if len(cfg.NamespaceVisibility) > 0 {
+		jwtManager = auth.NewJWTManager(cfg)
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "launch-server-package" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/launch",
+		Summary:     "Render a package launch command",
+		Description: "Get the exact command, arguments and environment that start a package of a server version, " +
+			"filled in from the given input values. Input values are not stored.",
+		Tags: []string{"servers"},
+	}, func(ctx context.Context, input *LaunchInput) (*Response[launch.Launch], error) {
+		serverResponse, err := getReadableServer(ctx, registry, jwtManager, cfg, input.Authorization, input.ServerName, input.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		packages := serverResponse.Server.Packages
+		if input.Body.Package >= len(packages) {
+			return nil, huma.Error400BadRequest("Package does not exist")
+		}
+
+		started, err := launch.Package(&packages[input.Body.Package], launch.Options{Values: input.Body.Inputs})
+		if err != nil {
+			return nil, launchError(err)
+		}
+		return &Response[launch.Launch]{Body: *started}, nil
+	})
+}
+
+// launchError reports each missing or invalid input at its location in the request body
+func launchError(err error) error {
+	var inputsErr *launch.InvalidInputsError
+	if errors.As(err, &inputsErr) {
+		details := make([]error, len(inputsErr.Problems))
+		for i, problem := range inputsErr.Problems {
+			details[i] = &huma.ErrorDetail{Location: "body.inputs." + problem.ID, Message: problem.Message}
+		}
+		return huma.Error422UnprocessableEntity("Missing or invalid inputs", details...)
+	}
+	return huma.Error400BadRequest("Cannot launch package: " + err.Error())
+}
@@ -0,0 +1,100 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/launch"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestLaunchEndpoint(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{EnableRegistryValidation: false}
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.example/weather",
+		Description: "Weather server",
+		Version:     "1.0.0",
+		Packages: []model.Package{{
+			RegistryType: model.RegistryTypeNPM,
+			Identifier:   "@example/weather",
+			Version:      "1.0.0",
+			Transport:    model.Transport{Type: model.TransportTypeStdio},
+			PackageArguments: []model.Argument{
+				{Type: model.ArgumentTypeNamed, Name: "--units", InputWithVariables: model.InputWithVariables{Input: model.Input{Choices: []string{"metric", "imperial"}}}},
+			},
+			EnvironmentVariables: []model.KeyValueInput{
+				{Name: "API_KEY", InputWithVariables: model.InputWithVariables{Input: model.Input{IsRequired: true, IsSecret: true}}},
+			},
+		}},
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterLaunchEndpoint(api, "/v0", registryService, cfg)
+
+	basePath := "/v0/servers/" + url.PathEscape("io.github.example/weather") + "/versions/"
+	post := func(path string, body any) *httptest.ResponseRecorder {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("renders the launch command", func(t *testing.T) {
+		w := post(basePath+"latest/launch", v0.LaunchRequest{Inputs: map[string][]string{
+			"API_KEY": {"secret"},
+			"units":   {"imperial"},
+		}})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var started launch.Launch
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &started))
+		assert.Equal(t, "npx", started.Command)
+		assert.Equal(t, []string{"-y", "@example/weather@1.0.0", "--units", "imperial"}, started.Args)
+		assert.Equal(t, map[string]string{"API_KEY": "secret"}, started.Env)
+	})
+
+	t.Run("reports missing and invalid inputs", func(t *testing.T) {
+		w := post(basePath+"1.0.0/launch", v0.LaunchRequest{Inputs: map[string][]string{"units": {"kelvin"}}})
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+
+		var problem huma.ErrorModel
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		locations := make([]string, len(problem.Errors))
+		for i, detail := range problem.Errors {
+			locations[i] = detail.Location
+		}
+		assert.ElementsMatch(t, []string{"body.inputs.API_KEY", "body.inputs.units"}, locations)
+	})
+
+	t.Run("unknown package", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, post(basePath+"1.0.0/launch", v0.LaunchRequest{Package: 2}).Code)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, post(basePath+"9.9.9/launch", v0.LaunchRequest{}).Code)
+	})
+}
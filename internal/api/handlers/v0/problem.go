@@ -0,0 +1,48 @@
+package v0
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/pkg/validators"
+)
+
+// ValidationProblemError is an RFC 9457 problem details response listing every validation issue
+type ValidationProblemError struct {
+	Title  string                       `json:"title" doc:"Short summary of the problem" example:"Failed to publish server"`
+	Status int                          `json:"status" doc:"HTTP status code" example:"400"`
+	Detail string                       `json:"detail,omitempty" doc:"Human-readable explanation of every issue found"`
+	Errors []validators.ValidationIssue `json:"errors" doc:"Every validation issue found, located by JSON Pointer"`
+}
+
+func (p *ValidationProblemError) Error() string {
+	return p.Detail
+}
+
+func (p *ValidationProblemError) GetStatus() int {
+	return p.Status
+}
+
+// ContentType reports problem details as application/problem+json
+func (p *ValidationProblemError) ContentType(ct string) string {
+	if ct == "application/json" {
+		return "application/problem+json"
+	}
+	return ct
+}
+
+// badRequestError returns validation failures as problem details listing every issue,
+// and any other error as a plain 400 response
+func badRequestError(title string, err error) error {
+	var validationErr *validators.ValidationError
+	if errors.As(err, &validationErr) {
+		return &ValidationProblemError{
+			Title:  title,
+			Status: http.StatusBadRequest,
+			Detail: validationErr.Error(),
+			Errors: validationErr.Issues,
+		}
+	}
+	return huma.Error400BadRequest(title, err)
+}
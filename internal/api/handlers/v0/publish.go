@@ -2,7 +2,10 @@ package v0
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"reflect"
 	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
@@ -10,12 +13,41 @@ import (
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/schema"
+	"github.com/modelcontextprotocol/registry/pkg/validators"
 )
 
 // PublishServerInput represents the input for publishing a server
 type PublishServerInput struct {
 	Authorization string           `header:"Authorization" doc:"Registry JWT token (obtained from /v0/auth/token/github)" required:"true"`
+	DryRun        bool             `query:"dry_run" doc:"Run every publish check and return a validation report without publishing" default:"false"`
 	Body          apiv0.ServerJSON `body:""`
+	RawBody       []byte
+}
+
+// PublishServerOutput is either the published server, or a validation report for dry runs.
+// Its schema is declared by publishResponses, as the body type alone cannot describe both.
+type PublishServerOutput struct {
+	Body any
+}
+
+// publishResponses declares the 200 response of the publish endpoint as either a ServerResponse or,
+// for dry runs, a PublishReport
+func publishResponses(api huma.API) map[string]*huma.Response {
+	registry := api.OpenAPI().Components.Schemas
+	return map[string]*huma.Response{
+		"200": {
+			Description: "The published server, or the check report when dry_run=true",
+			Content: map[string]*huma.MediaType{
+				"application/json": {
+					Schema: &huma.Schema{OneOf: []*huma.Schema{
+						registry.Schema(reflect.TypeOf(apiv0.ServerResponse{}), true, ""),
+						registry.Schema(reflect.TypeOf(apiv0.PublishReport{}), true, ""),
+					}},
+				},
+			},
+		},
+	}
 }
 
 // RegisterPublishEndpoint registers the publish endpoint with a custom path prefix
@@ -28,44 +60,129 @@ func RegisterPublishEndpoint(api huma.API, pathPrefix string, registry service.R
 		Method:      http.MethodPost,
 		Path:        pathPrefix + "/publish",
 		Summary:     "Publish MCP server",
-		Description: "Publish a new MCP server to the registry or update an existing one",
+		Description: "Publish a new MCP server to the registry or update an existing one. With dry_run=true, returns a report of every publish check instead of publishing.",
 		Tags:        []string{"publish"},
 		Security: []map[string][]string{
 			{"bearer": {}},
 		},
-	}, func(ctx context.Context, input *PublishServerInput) (*Response[apiv0.ServerResponse], error) {
-		// Extract bearer token
-		const bearerPrefix = "Bearer "
-		authHeader := input.Authorization
-		if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
-			return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
+		Responses: publishResponses(api),
+		// The body is validated by upgradeServerJSON, after upgrading earlier schema versions
+		SkipValidateBody: true,
+	}, func(ctx context.Context, input *PublishServerInput) (*PublishServerOutput, error) {
+		originalSchemaVersion, err := upgradeServerJSON(api, input.RawBody, &input.Body)
+		if err != nil {
+			return nil, err
 		}
-		token := authHeader[len(bearerPrefix):]
 
-		// Validate Registry JWT token
-		claims, err := jwtManager.ValidateToken(ctx, token)
+		claims, err := validatePublishToken(ctx, jwtManager, input.Authorization)
 		if err != nil {
-			return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+			return nil, err
 		}
 
 		// Verify that the token has permission to publish the server
-		if !jwtManager.HasPermission(input.Body.Name, auth.PermissionActionPublish, claims.Permissions) {
+		hasPermission := jwtManager.HasPermission(input.Body.Name, auth.PermissionActionPublish, claims.Permissions)
+
+		if input.DryRun {
+			return dryRunPublish(ctx, registry, &input.Body, hasPermission, claims.Permissions)
+		}
+
+		if !hasPermission {
 			return nil, huma.Error403Forbidden(buildPermissionErrorMessage(input.Body.Name, claims.Permissions))
 		}
 
-		// Publish the server with extensions
-		publishedServer, err := registry.CreateServer(ctx, &input.Body)
+		// Publish the server with extensions, recording the schema version it was upgraded from
+		var publishedServer *apiv0.ServerResponse
+		if originalSchemaVersion != "" {
+			publishedServer, err = registry.CreateUpgradedServer(ctx, &input.Body, originalSchemaVersion)
+		} else {
+			publishedServer, err = registry.CreateServer(ctx, &input.Body)
+		}
 		if err != nil {
-			return nil, huma.Error400BadRequest("Failed to publish server", err)
+			return nil, badRequestError("Failed to publish server", err)
 		}
 
 		// Return the published server response with metadata
-		return &Response[apiv0.ServerResponse]{
+		return &PublishServerOutput{
 			Body: *publishedServer,
 		}, nil
 	})
 }
 
+// validatePublishToken extracts the bearer token from the Authorization header and validates it
+func validatePublishToken(ctx context.Context, jwtManager *auth.JWTManager, authHeader string) (*auth.JWTClaims, error) {
+	const bearerPrefix = "Bearer "
+	if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
+	}
+	token := authHeader[len(bearerPrefix):]
+
+	// Validate Registry JWT token
+	claims, err := jwtManager.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+	}
+	return claims, nil
+}
+
+// upgradeServerJSON upgrades a server.json written against an earlier schema version to the current
+// schema, then validates the request body against the ServerJSON schema. It returns the version the
+// server.json was upgraded from, or "" if it was already current.
+func upgradeServerJSON(api huma.API, rawBody []byte, body *apiv0.ServerJSON) (string, error) {
+	upgraded, version := rawBody, ""
+	if schema.NeedsUpgrade(body.Schema) {
+		var err error
+		upgraded, version, err = schema.Upgrade(rawBody)
+		if err != nil {
+			return "", huma.Error400BadRequest("Failed to upgrade server.json", err)
+		}
+		*body = apiv0.ServerJSON{}
+		if err := json.Unmarshal(upgraded, body); err != nil {
+			return "", huma.Error400BadRequest("Failed to upgrade server.json", err)
+		}
+	}
+
+	var data any
+	if err := json.Unmarshal(upgraded, &data); err != nil {
+		return "", huma.Error400BadRequest("Invalid request body", err)
+	}
+	registry := api.OpenAPI().Components.Schemas
+	result := &huma.ValidateResult{}
+	path := huma.NewPathBuffer([]byte{}, 0)
+	path.Push("body")
+	huma.Validate(registry, registry.Schema(reflect.TypeOf(apiv0.ServerJSON{}), true, ""), path, huma.ModeWriteToServer, data, result)
+	if len(result.Errors) > 0 {
+		return "", huma.Error422UnprocessableEntity("validation failed", result.Errors...)
+	}
+	return version, nil
+}
+
+// dryRunPublish runs every publish check, including the namespace permission check, and returns the report
+func dryRunPublish(ctx context.Context, registry service.RegistryService, serverJSON *apiv0.ServerJSON, hasPermission bool, permissions []auth.Permission) (*PublishServerOutput, error) {
+	var permissionErr error
+	if !hasPermission {
+		permissionErr = errors.New(buildPermissionErrorMessage(serverJSON.Name, permissions))
+	}
+	checks := []apiv0.PublishCheck{validators.NewPublishCheck("namespace-permission", permissionErr)}
+
+	serviceChecks, err := registry.CheckPublish(ctx, serverJSON)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to check server", err)
+	}
+	checks = append(checks, serviceChecks...)
+
+	report := apiv0.PublishReport{Valid: true, Checks: checks}
+	for _, check := range checks {
+		if check.Status == apiv0.CheckStatusFail {
+			report.Valid = false
+			break
+		}
+	}
+
+	return &PublishServerOutput{
+		Body: report,
+	}, nil
+}
+
 // buildPermissionErrorMessage creates a detailed error message showing what permissions
 // the user has and what they're trying to publish
 func buildPermissionErrorMessage(attemptedResource string, permissions []auth.Permission) string {
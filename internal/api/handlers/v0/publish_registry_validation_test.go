@@ -88,14 +88,14 @@ func TestPublishRegistryValidation(t *testing.T) {
 	t.Run("publish succeeds with MCPB package (registry validation enabled)", func(t *testing.T) {
 		publishReq := apiv0.ServerJSON{
 			Schema:      model.CurrentSchemaURL,
-			Name:        "com.example/test-server-mcpb-validation",
+			Name:        "io.github.domdomegg/airtable-mcp-server",
 			Description: "A test server with MCPB package and registry validation enabled",
-			Version:     "0.0.36",
+			Version:     "1.7.2",
 			Packages: []model.Package{
 				{
 					RegistryType: model.RegistryTypeMCPB,
-					Identifier:   "https://github.com/microsoft/playwright-mcp/releases/download/v0.0.36/playwright-mcp-extension-v0.0.36.zip",
-					FileSHA256:   "fe333e598595000ae021bd27117db32ec69af6987f507ba7a63c90638ff633ce",
+					Identifier:   "https://github.com/domdomegg/airtable-mcp-server/releases/download/v1.7.2/airtable-mcp-server.mcpb",
+					FileSHA256:   "8220de07a08ebe908f04da139ea03dbfe29758141347e945da60535fb7bcca20",
 					Transport: model.Transport{
 						Type: model.TransportTypeStdio,
 					},
@@ -138,14 +138,14 @@ func TestPublishRegistryValidation(t *testing.T) {
 	t.Run("publish fails when second package fails npm validation", func(t *testing.T) {
 		publishReq := apiv0.ServerJSON{
 			Schema:      model.CurrentSchemaURL,
-			Name:        "com.example/test-server-multiple-packages",
+			Name:        "io.github.domdomegg/airtable-mcp-server",
 			Description: "A test server with multiple packages where second fails",
 			Version:     "1.0.0",
 			Packages: []model.Package{
 				{
 					RegistryType: model.RegistryTypeMCPB,
-					Identifier:   "https://github.com/microsoft/playwright-mcp/releases/download/v0.0.36/playwright-mcp-extension-v0.0.36.zip",
-					FileSHA256:   "fe333e598595000ae021bd27117db32ec69af6987f507ba7a63c90638ff633ce",
+					Identifier:   "https://github.com/domdomegg/airtable-mcp-server/releases/download/v1.7.2/airtable-mcp-server.mcpb",
+					FileSHA256:   "8220de07a08ebe908f04da139ea03dbfe29758141347e945da60535fb7bcca20",
 					Transport: model.Transport{
 						Type: model.TransportTypeStdio,
 					},
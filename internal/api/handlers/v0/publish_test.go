@@ -20,6 +20,7 @@ import (
 	"github.com/modelcontextprotocol/registry/internal/service"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validators"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -524,3 +525,303 @@ func TestPublishEndpoint_MultipleSlashesEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestPublishEndpoint_DryRun(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+
+	// Publish an existing version so the dry run detects the duplicate
+	_, err = registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/dry-run-server",
+		Description: "Existing version",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, testConfig)
+
+	doDryRun := func(t *testing.T, serverJSON apiv0.ServerJSON, resourcePattern string) apiv0.PublishReport {
+		t.Helper()
+
+		bodyBytes, err := json.Marshal(serverJSON)
+		require.NoError(t, err)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/publish?dry_run=true", bytes.NewBuffer(bodyBytes))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod: auth.MethodNone,
+			Permissions: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: resourcePattern},
+			},
+		})
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var report apiv0.PublishReport
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+		return report
+	}
+
+	checkStatuses := func(report apiv0.PublishReport) map[string]apiv0.CheckStatus {
+		statuses := make(map[string]apiv0.CheckStatus)
+		for _, check := range report.Checks {
+			statuses[check.Name] = check.Status
+		}
+		return statuses
+	}
+
+	t.Run("valid new version", func(t *testing.T) {
+		report := doDryRun(t, apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/dry-run-server",
+			Description: "New version",
+			Version:     "2.0.0",
+		}, "com.example/*")
+
+		assert.True(t, report.Valid)
+		for _, check := range report.Checks {
+			assert.NotEqual(t, apiv0.CheckStatusFail, check.Status, check.Name)
+		}
+
+		// Nothing should have been written
+		_, err := registryService.GetServerByNameAndVersion(context.Background(), "com.example/dry-run-server", "2.0.0")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("reports every failing check", func(t *testing.T) {
+		report := doDryRun(t, apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/dry-run-server",
+			Description: "Duplicate version",
+			Version:     "1.0.0",
+			Remotes: []model.Transport{
+				{Type: model.TransportTypeStreamableHTTP, URL: "https://other.org/mcp"},
+			},
+		}, "io.github.someone/*")
+
+		assert.False(t, report.Valid)
+		statuses := checkStatuses(report)
+		assert.Equal(t, apiv0.CheckStatusFail, statuses["namespace-permission"])
+		assert.Equal(t, apiv0.CheckStatusFail, statuses["server-json: /remotes/0/url"])
+		assert.Equal(t, apiv0.CheckStatusFail, statuses["version-uniqueness"])
+		assert.Equal(t, apiv0.CheckStatusPass, statuses["duplicate-remotes"])
+	})
+}
+
+func TestPublishEndpoint_ResponseSchemas(t *testing.T) {
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", &validatingRegistryService{}, &config.Config{JWTPrivateKey: hex.EncodeToString(make([]byte, ed25519.SeedSize))})
+
+	operation := api.OpenAPI().Paths["/v0/publish"].Post
+	require.NotNil(t, operation)
+	schema := operation.Responses["200"].Content["application/json"].Schema
+	require.Len(t, schema.OneOf, 2)
+	assert.Equal(t, "#/components/schemas/ServerResponse", schema.OneOf[0].Ref)
+	assert.Equal(t, "#/components/schemas/PublishReport", schema.OneOf[1].Ref)
+}
+
+func TestPublishEndpoint_DryRunDoesNotPublish(t *testing.T) {
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(make([]byte, ed25519.SeedSize))}
+	registryService := &validatingRegistryService{}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, testConfig)
+
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod:  auth.MethodNone,
+		Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"}},
+	})
+	require.NoError(t, err)
+	bodyBytes, err := json.Marshal(apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/dry-run-server",
+		Description: "A server checked without publishing",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/v0/publish?dry_run=true", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var report apiv0.PublishReport
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+	assert.True(t, report.Valid)
+	assert.NotEmpty(t, report.Checks)
+	assert.Zero(t, registryService.published, "a dry run must not publish")
+}
+
+func TestPublishEndpoint_UpgradesEarlierSchemaVersions(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, testConfig)
+
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod:  auth.MethodNone,
+		Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"}},
+	})
+	require.NoError(t, err)
+
+	publish := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v0/publish", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("2025-07-09 server.json is upgraded", func(t *testing.T) {
+		rr := publish(`{
+			"$schema": "https://static.modelcontextprotocol.io/schemas/2025-07-09/server.schema.json",
+			"name": "com.example/legacy-server",
+			"description": "Published with the first schema",
+			"status": "active",
+			"version": "1.0.0",
+			"packages": [{
+				"registry_type": "npm",
+				"identifier": "@example/legacy-server",
+				"version": "1.0.0",
+				"transport": {"type": "stdio"},
+				"environment_variables": [{"name": "API_KEY", "is_required": true, "is_secret": true}]
+			}]
+		}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		stored, err := registryService.GetServerByNameAndVersion(context.Background(), "com.example/legacy-server", "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, model.CurrentSchemaURL, stored.Server.Schema)
+		require.Len(t, stored.Server.Packages, 1)
+		assert.Equal(t, model.RegistryTypeNPM, stored.Server.Packages[0].RegistryType)
+		require.Len(t, stored.Server.Packages[0].EnvironmentVariables, 1)
+		assert.True(t, stored.Server.Packages[0].EnvironmentVariables[0].IsSecret)
+		assert.Equal(t, "2025-07-09", stored.Meta.Official.OriginalSchemaVersion)
+	})
+
+	t.Run("current server.json is not marked as upgraded", func(t *testing.T) {
+		rr := publish(`{"$schema": "` + model.CurrentSchemaURL + `", "name": "com.example/current-server", "description": "Current", "version": "1.0.0"}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response apiv0.ServerResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Empty(t, response.Meta.Official.OriginalSchemaVersion)
+	})
+
+	t.Run("upgraded server.json is still validated", func(t *testing.T) {
+		rr := publish(`{
+			"$schema": "https://static.modelcontextprotocol.io/schemas/2025-09-16/server.schema.json",
+			"name": "com.example//invalid",
+			"description": "Invalid name",
+			"version": "1.0.0"
+		}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		assert.Contains(t, rr.Body.String(), "expected string to match pattern")
+	})
+}
+
+// validatingRegistryService runs publish validation without a database, and without registry validation
+type validatingRegistryService struct {
+	service.RegistryService
+	published int
+}
+
+func (s *validatingRegistryService) CreateServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+	if err := validators.ValidatePublishRequest(ctx, *req, validators.Options{}); err != nil {
+		return nil, err
+	}
+	s.published++
+	return &apiv0.ServerResponse{Server: *req}, nil
+}
+
+func (s *validatingRegistryService) CheckPublish(ctx context.Context, req *apiv0.ServerJSON) ([]apiv0.PublishCheck, error) {
+	return validators.CheckPublishRequest(ctx, *req, validators.Options{}), nil
+}
+
+func TestPublishEndpoint_ValidationProblemDetails(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", &validatingRegistryService{}, testConfig)
+
+	bodyBytes, err := json.Marshal(apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/test-server",
+		Description: "A server with several problems",
+		Version:     "latest",
+		Remotes: []model.Transport{
+			{Type: model.TransportTypeStdio},
+			{Type: model.TransportTypeStreamableHTTP, URL: "https://other.org/mcp"},
+		},
+	})
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/publish", bytes.NewBuffer(bodyBytes))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod: auth.MethodNone,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "application/problem+json", rr.Header().Get("Content-Type"))
+
+	var problem v0.ValidationProblemError
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+	assert.Equal(t, "Failed to publish server", problem.Title)
+	assert.Equal(t, http.StatusBadRequest, problem.Status)
+
+	paths := make([]string, len(problem.Errors))
+	for i, issue := range problem.Errors {
+		paths[i] = issue.Path
+		assert.NotEmpty(t, issue.Code)
+		assert.Equal(t, validators.SeverityError, issue.Severity)
+	}
+	assert.Equal(t, []string{"/remotes/0", "/version", "/remotes/0/type", "/remotes/1/url"}, paths)
+	assert.Equal(t, validators.CodeSchemaViolation, problem.Errors[0].Code)
+}
@@ -0,0 +1,44 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/pkg/schema"
+)
+
+// JSONSchemaInput represents the input for getting the server.json JSON Schema of a schema version
+type JSONSchemaInput struct {
+	Version string `path:"version" doc:"Schema version date" example:"2025-10-17" pattern:"^[0-9]{4}-[0-9]{2}-[0-9]{2}$"`
+}
+
+// JSONSchemaOutput is the raw JSON Schema document
+type JSONSchemaOutput struct {
+	ContentType  string `header:"Content-Type"`
+	CacheControl string `header:"Cache-Control"`
+	Body         []byte
+}
+
+// RegisterSchemaEndpoint registers the endpoint serving the server.json JSON Schemas publishes are validated against
+func RegisterSchemaEndpoint(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-json-schema",
+		Method:      http.MethodGet,
+		Path:        "/schemas/{version}/server.schema.json",
+		Summary:     "Get server.json JSON Schema",
+		Description: "Returns the JSON Schema for a server.json schema version, as used to validate publishes and edits",
+		Tags:        []string{"publish"},
+	}, func(_ context.Context, input *JSONSchemaInput) (*JSONSchemaOutput, error) {
+		data, ok := schema.JSONSchema(input.Version)
+		if !ok {
+			return nil, huma.Error404NotFound("No JSON Schema is available for schema version " + input.Version)
+		}
+		return &JSONSchemaOutput{
+			ContentType:  "application/schema+json",
+			CacheControl: "public, max-age=86400",
+			Body:         data,
+		}, nil
+	})
+}
@@ -0,0 +1,39 @@
+package v0_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestSchemaEndpoint(t *testing.T) {
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterSchemaEndpoint(api)
+
+	t.Run("current schema version", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/schemas/"+model.CurrentSchemaVersion+"/server.schema.json", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/schema+json", w.Header().Get("Content-Type"))
+		var jsonSchema map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &jsonSchema))
+		assert.Equal(t, model.CurrentSchemaURL, jsonSchema["$id"])
+	})
+
+	t.Run("unknown schema version", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/schemas/2020-01-01/server.schema.json", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
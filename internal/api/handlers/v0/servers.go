@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
@@ -18,31 +20,43 @@ const errRecordNotFound = "record not found"
 
 // ListServersInput represents the input for listing servers
 type ListServersInput struct {
-	Cursor       string `query:"cursor" doc:"Pagination cursor" required:"false" example:"server-cursor-123"`
-	Limit        int    `query:"limit" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100" example:"50"`
-	UpdatedSince string `query:"updated_since" doc:"Filter servers updated since timestamp (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
-	Search       string `query:"search" doc:"Search servers by name (substring match)" required:"false" example:"filesystem"`
-	Version      string `query:"version" doc:"Filter by version ('latest' for latest version, or an exact version like '1.2.3')" required:"false" example:"latest"`
+	Authorization string `header:"Authorization" doc:"Optional Registry JWT token, needed to see servers in non-public namespaces" required:"false"`
+	Cursor        string `query:"cursor" doc:"Pagination cursor" required:"false" example:"server-cursor-123"`
+	Limit         int    `query:"limit" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100" example:"50"`
+	UpdatedSince  string `query:"updated_since" doc:"Filter servers updated since timestamp (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
+	Search        string `query:"search" doc:"Search servers by name (substring match)" required:"false" example:"filesystem"`
+	Version       string `query:"version" doc:"Filter by version ('latest' for latest version, or an exact version like '1.2.3')" required:"false" example:"latest"`
+	Tool          string `query:"tool" doc:"Filter servers exposing a tool with this exact name" required:"false" example:"create_issue"`
+	Platform      string `query:"platform" doc:"Filter servers with an OCI image supporting this platform (os/arch, with an optional variant)" required:"false" pattern:"^[a-z0-9]+/[a-z0-9]+(/[a-z0-9]+)?$" example:"linux/arm64"`
 }
 
 // ServerDetailInput represents the input for getting server details
 type ServerDetailInput struct {
-	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Authorization string `header:"Authorization" doc:"Optional Registry JWT token, needed to see servers in non-public namespaces" required:"false"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
 }
 
 // ServerVersionDetailInput represents the input for getting a specific version
 type ServerVersionDetailInput struct {
-	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
-	Version    string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+	Authorization string `header:"Authorization" doc:"Optional Registry JWT token, needed to see servers in non-public namespaces" required:"false"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version       string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
 }
 
 // ServerVersionsInput represents the input for listing all versions of a server
 type ServerVersionsInput struct {
-	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Authorization string `header:"Authorization" doc:"Optional Registry JWT token, needed to see servers in non-public namespaces" required:"false"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
 }
 
 // RegisterServersEndpoints registers all server-related endpoints with a custom path prefix
-func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	// Registry tokens only affect reads when namespace visibility rules are configured
+	var jwtManager *auth.JWTManager
+	if len(cfg.NamespaceVisibility) > 0 {
+		jwtManager = auth.NewJWTManager(cfg)
+	}
+
 	// List servers endpoint
 	huma.Register(api, huma.Operation{
 		OperationID: "list-servers" + strings.ReplaceAll(pathPrefix, "/", "-"),
@@ -52,35 +66,16 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		Description: "Get a paginated list of MCP servers from the registry",
 		Tags:        []string{"servers"},
 	}, func(ctx context.Context, input *ListServersInput) (*Response[apiv0.ServerListResponse], error) {
-		// Build filter from input parameters
-		filter := &database.ServerFilter{}
-
-		// Parse updated_since parameter
-		if input.UpdatedSince != "" {
-			// Parse RFC3339 format
-			if updatedTime, err := time.Parse(time.RFC3339, input.UpdatedSince); err == nil {
-				filter.UpdatedSince = &updatedTime
-			} else {
-				return nil, huma.Error400BadRequest("Invalid updated_since format: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04.280Z)")
-			}
-		}
-
-		// Handle search parameter
-		if input.Search != "" {
-			filter.SubstringName = &input.Search
+		reader, err := resolveReader(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
 		}
 
-		// Handle version parameter
-		if input.Version != "" {
-			if input.Version == "latest" {
-				// Special case: filter for latest versions
-				isLatest := true
-				filter.IsLatest = &isLatest
-			} else {
-				// Future: exact version matching
-				filter.Version = &input.Version
-			}
+		filter, err := buildServerFilter(input)
+		if err != nil {
+			return nil, err
 		}
+		filter.HiddenNamespaces = reader.HiddenNamespaces(cfg.NamespaceVisibility)
 
 		// Get paginated results with filtering
 		servers, nextCursor, err := registry.ListServers(ctx, filter, input.Cursor, input.Limit)
@@ -89,9 +84,11 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		}
 
 		// Convert []*ServerResponse to []ServerResponse
+		// Capabilities are only included on detail responses to keep list pages small
 		serverValues := make([]apiv0.ServerResponse, len(servers))
 		for i, server := range servers {
 			serverValues[i] = *server
+			serverValues[i].Meta.Capabilities = nil
 		}
 
 		return &Response[apiv0.ServerListResponse]{
@@ -114,31 +111,9 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		Description: "Get detailed information about a specific version of an MCP server. Use the special version 'latest' to get the latest version.",
 		Tags:        []string{"servers"},
 	}, func(ctx context.Context, input *ServerVersionDetailInput) (*Response[apiv0.ServerResponse], error) {
-		// URL-decode the server name
-		serverName, err := url.PathUnescape(input.ServerName)
-		if err != nil {
-			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
-		}
-
-		// URL-decode the version
-		version, err := url.PathUnescape(input.Version)
-		if err != nil {
-			return nil, huma.Error400BadRequest("Invalid version encoding", err)
-		}
-
-		var serverResponse *apiv0.ServerResponse
-		// Handle "latest" as a special version
-		if version == "latest" {
-			serverResponse, err = registry.GetServerByName(ctx, serverName)
-		} else {
-			serverResponse, err = registry.GetServerByNameAndVersion(ctx, serverName, version)
-		}
-
+		serverResponse, err := getReadableServer(ctx, registry, jwtManager, cfg, input.Authorization, input.ServerName, input.Version)
 		if err != nil {
-			if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) {
-				return nil, huma.Error404NotFound("Server not found")
-			}
-			return nil, huma.Error500InternalServerError("Failed to get server details", err)
+			return nil, err
 		}
 
 		return &Response[apiv0.ServerResponse]{
@@ -161,6 +136,14 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
 		}
 
+		reader, err := resolveReader(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if !reader.CanRead(cfg.NamespaceVisibility, serverName) {
+			return nil, huma.Error404NotFound("Server not found")
+		}
+
 		// Get all versions for this server
 		servers, err := registry.GetAllVersionsByServerName(ctx, serverName)
 		if err != nil {
@@ -186,3 +169,104 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		}, nil
 	})
 }
+
+// buildServerFilter builds the database filter from list query parameters
+func buildServerFilter(input *ListServersInput) (*database.ServerFilter, error) {
+	filter := &database.ServerFilter{}
+
+	// Parse updated_since parameter
+	if input.UpdatedSince != "" {
+		// Parse RFC3339 format
+		if updatedTime, err := time.Parse(time.RFC3339, input.UpdatedSince); err == nil {
+			filter.UpdatedSince = &updatedTime
+		} else {
+			return nil, huma.Error400BadRequest("Invalid updated_since format: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04.280Z)")
+		}
+	}
+
+	// Handle search parameter
+	if input.Search != "" {
+		filter.SubstringName = &input.Search
+	}
+
+	// Handle version parameter
+	if input.Version != "" {
+		if input.Version == "latest" {
+			// Special case: filter for latest versions
+			isLatest := true
+			filter.IsLatest = &isLatest
+		} else {
+			// Future: exact version matching
+			filter.Version = &input.Version
+		}
+	}
+
+	// Handle tool parameter
+	if input.Tool != "" {
+		filter.ToolName = &input.Tool
+	}
+
+	// Handle platform parameter
+	if input.Platform != "" {
+		filter.Platform = &input.Platform
+	}
+
+	return filter, nil
+}
+
+// resolveReader identifies the caller of a read endpoint from its optional bearer token
+func resolveReader(ctx context.Context, jwtManager *auth.JWTManager, authorization string) (auth.Reader, error) {
+	if jwtManager == nil || authorization == "" {
+		return auth.Reader{}, nil
+	}
+
+	const bearerPrefix = "Bearer "
+	if len(authorization) < len(bearerPrefix) || !strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		return auth.Reader{}, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
+	}
+
+	claims, err := jwtManager.ValidateToken(ctx, authorization[len(bearerPrefix):])
+	if err != nil {
+		return auth.Reader{}, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+	}
+
+	return auth.Reader{Claims: claims}, nil
+}
+
+// getReadableServer loads a server version, or its latest version for "latest", from URL-encoded path
+// parameters, reporting servers the caller cannot read as not found
+func getReadableServer(
+	ctx context.Context, registry service.RegistryService, jwtManager *auth.JWTManager, cfg *config.Config,
+	authorization, encodedName, encodedVersion string,
+) (*apiv0.ServerResponse, error) {
+	serverName, err := url.PathUnescape(encodedName)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+	}
+	version, err := url.PathUnescape(encodedVersion)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid version encoding", err)
+	}
+
+	reader, err := resolveReader(ctx, jwtManager, authorization)
+	if err != nil {
+		return nil, err
+	}
+	if !reader.CanRead(cfg.NamespaceVisibility, serverName) {
+		return nil, huma.Error404NotFound("Server not found")
+	}
+
+	var serverResponse *apiv0.ServerResponse
+	if version == "latest" {
+		serverResponse, err = registry.GetServerByName(ctx, serverName)
+	} else {
+		serverResponse, err = registry.GetServerByNameAndVersion(ctx, serverName, version)
+	}
+	if err != nil {
+		if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) {
+			return nil, huma.Error404NotFound("Server not found")
+		}
+		return nil, huma.Error500InternalServerError("Failed to get server details", err)
+	}
+	return serverResponse, nil
+}
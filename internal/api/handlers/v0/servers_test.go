@@ -11,6 +11,7 @@ import (
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
@@ -44,7 +45,7 @@ func TestListServersEndpoint(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
 
 	tests := []struct {
 		name           string
@@ -130,7 +131,7 @@ func TestGetLatestServerVersionEndpoint(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
 
 	tests := []struct {
 		name           string
@@ -210,7 +211,7 @@ func TestGetServerVersionEndpoint(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
 
 	tests := []struct {
 		name           string
@@ -321,7 +322,7 @@ func TestGetAllVersionsEndpoint(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
 
 	tests := []struct {
 		name           string
@@ -420,7 +421,7 @@ func TestServersEndpointEdgeCases(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
 
 	t.Run("URL encoding edge cases", func(t *testing.T) {
 		tests := []struct {
@@ -518,3 +519,77 @@ func TestServersEndpointEdgeCases(t *testing.T) {
 		}
 	})
 }
+
+func TestServersEndpoint_NamespaceVisibility(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{
+		JWTPrivateKey: "0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20",
+		NamespaceVisibility: config.NamespaceVisibility{
+			{Namespace: "com.example/*", Visibility: config.VisibilityPrivate},
+		},
+	}
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	for _, name := range []string{"com.example/internal", "io.github.user/public"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Visibility test server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, cfg)
+
+	tokenResponse, err := auth.NewJWTManager(cfg).GenerateTokenResponse(ctx, auth.JWTClaims{
+		AuthMethod:  auth.MethodOIDC,
+		Permissions: []auth.Permission{{Action: auth.PermissionActionRead, ResourcePattern: "com.example/*"}},
+	})
+	require.NoError(t, err)
+
+	get := func(path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	privatePath := "/v0/servers/" + url.PathEscape("com.example/internal")
+
+	t.Run("anonymous list hides private servers", func(t *testing.T) {
+		w := get("/v0/servers", "")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp apiv0.ServerListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Len(t, resp.Servers, 1)
+		assert.Equal(t, "io.github.user/public", resp.Servers[0].Server.Name)
+	})
+
+	t.Run("anonymous detail of private server is not found", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, get(privatePath+"/versions/latest", "").Code)
+		assert.Equal(t, http.StatusNotFound, get(privatePath+"/versions/1.0.0", "").Code)
+		assert.Equal(t, http.StatusNotFound, get(privatePath+"/versions", "").Code)
+	})
+
+	t.Run("read permission shows private servers", func(t *testing.T) {
+		w := get("/v0/servers", tokenResponse.RegistryToken)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp apiv0.ServerListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Len(t, resp.Servers, 2)
+
+		assert.Equal(t, http.StatusOK, get(privatePath+"/versions/latest", tokenResponse.RegistryToken).Code)
+		assert.Equal(t, http.StatusOK, get(privatePath+"/versions", tokenResponse.RegistryToken).Code)
+	})
+
+	t.Run("invalid token is rejected", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, get("/v0/servers", "not-a-token").Code)
+	})
+}
@@ -0,0 +1,27 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/mirror"
+)
+
+// RegisterSyncStatusEndpoint registers the upstream sync status endpoint with a custom path prefix
+func RegisterSyncStatusEndpoint(api huma.API, pathPrefix string, syncer *mirror.Syncer) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-sync-status" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/sync/status",
+		Summary:     "Upstream sync status",
+		Description: "Get the state of syncing from the upstream registry, when this registry runs as a mirror",
+		Tags:        []string{"health"},
+	}, func(_ context.Context, _ *struct{}) (*Response[mirror.Status], error) {
+		return &Response[mirror.Status]{
+			Body: syncer.Status(),
+		}, nil
+	})
+}
@@ -181,6 +181,9 @@ func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.
 	RegisterV0Routes(api, cfg, registry, metrics, versionInfo)
 	RegisterV0_1Routes(api, cfg, registry, metrics, versionInfo)
 
+	// Serve the server.json JSON Schemas at the same paths as their $schema URLs
+	v0.RegisterSchemaEndpoint(api)
+
 	// Add /metrics for Prometheus metrics using promhttp
 	mux.Handle("/metrics", metrics.PrometheusHandler())
 
@@ -17,7 +17,9 @@ func RegisterV0Routes(
 	v0.RegisterHealthEndpoint(api, "/v0", cfg, metrics)
 	v0.RegisterPingEndpoint(api, "/v0")
 	v0.RegisterVersionEndpoint(api, "/v0", versionInfo)
-	v0.RegisterServersEndpoints(api, "/v0", registry)
+	v0.RegisterServersEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterClientConfigEndpoint(api, "/v0", registry, cfg)
+	v0.RegisterLaunchEndpoint(api, "/v0", registry, cfg)
 	v0.RegisterEditEndpoints(api, "/v0", registry, cfg)
 	v0auth.RegisterAuthEndpoints(api, "/v0", cfg)
 	v0.RegisterPublishEndpoint(api, "/v0", registry, cfg)
@@ -29,7 +31,9 @@ func RegisterV0_1Routes(
 	v0.RegisterHealthEndpoint(api, "/v0.1", cfg, metrics)
 	v0.RegisterPingEndpoint(api, "/v0.1")
 	v0.RegisterVersionEndpoint(api, "/v0.1", versionInfo)
-	v0.RegisterServersEndpoints(api, "/v0.1", registry)
+	v0.RegisterServersEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterClientConfigEndpoint(api, "/v0.1", registry, cfg)
+	v0.RegisterLaunchEndpoint(api, "/v0.1", registry, cfg)
 	v0.RegisterEditEndpoints(api, "/v0.1", registry, cfg)
 	v0auth.RegisterAuthEndpoints(api, "/v0.1", cfg)
 	v0.RegisterPublishEndpoint(api, "/v0.1", registry, cfg)
@@ -12,6 +12,8 @@ import (
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/api/router"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/mirror"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
@@ -66,6 +68,18 @@ func NewServer(cfg *config.Config, registryService service.RegistryService, metr
 	return server
 }
 
+// RegisterSyncStatus exposes the status of an upstream syncer when the registry runs as a mirror
+func (s *Server) RegisterSyncStatus(syncer *mirror.Syncer) {
+	v0.RegisterSyncStatusEndpoint(s.humaAPI, "/v0", syncer)
+	v0.RegisterSyncStatusEndpoint(s.humaAPI, "/v0.1", syncer)
+}
+
+// RegisterImportJobs exposes the admin endpoints for starting and tracking import jobs
+func (s *Server) RegisterImportJobs(jobs *importer.JobManager) {
+	v0.RegisterImportEndpoints(s.humaAPI, "/v0", jobs, s.config)
+	v0.RegisterImportEndpoints(s.humaAPI, "/v0.1", jobs, s.config)
+}
+
 // Start begins listening for incoming HTTP requests
 func (s *Server) Start() error {
 	log.Printf("HTTP server starting on %s", s.config.ServerAddress)
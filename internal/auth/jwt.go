@@ -19,10 +19,12 @@ const (
 	PermissionActionPublish PermissionAction = "publish"
 	// Intended for admins taking moderation actions only, at least for now
 	PermissionActionEdit PermissionAction = "edit"
+	// Grants access to servers in private namespaces (publish and edit permissions also imply it)
+	PermissionActionRead PermissionAction = "read"
 )
 
 type Permission struct {
-	Action          PermissionAction `json:"action"`   // The action type (publish or edit)
+	Action          PermissionAction `json:"action"`   // The action type (publish, edit or read)
 	ResourcePattern string           `json:"resource"` // e.g., "io.github.username/*"
 }
 
@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// Reader is a caller of the read endpoints, checked against namespace visibility rules
+type Reader struct {
+	// Claims from the caller's registry token, or nil for anonymous callers
+	Claims *JWTClaims
+}
+
+// CanRead reports whether the reader may see the server, according to the most specific matching rule
+func (r Reader) CanRead(rules config.NamespaceVisibility, serverName string) bool {
+	rule := rules.Match(serverName)
+	if rule == nil {
+		return true
+	}
+
+	switch rule.Visibility {
+	case config.VisibilityAuthenticated:
+		return r.Claims != nil
+	case config.VisibilityPrivate:
+		for _, pattern := range r.readPatterns() {
+			if isResourceMatch(serverName, pattern) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// HiddenNamespaces returns the namespaces the reader cannot see, for filtering server lists.
+// Each hidden rule is excepted for more specific rules and read grants within it that the reader can see.
+func (r Reader) HiddenNamespaces(rules config.NamespaceVisibility) []database.NamespaceExclusion {
+	var hidden []database.NamespaceExclusion
+	for _, rule := range rules {
+		if r.canReadNamespace(rule) {
+			continue
+		}
+
+		exclusion := database.NamespaceExclusion{Pattern: rule.Namespace}
+		for _, other := range rules {
+			if other.Namespace != rule.Namespace && config.NamespaceCovers(rule.Namespace, other.Namespace) && r.canReadNamespace(other) {
+				exclusion.Except = append(exclusion.Except, other.Namespace)
+			}
+		}
+		if rule.Visibility == config.VisibilityPrivate {
+			for _, pattern := range r.readPatterns() {
+				if config.NamespaceCovers(rule.Namespace, pattern) {
+					exclusion.Except = append(exclusion.Except, pattern)
+				}
+			}
+		}
+		hidden = append(hidden, exclusion)
+	}
+	return hidden
+}
+
+// canReadNamespace reports whether the reader may see every server the rule applies to
+func (r Reader) canReadNamespace(rule config.NamespaceVisibilityRule) bool {
+	switch rule.Visibility {
+	case config.VisibilityAuthenticated:
+		return r.Claims != nil
+	case config.VisibilityPrivate:
+		for _, pattern := range r.readPatterns() {
+			if config.NamespaceCovers(pattern, rule.Namespace) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// readPatterns returns the resource patterns the reader may read private servers in.
+// Publishers and admins can always read the namespaces they manage.
+func (r Reader) readPatterns() []string {
+	if r.Claims == nil {
+		return nil
+	}
+
+	var patterns []string
+	for _, perm := range r.Claims.Permissions {
+		switch perm.Action {
+		case PermissionActionRead, PermissionActionPublish, PermissionActionEdit:
+			patterns = append(patterns, perm.ResourcePattern)
+		}
+	}
+	return patterns
+}
@@ -0,0 +1,80 @@
+package auth_test
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/stretchr/testify/assert"
+)
+
+var visibilityRules = config.NamespaceVisibility{
+	{Namespace: "com.example/*", Visibility: config.VisibilityPrivate},
+	{Namespace: "com.example/docs-*", Visibility: config.VisibilityPublic},
+	{Namespace: "com.example.staff/*", Visibility: config.VisibilityAuthenticated},
+}
+
+func TestReader_CanRead(t *testing.T) {
+	anonymous := auth.Reader{}
+	authenticated := auth.Reader{Claims: &auth.JWTClaims{}}
+	teamReader := auth.Reader{Claims: &auth.JWTClaims{Permissions: []auth.Permission{
+		{Action: auth.PermissionActionRead, ResourcePattern: "com.example/team-*"},
+	}}}
+	publisher := auth.Reader{Claims: &auth.JWTClaims{Permissions: []auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"},
+	}}}
+
+	tests := []struct {
+		name       string
+		reader     auth.Reader
+		serverName string
+		expected   bool
+	}{
+		{"unconfigured namespace is public", anonymous, "io.github.user/server", true},
+		{"private namespace hidden from anonymous callers", anonymous, "com.example/internal", false},
+		{"private namespace hidden without a matching grant", authenticated, "com.example/internal", false},
+		{"more specific public rule wins", anonymous, "com.example/docs-server", true},
+		{"read grant within private namespace", teamReader, "com.example/team-server", true},
+		{"read grant does not extend past its pattern", teamReader, "com.example/internal", false},
+		{"publish permission implies read", publisher, "com.example/internal", true},
+		{"authenticated namespace hidden from anonymous callers", anonymous, "com.example.staff/server", false},
+		{"authenticated namespace visible with any token", authenticated, "com.example.staff/server", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.reader.CanRead(visibilityRules, tt.serverName))
+		})
+	}
+}
+
+func TestReader_HiddenNamespaces(t *testing.T) {
+	t.Run("anonymous", func(t *testing.T) {
+		hidden := auth.Reader{}.HiddenNamespaces(visibilityRules)
+		assert.Equal(t, []database.NamespaceExclusion{
+			{Pattern: "com.example/*", Except: []string{"com.example/docs-*"}},
+			{Pattern: "com.example.staff/*"},
+		}, hidden)
+	})
+
+	t.Run("read grant within private namespace", func(t *testing.T) {
+		reader := auth.Reader{Claims: &auth.JWTClaims{Permissions: []auth.Permission{
+			{Action: auth.PermissionActionRead, ResourcePattern: "com.example/team-*"},
+		}}}
+		assert.Equal(t, []database.NamespaceExclusion{
+			{Pattern: "com.example/*", Except: []string{"com.example/docs-*", "com.example/team-*"}},
+		}, reader.HiddenNamespaces(visibilityRules))
+	})
+
+	t.Run("admin sees everything", func(t *testing.T) {
+		reader := auth.Reader{Claims: &auth.JWTClaims{Permissions: []auth.Permission{
+			{Action: auth.PermissionActionEdit, ResourcePattern: "*"},
+		}}}
+		assert.Empty(t, reader.HiddenNamespaces(visibilityRules))
+	})
+
+	t.Run("no rules", func(t *testing.T) {
+		assert.Empty(t, auth.Reader{}.HiddenNamespaces(nil))
+	})
+}
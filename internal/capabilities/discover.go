@@ -0,0 +1,316 @@
+package capabilities
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+const (
+	// protocolVersion is the MCP protocol version the registry negotiates when listing capabilities
+	protocolVersion = "2025-06-18"
+
+	// maxPages bounds pagination of each list method so a misbehaving server cannot keep us busy forever
+	maxPages = 20
+
+	// maxResponseSize bounds the size of a single JSON-RPC response body
+	maxResponseSize = 4 << 20
+)
+
+var (
+	ErrUnsupportedTransport = errors.New("capability discovery only supports streamable-http remotes")
+	ErrAuthRequired         = errors.New("remote requires headers or authentication")
+	ErrNonPublicAddress     = errors.New("remote resolves to a non-public address")
+)
+
+// sharedAddressSpace is the carrier-grade NAT range, which netip does not count as private
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// Discover connects to a remote MCP server and lists the tools, prompts and resources it exposes.
+// Only streamable-http remotes that accept anonymous requests are supported.
+// Remote URLs are supplied by publishers, so only public addresses are connected to.
+func Discover(ctx context.Context, remote model.Transport) (*apiv0.Capabilities, error) {
+	return DiscoverWithClient(ctx, newPublicHTTPClient(), remote)
+}
+
+// DiscoverWithClient is Discover using the given HTTP client, which decides which addresses may be connected to
+func DiscoverWithClient(ctx context.Context, httpClient *http.Client, remote model.Transport) (*apiv0.Capabilities, error) {
+	if remote.Type != model.TransportTypeStreamableHTTP {
+		return nil, ErrUnsupportedTransport
+	}
+	if len(remote.Headers) > 0 || strings.Contains(remote.URL, "{") {
+		return nil, ErrAuthRequired
+	}
+
+	c := &client{
+		httpClient: httpClient,
+		url:        remote.URL,
+	}
+
+	var initResult struct {
+		ProtocolVersion string `json:"protocolVersion"`
+		Capabilities    struct {
+			Tools     *json.RawMessage `json:"tools"`
+			Prompts   *json.RawMessage `json:"prompts"`
+			Resources *json.RawMessage `json:"resources"`
+		} `json:"capabilities"`
+	}
+	err := c.call(ctx, "initialize", map[string]any{
+		"protocolVersion": protocolVersion,
+		"capabilities":    map[string]any{},
+		"clientInfo": map[string]any{
+			"name":    "mcp-registry",
+			"version": "1.0.0",
+		},
+	}, &initResult)
+	if err != nil {
+		return nil, fmt.Errorf("initialize failed: %w", err)
+	}
+	c.protocolVersion = initResult.ProtocolVersion
+
+	if err := c.notify(ctx, "notifications/initialized"); err != nil {
+		return nil, fmt.Errorf("initialized notification failed: %w", err)
+	}
+
+	capabilities := &apiv0.Capabilities{Source: apiv0.CapabilitiesSourceDiscovered}
+	if initResult.Capabilities.Tools != nil {
+		if capabilities.Tools, err = c.list(ctx, "tools/list", "tools"); err != nil {
+			return nil, err
+		}
+	}
+	if initResult.Capabilities.Prompts != nil {
+		if capabilities.Prompts, err = c.list(ctx, "prompts/list", "prompts"); err != nil {
+			return nil, err
+		}
+	}
+	if initResult.Capabilities.Resources != nil {
+		if capabilities.Resources, err = c.list(ctx, "resources/list", "resources"); err != nil {
+			return nil, err
+		}
+	}
+
+	return capabilities, nil
+}
+
+// newPublicHTTPClient returns an HTTP client that refuses to connect to loopback, private, link-local
+// and other non-public addresses. The check runs on the resolved address of every connection, redirects included.
+func newPublicHTTPClient() *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second, Control: rejectNonPublicAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would be the only address checked, so connect directly
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: 10 * time.Second, Transport: transport}
+}
+
+// rejectNonPublicAddress is a net.Dialer Control function allowing only public unicast addresses
+func rejectNonPublicAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() || sharedAddressSpace.Contains(ip) {
+		return fmt.Errorf("%w: %s", ErrNonPublicAddress, ip)
+	}
+	return nil
+}
+
+// client is a minimal MCP streamable-http client sufficient for listing capabilities
+type client struct {
+	httpClient      *http.Client
+	url             string
+	sessionID       string
+	protocolVersion string
+	nextID          int
+}
+
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      *int   `json:"id,omitempty"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	ID     *int            `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// list calls a paginated list method and collects every item across pages
+func (c *client) list(ctx context.Context, method, key string) ([]apiv0.Capability, error) {
+	var items []apiv0.Capability
+	cursor := ""
+	for range maxPages {
+		var params map[string]any
+		if cursor != "" {
+			params = map[string]any{"cursor": cursor}
+		}
+
+		var page map[string]json.RawMessage
+		if err := c.call(ctx, method, params, &page); err != nil {
+			return nil, fmt.Errorf("%s failed: %w", method, err)
+		}
+
+		var pageItems []apiv0.Capability
+		if raw, ok := page[key]; ok {
+			if err := json.Unmarshal(raw, &pageItems); err != nil {
+				return nil, fmt.Errorf("failed to parse %s result: %w", method, err)
+			}
+		}
+		items = append(items, pageItems...)
+
+		cursor = ""
+		if raw, ok := page["nextCursor"]; ok {
+			_ = json.Unmarshal(raw, &cursor)
+		}
+		if cursor == "" {
+			return items, nil
+		}
+	}
+	return nil, fmt.Errorf("%s returned more than %d pages", method, maxPages)
+}
+
+// call sends a JSON-RPC request and decodes its result into out
+func (c *client) call(ctx context.Context, method string, params any, out any) error {
+	c.nextID++
+	id := c.nextID
+
+	resp, err := c.post(ctx, rpcRequest{JSONRPC: "2.0", ID: &id, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			return ErrAuthRequired
+		}
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if sessionID := resp.Header.Get("Mcp-Session-Id"); sessionID != "" {
+		c.sessionID = sessionID
+	}
+
+	rpcResp, err := readResponse(resp, id)
+	if err != nil {
+		return err
+	}
+	if rpcResp.Error != nil {
+		return fmt.Errorf("server returned error %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
+	}
+	if err := json.Unmarshal(rpcResp.Result, out); err != nil {
+		return fmt.Errorf("failed to parse result: %w", err)
+	}
+	return nil
+}
+
+// notify sends a JSON-RPC notification, which expects no response body
+func (c *client) notify(ctx context.Context, method string) error {
+	resp, err := c.post(ctx, rpcRequest{JSONRPC: "2.0", Method: method})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize))
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (c *client) post(ctx context.Context, body rpcRequest) (*http.Response, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	req.Header.Set("User-Agent", "MCP-Registry-Capabilities/1.0")
+	if c.sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", c.sessionID)
+	}
+	if c.protocolVersion != "" {
+		req.Header.Set("MCP-Protocol-Version", c.protocolVersion)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	return resp, nil
+}
+
+// readResponse extracts the JSON-RPC response with the given id from either a JSON or an SSE body
+func readResponse(resp *http.Response, id int) (*rpcResponse, error) {
+	body := io.LimitReader(resp.Body, maxResponseSize)
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/event-stream" {
+		var rpcResp rpcResponse
+		if err := json.NewDecoder(body).Decode(&rpcResp); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		return &rpcResp, nil
+	}
+
+	// Each SSE event carries one JSON-RPC message; skip server requests and notifications
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxResponseSize)
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		if rest, ok := strings.CutPrefix(line, "data:"); ok {
+			data.WriteString(strings.TrimPrefix(rest, " "))
+			continue
+		}
+		if line != "" || data.Len() == 0 {
+			continue
+		}
+
+		var rpcResp rpcResponse
+		if err := json.Unmarshal([]byte(data.String()), &rpcResp); err == nil && rpcResp.ID != nil && *rpcResp.ID == id {
+			return &rpcResp, nil
+		}
+		data.Reset()
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read event stream: %w", err)
+	}
+
+	if data.Len() > 0 {
+		var rpcResp rpcResponse
+		if err := json.Unmarshal([]byte(data.String()), &rpcResp); err == nil && rpcResp.ID != nil && *rpcResp.ID == id {
+			return &rpcResp, nil
+		}
+	}
+	return nil, errors.New("event stream ended without a response")
+}
@@ -0,0 +1,173 @@
+package capabilities_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/capabilities"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// newMCPServer starts a fake streamable-http MCP server. When useSSE is set, responses are sent as event streams.
+func newMCPServer(t *testing.T, useSSE bool) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     *int            `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if req.Method != "initialize" && r.Header.Get("Mcp-Session-Id") != "session-1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if req.ID == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		var result any
+		switch req.Method {
+		case "initialize":
+			w.Header().Set("Mcp-Session-Id", "session-1")
+			result = map[string]any{
+				"protocolVersion": "2025-06-18",
+				"capabilities":    map[string]any{"tools": map[string]any{}, "resources": map[string]any{}},
+				"serverInfo":      map[string]any{"name": "fake", "version": "1.0.0"},
+			}
+		case "tools/list":
+			var params struct {
+				Cursor string `json:"cursor"`
+			}
+			_ = json.Unmarshal(req.Params, &params)
+			if params.Cursor == "" {
+				result = map[string]any{
+					"tools":      []map[string]any{{"name": "create_issue", "description": "Create an issue"}},
+					"nextCursor": "page-2",
+				}
+			} else {
+				result = map[string]any{
+					"tools": []map[string]any{{"name": "close_issue"}},
+				}
+			}
+		case "resources/list":
+			result = map[string]any{
+				"resources": []map[string]any{{"name": "app.log", "uri": "file:///logs/app.log"}},
+			}
+		default:
+			t.Errorf("unexpected method %q", req.Method)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		payload, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": *req.ID, "result": result})
+		if useSSE {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = fmt.Fprintf(w, "event: message\ndata: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/message\"}\n\n")
+			_, _ = fmt.Fprintf(w, "event: message\ndata: %s\n\n", payload)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(payload)
+	}))
+}
+
+func TestDiscover(t *testing.T) {
+	for _, useSSE := range []bool{false, true} {
+		t.Run(fmt.Sprintf("sse=%v", useSSE), func(t *testing.T) {
+			server := newMCPServer(t, useSSE)
+			defer server.Close()
+
+			caps, err := capabilities.DiscoverWithClient(context.Background(), server.Client(), model.Transport{
+				Type: model.TransportTypeStreamableHTTP,
+				URL:  server.URL,
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, apiv0.CapabilitiesSourceDiscovered, caps.Source)
+			assert.Equal(t, []apiv0.Capability{
+				{Name: "create_issue", Description: "Create an issue"},
+				{Name: "close_issue"},
+			}, caps.Tools)
+			assert.Empty(t, caps.Prompts)
+			assert.Equal(t, []apiv0.Capability{{Name: "app.log", URI: "file:///logs/app.log"}}, caps.Resources)
+		})
+	}
+}
+
+func TestDiscover_Unsupported(t *testing.T) {
+	tests := []struct {
+		name     string
+		remote   model.Transport
+		expected error
+	}{
+		{
+			name:     "sse transport",
+			remote:   model.Transport{Type: model.TransportTypeSSE, URL: "https://example.com/sse"},
+			expected: capabilities.ErrUnsupportedTransport,
+		},
+		{
+			name: "remote with headers",
+			remote: model.Transport{
+				Type:    model.TransportTypeStreamableHTTP,
+				URL:     "https://example.com/mcp",
+				Headers: []model.KeyValueInput{{Name: "Authorization"}},
+			},
+			expected: capabilities.ErrAuthRequired,
+		},
+		{
+			name:     "templated url",
+			remote:   model.Transport{Type: model.TransportTypeStreamableHTTP, URL: "https://{tenant}.example.com/mcp"},
+			expected: capabilities.ErrAuthRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := capabilities.Discover(context.Background(), tt.remote)
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestDiscover_Unauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	_, err := capabilities.DiscoverWithClient(context.Background(), server.Client(), model.Transport{
+		Type: model.TransportTypeStreamableHTTP,
+		URL:  server.URL,
+	})
+	assert.ErrorIs(t, err, capabilities.ErrAuthRequired)
+}
+
+func TestDiscover_NonPublicAddress(t *testing.T) {
+	server := newMCPServer(t, false)
+	defer server.Close()
+
+	for _, url := range []string{server.URL, "http://169.254.169.254/mcp", "http://10.0.0.1/mcp", "http://[::1]:1/mcp"} {
+		t.Run(url, func(t *testing.T) {
+			_, err := capabilities.Discover(context.Background(), model.Transport{
+				Type: model.TransportTypeStreamableHTTP,
+				URL:  url,
+			})
+			assert.ErrorIs(t, err, capabilities.ErrNonPublicAddress)
+		})
+	}
+}
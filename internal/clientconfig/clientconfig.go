@@ -0,0 +1,174 @@
+// Package clientconfig turns a server.json package or remote into ready-to-paste MCP client configuration
+package clientconfig
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/launch"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Format is a client configuration format
+type Format string
+
+const (
+	// FormatVSCode is a VS Code mcp.json snippet, prompting for inputs with ${input:id}
+	FormatVSCode Format = "vscode"
+	// FormatClaudeDesktop is a claude_desktop_config.json snippet, with <id> placeholders to fill in
+	FormatClaudeDesktop Format = "claude-desktop"
+	// FormatGeneric is a client-neutral description with ${input:id} placeholders and the inputs they refer to
+	FormatGeneric Format = "generic"
+)
+
+// Target selects the package or remote to configure by index. With neither set, the first package
+// is used, or the first remote if there are no packages.
+type Target struct {
+	Package *int
+	Remote  *int
+}
+
+// Input is a value the user has to provide before the configuration can be used
+type Input struct {
+	ID          string   `json:"id" doc:"Input ID referenced by placeholders"`
+	Description string   `json:"description,omitempty" doc:"What the input is for"`
+	IsRequired  bool     `json:"isRequired,omitempty" doc:"Whether the input is required"`
+	IsSecret    bool     `json:"isSecret,omitempty" doc:"Whether the input is a secret, such as a token"`
+	Format      string   `json:"format,omitempty" doc:"Input format (string, number, boolean or filepath)"`
+	Default     string   `json:"default,omitempty" doc:"Default value"`
+	Choices     []string `json:"choices,omitempty" doc:"Allowed values"`
+}
+
+// connection is how a client starts or reaches a server, in a format-neutral shape
+type connection struct {
+	transport string
+	command   string
+	args      []string
+	env       map[string]string
+	url       string
+	headers   map[string]string
+}
+
+// Render builds the client configuration for a server version in the given format
+func Render(server *apiv0.ServerJSON, format Format, target Target) (any, error) {
+	placeholder := func(id string) string { return "${input:" + id + "}" }
+	if format == FormatClaudeDesktop {
+		placeholder = func(id string) string { return "<" + id + ">" }
+	}
+	r := &prompter{placeholder: placeholder, seen: map[string]bool{}}
+
+	conn, err := r.connect(server, target)
+	if err != nil {
+		return nil, err
+	}
+
+	key := server.Name[strings.LastIndex(server.Name, "/")+1:]
+	switch format {
+	case FormatVSCode:
+		return vscodeConfig(key, conn, r.inputs), nil
+	case FormatClaudeDesktop:
+		return claudeDesktopConfig(key, conn), nil
+	case FormatGeneric:
+		return genericConfig{
+			Name:      server.Name,
+			Transport: conn.transport,
+			Command:   conn.command,
+			Args:      conn.args,
+			Env:       conn.env,
+			URL:       conn.url,
+			Headers:   conn.headers,
+			Inputs:    r.inputs,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q (expected vscode, claude-desktop or generic)", format)
+	}
+}
+
+// connect resolves the targeted package or remote into a connection
+func (r *prompter) connect(server *apiv0.ServerJSON, target Target) (*connection, error) {
+	switch {
+	case target.Package != nil && target.Remote != nil:
+		return nil, fmt.Errorf("choose either a package or a remote, not both")
+	case target.Package != nil:
+		if *target.Package < 0 || *target.Package >= len(server.Packages) {
+			return nil, fmt.Errorf("package %d does not exist (server has %d packages)", *target.Package, len(server.Packages))
+		}
+		return r.packageConnection(&server.Packages[*target.Package])
+	case target.Remote != nil:
+		if *target.Remote < 0 || *target.Remote >= len(server.Remotes) {
+			return nil, fmt.Errorf("remote %d does not exist (server has %d remotes)", *target.Remote, len(server.Remotes))
+		}
+		return r.remoteConnection(&server.Remotes[*target.Remote])
+	case len(server.Packages) > 0:
+		return r.packageConnection(&server.Packages[0])
+	case len(server.Remotes) > 0:
+		return r.remoteConnection(&server.Remotes[0])
+	default:
+		return nil, fmt.Errorf("server has no packages or remotes")
+	}
+}
+
+func (r *prompter) packageConnection(pkg *model.Package) (*connection, error) {
+	if pkg.Transport.Type != model.TransportTypeStdio {
+		return nil, fmt.Errorf("package %s uses the %s transport; start it separately and connect to its URL", pkg.Identifier, pkg.Transport.Type)
+	}
+
+	started, err := launch.Package(pkg, r.options())
+	if errors.Is(err, launch.ErrNoRuntime) {
+		return nil, fmt.Errorf("no command is known for %s packages; the client has to install %s itself", pkg.RegistryType, pkg.Identifier)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &connection{
+		transport: model.TransportTypeStdio,
+		command:   started.Command,
+		args:      started.Args,
+		env:       started.Env,
+	}, nil
+}
+
+func (r *prompter) remoteConnection(remote *model.Transport) (*connection, error) {
+	headers, err := launch.Headers(remote.Headers, r.options())
+	if err != nil {
+		return nil, err
+	}
+	return &connection{
+		transport: remote.Type,
+		url:       remote.URL,
+		headers:   headers,
+	}, nil
+}
+
+// prompter records the values the user has to provide, replacing them with placeholders
+type prompter struct {
+	placeholder func(id string) string
+	inputs      []Input
+	seen        map[string]bool
+}
+
+// options renders with placeholders for every input the user has to provide, so rendering never
+// fails for missing inputs
+func (r *prompter) options() launch.Options {
+	return launch.Options{Placeholder: r.prompt}
+}
+
+// prompt records an input the user has to provide and returns its placeholder
+func (r *prompter) prompt(id string, input model.Input) string {
+	if !r.seen[id] {
+		r.seen[id] = true
+		r.inputs = append(r.inputs, Input{
+			ID:          id,
+			Description: input.Description,
+			IsRequired:  input.IsRequired,
+			IsSecret:    input.IsSecret,
+			Format:      string(input.Format),
+			Default:     input.Default,
+			Choices:     input.Choices,
+		})
+	}
+	return r.placeholder(id)
+}
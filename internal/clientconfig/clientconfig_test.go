@@ -0,0 +1,186 @@
+package clientconfig_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/clientconfig"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func render(t *testing.T, server *apiv0.ServerJSON, format clientconfig.Format, target clientconfig.Target) string {
+	t.Helper()
+	rendered, err := clientconfig.Render(server, format, target)
+	require.NoError(t, err)
+	data, err := json.Marshal(rendered)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+var testServer = &apiv0.ServerJSON{
+	Name:    "io.github.example/weather",
+	Version: "1.2.0",
+	Packages: []model.Package{
+		{
+			RegistryType: model.RegistryTypeNPM,
+			Identifier:   "@example/weather",
+			Version:      "1.2.0",
+			Transport:    model.Transport{Type: model.TransportTypeStdio},
+			PackageArguments: []model.Argument{
+				{Type: model.ArgumentTypeNamed, Name: "--units", InputWithVariables: model.InputWithVariables{Input: model.Input{Default: "metric"}}},
+				{Type: model.ArgumentTypeNamed, Name: "--verbose"},
+			},
+			EnvironmentVariables: []model.KeyValueInput{
+				{Name: "WEATHER_API_KEY", InputWithVariables: model.InputWithVariables{Input: model.Input{Description: "API key", IsRequired: true, IsSecret: true}}},
+				{Name: "LOG_LEVEL", InputWithVariables: model.InputWithVariables{Input: model.Input{Default: "info"}}},
+			},
+		},
+		{
+			RegistryType: model.RegistryTypeOCI,
+			Identifier:   "ghcr.io/example/weather:1.2.0",
+			Transport:    model.Transport{Type: model.TransportTypeStdio},
+			RuntimeArguments: []model.Argument{{
+				Type: model.ArgumentTypeNamed,
+				Name: "--mount",
+				InputWithVariables: model.InputWithVariables{
+					Input: model.Input{Value: "type=bind,src={source_path},dst=/data"},
+					Variables: map[string]model.Input{
+						"source_path": {Description: "Data directory", IsRequired: true, Format: model.FormatFilePath},
+					},
+				},
+			}},
+			EnvironmentVariables: []model.KeyValueInput{
+				{Name: "WEATHER_API_KEY", InputWithVariables: model.InputWithVariables{Input: model.Input{IsRequired: true, IsSecret: true}}},
+			},
+		},
+		{
+			RegistryType: model.RegistryTypePyPI,
+			Identifier:   "example-weather",
+			Version:      "1.2.0",
+			Transport:    model.Transport{Type: model.TransportTypeStdio},
+		},
+		{
+			RegistryType: model.RegistryTypeMCPB,
+			Identifier:   "https://github.com/example/weather/releases/download/v1.2.0/weather.mcpb",
+			Transport:    model.Transport{Type: model.TransportTypeStdio},
+		},
+	},
+	Remotes: []model.Transport{{
+		Type: model.TransportTypeStreamableHTTP,
+		URL:  "https://weather.example.com/mcp",
+		Headers: []model.KeyValueInput{
+			{Name: "Authorization", InputWithVariables: model.InputWithVariables{
+				Input:     model.Input{Value: "Bearer {token}"},
+				Variables: map[string]model.Input{"token": {IsRequired: true, IsSecret: true}},
+			}},
+		},
+	}},
+}
+
+func TestRender_Package(t *testing.T) {
+	t.Run("vscode prompts for secrets", func(t *testing.T) {
+		assert.JSONEq(t, `{
+			"inputs": [{"type": "promptString", "id": "WEATHER_API_KEY", "description": "API key", "password": true}],
+			"servers": {"weather": {
+				"type": "stdio",
+				"command": "npx",
+				"args": ["-y", "@example/weather@1.2.0", "--units", "metric"],
+				"env": {"WEATHER_API_KEY": "${input:WEATHER_API_KEY}", "LOG_LEVEL": "info"}
+			}}
+		}`, render(t, testServer, clientconfig.FormatVSCode, clientconfig.Target{}))
+	})
+
+	t.Run("claude desktop uses placeholders", func(t *testing.T) {
+		assert.JSONEq(t, `{"mcpServers": {"weather": {
+			"command": "npx",
+			"args": ["-y", "@example/weather@1.2.0", "--units", "metric"],
+			"env": {"WEATHER_API_KEY": "<WEATHER_API_KEY>", "LOG_LEVEL": "info"}
+		}}}`, render(t, testServer, clientconfig.FormatClaudeDesktop, clientconfig.Target{}))
+	})
+
+	t.Run("oci passes environment through docker and fills templates", func(t *testing.T) {
+		assert.JSONEq(t, `{
+			"name": "io.github.example/weather",
+			"transport": "stdio",
+			"command": "docker",
+			"args": ["run", "-i", "--rm", "-e", "WEATHER_API_KEY", "--mount", "type=bind,src=${input:source_path},dst=/data", "ghcr.io/example/weather:1.2.0"],
+			"env": {"WEATHER_API_KEY": "${input:WEATHER_API_KEY}"},
+			"inputs": [
+				{"id": "WEATHER_API_KEY", "isRequired": true, "isSecret": true},
+				{"id": "source_path", "description": "Data directory", "isRequired": true, "format": "filepath"}
+			]
+		}`, render(t, testServer, clientconfig.FormatGeneric, clientconfig.Target{Package: intPtr(1)}))
+	})
+
+	t.Run("pypi pins the version for uvx", func(t *testing.T) {
+		assert.JSONEq(t, `{"mcpServers": {"weather": {"command": "uvx", "args": ["example-weather==1.2.0"]}}}`,
+			render(t, testServer, clientconfig.FormatClaudeDesktop, clientconfig.Target{Package: intPtr(2)}))
+	})
+
+	t.Run("runtime hint overrides the default command", func(t *testing.T) {
+		server := &apiv0.ServerJSON{Name: "io.github.example/hinted", Packages: []model.Package{{
+			RegistryType: model.RegistryTypeNPM,
+			Identifier:   "@example/hinted",
+			Version:      "1.0.0",
+			RunTimeHint:  "bunx",
+			Transport:    model.Transport{Type: model.TransportTypeStdio},
+		}}}
+		assert.JSONEq(t, `{"mcpServers": {"hinted": {"command": "bunx", "args": ["@example/hinted@1.0.0"]}}}`,
+			render(t, server, clientconfig.FormatClaudeDesktop, clientconfig.Target{}))
+	})
+}
+
+func TestRender_Remote(t *testing.T) {
+	t.Run("vscode", func(t *testing.T) {
+		assert.JSONEq(t, `{
+			"inputs": [{"type": "promptString", "id": "token", "password": true}],
+			"servers": {"weather": {
+				"type": "http",
+				"url": "https://weather.example.com/mcp",
+				"headers": {"Authorization": "Bearer ${input:token}"}
+			}}
+		}`, render(t, testServer, clientconfig.FormatVSCode, clientconfig.Target{Remote: intPtr(0)}))
+	})
+
+	t.Run("claude desktop bridges through mcp-remote", func(t *testing.T) {
+		assert.JSONEq(t, `{"mcpServers": {"weather": {
+			"command": "npx",
+			"args": ["-y", "mcp-remote", "https://weather.example.com/mcp", "--header", "Authorization:Bearer <token>"]
+		}}}`, render(t, testServer, clientconfig.FormatClaudeDesktop, clientconfig.Target{Remote: intPtr(0)}))
+	})
+
+	t.Run("used when there are no packages", func(t *testing.T) {
+		server := &apiv0.ServerJSON{Name: "io.github.example/remote", Remotes: []model.Transport{{Type: model.TransportTypeSSE, URL: "https://example.com/sse"}}}
+		assert.JSONEq(t, `{"name": "io.github.example/remote", "transport": "sse", "url": "https://example.com/sse"}`,
+			render(t, server, clientconfig.FormatGeneric, clientconfig.Target{}))
+	})
+}
+
+func TestRender_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		server   *apiv0.ServerJSON
+		target   clientconfig.Target
+		expected string
+	}{
+		{"package out of range", testServer, clientconfig.Target{Package: intPtr(9)}, "package 9 does not exist"},
+		{"remote out of range", testServer, clientconfig.Target{Remote: intPtr(1)}, "remote 1 does not exist"},
+		{"both package and remote", testServer, clientconfig.Target{Package: intPtr(0), Remote: intPtr(0)}, "not both"},
+		{"mcpb package", testServer, clientconfig.Target{Package: intPtr(3)}, "no command is known for mcpb packages"},
+		{"nothing to configure", &apiv0.ServerJSON{Name: "io.github.example/empty"}, clientconfig.Target{}, "no packages or remotes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := clientconfig.Render(tt.server, clientconfig.FormatGeneric, tt.target)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
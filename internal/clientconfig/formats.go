@@ -0,0 +1,116 @@
+package clientconfig
+
+import (
+	"sort"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// vscodeServer is a server entry in VS Code's mcp.json
+type vscodeServer struct {
+	Type    string            `json:"type"`
+	Command string            `json:"command,omitempty"`
+	Args    []string          `json:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// vscodeInput is a value VS Code prompts for when starting the server
+type vscodeInput struct {
+	Type        string   `json:"type"`
+	ID          string   `json:"id"`
+	Description string   `json:"description,omitempty"`
+	Password    bool     `json:"password,omitempty"`
+	Default     string   `json:"default,omitempty"`
+	Options     []string `json:"options,omitempty"`
+}
+
+type vscodeFile struct {
+	Inputs  []vscodeInput           `json:"inputs,omitempty"`
+	Servers map[string]vscodeServer `json:"servers"`
+}
+
+func vscodeConfig(key string, conn *connection, inputs []Input) vscodeFile {
+	server := vscodeServer{
+		Type:    conn.transport,
+		Command: conn.command,
+		Args:    conn.args,
+		Env:     conn.env,
+		URL:     conn.url,
+		Headers: conn.headers,
+	}
+	if conn.transport == model.TransportTypeStreamableHTTP {
+		server.Type = "http"
+	}
+
+	file := vscodeFile{Servers: map[string]vscodeServer{key: server}}
+	for _, input := range inputs {
+		prompt := vscodeInput{
+			Type:        "promptString",
+			ID:          input.ID,
+			Description: input.Description,
+			Password:    input.IsSecret,
+			Default:     input.Default,
+		}
+		if len(input.Choices) > 0 {
+			prompt.Type = "pickString"
+			prompt.Options = input.Choices
+		}
+		file.Inputs = append(file.Inputs, prompt)
+	}
+	return file
+}
+
+// claudeDesktopServer is a server entry in claude_desktop_config.json
+type claudeDesktopServer struct {
+	Command string            `json:"command"`
+	Args    []string          `json:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+}
+
+type claudeDesktopFile struct {
+	MCPServers map[string]claudeDesktopServer `json:"mcpServers"`
+}
+
+// claudeDesktopConfig configures a stdio server directly. Remotes are reached through the
+// mcp-remote bridge, since the config file only supports local commands.
+func claudeDesktopConfig(key string, conn *connection) claudeDesktopFile {
+	server := claudeDesktopServer{
+		Command: conn.command,
+		Args:    conn.args,
+		Env:     conn.env,
+	}
+	if conn.transport != model.TransportTypeStdio {
+		server.Command = model.RuntimeHintNPX
+		server.Args = []string{"-y", "mcp-remote", conn.url}
+		for _, name := range sortedKeys(conn.headers) {
+			server.Args = append(server.Args, "--header", name+":"+conn.headers[name])
+		}
+		if conn.transport == model.TransportTypeSSE {
+			server.Args = append(server.Args, "--transport", "sse-only")
+		}
+	}
+	return claudeDesktopFile{MCPServers: map[string]claudeDesktopServer{key: server}}
+}
+
+// genericConfig describes how to connect in a client-neutral way
+type genericConfig struct {
+	Name      string            `json:"name"`
+	Transport string            `json:"transport"`
+	Command   string            `json:"command,omitempty"`
+	Args      []string          `json:"args,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+	URL       string            `json:"url,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Inputs    []Input           `json:"inputs,omitempty"`
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,21 +1,35 @@
 package config
 
 import (
+	"time"
+
 	env "github.com/caarlos0/env/v11"
 )
 
 // Config holds the application configuration
 // See .env.example for more documentation
 type Config struct {
-	ServerAddress            string `env:"SERVER_ADDRESS" envDefault:":8080"`
-	DatabaseURL              string `env:"DATABASE_URL" envDefault:"postgres://localhost:5432/mcp-registry?sslmode=disable"`
-	SeedFrom                 string `env:"SEED_FROM" envDefault:""`
-	Version                  string `env:"VERSION" envDefault:"dev"`
-	GithubClientID           string `env:"GITHUB_CLIENT_ID" envDefault:""`
-	GithubClientSecret       string `env:"GITHUB_CLIENT_SECRET" envDefault:""`
-	JWTPrivateKey            string `env:"JWT_PRIVATE_KEY" envDefault:""`
-	EnableAnonymousAuth      bool   `env:"ENABLE_ANONYMOUS_AUTH" envDefault:"false"`
-	EnableRegistryValidation bool   `env:"ENABLE_REGISTRY_VALIDATION" envDefault:"true"`
+	ServerAddress             string `env:"SERVER_ADDRESS" envDefault:":8080"`
+	DatabaseURL               string `env:"DATABASE_URL" envDefault:"postgres://localhost:5432/mcp-registry?sslmode=disable"`
+	SeedFrom                  string `env:"SEED_FROM" envDefault:""`
+	SyncFrom                  string `env:"SYNC_FROM" envDefault:""`
+	Version                   string `env:"VERSION" envDefault:"dev"`
+	GithubClientID            string `env:"GITHUB_CLIENT_ID" envDefault:""`
+	GithubClientSecret        string `env:"GITHUB_CLIENT_SECRET" envDefault:""`
+	JWTPrivateKey             string `env:"JWT_PRIVATE_KEY" envDefault:""`
+	EnableAnonymousAuth       bool   `env:"ENABLE_ANONYMOUS_AUTH" envDefault:"false"`
+	EnableRegistryValidation  bool   `env:"ENABLE_REGISTRY_VALIDATION" envDefault:"true"`
+	EnableCapabilityDiscovery bool   `env:"ENABLE_CAPABILITY_DISCOVERY" envDefault:"false"`
+	OCIRequireDigest          bool   `env:"OCI_REQUIRE_DIGEST" envDefault:"false"`
+
+	// How often to poll the SyncFrom registry for changes
+	SyncInterval time.Duration `env:"SYNC_INTERVAL" envDefault:"5m"`
+
+	// Additional package registries accepted by validators, as a JSON array (see .env.example)
+	UpstreamRegistries UpstreamRegistries `env:"UPSTREAM_REGISTRIES" envDefault:""`
+
+	// Read visibility of server namespaces, as a JSON array (see .env.example)
+	NamespaceVisibility NamespaceVisibility `env:"NAMESPACE_VISIBILITY" envDefault:""`
 
 	// OIDC Configuration
 	OIDCEnabled      bool   `env:"OIDC_ENABLED" envDefault:"false"`
@@ -24,6 +38,7 @@ type Config struct {
 	OIDCExtraClaims  string `env:"OIDC_EXTRA_CLAIMS" envDefault:""`
 	OIDCEditPerms    string `env:"OIDC_EDIT_PERMISSIONS" envDefault:""`
 	OIDCPublishPerms string `env:"OIDC_PUBLISH_PERMISSIONS" envDefault:""`
+	OIDCReadPerms    string `env:"OIDC_READ_PERMISSIONS" envDefault:""`
 }
 
 // NewConfig creates a new configuration with default values
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validators/registries"
+)
+
+// Ownership checks and authentication schemes, as package validators define them
+const (
+	UpstreamOwnershipMetadata = registries.UpstreamOwnershipMetadata
+	UpstreamOwnershipNone     = registries.UpstreamOwnershipNone
+	UpstreamAuthBearer        = registries.UpstreamAuthBearer
+	UpstreamAuthBasic         = registries.UpstreamAuthBasic
+)
+
+// UpstreamAuth holds the credentials used when validators look up packages on an upstream registry
+type UpstreamAuth struct {
+	Type     string `json:"type"`
+	Token    string `json:"token,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// UpstreamRegistry is an additional package registry that publishers may reference with registryBaseUrl
+type UpstreamRegistry struct {
+	// RegistryType is the package registry type this base URL is accepted for (npm, pypi or nuget)
+	RegistryType string `json:"registryType"`
+	// BaseURL is the registry base URL publishers must use, e.g. https://artifactory.example.com/api/npm/npm
+	BaseURL string `json:"baseUrl"`
+	// Auth holds optional credentials for package lookups
+	Auth *UpstreamAuth `json:"auth,omitempty"`
+	// Ownership selects how package ownership is checked, defaulting to UpstreamOwnershipMetadata
+	Ownership string `json:"ownership,omitempty"`
+	// Namespaces optionally restricts which servers may use this registry, by server name prefix (e.g. "com.example/")
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// UpstreamRegistries is the list of admin-configured upstream registries, parsed from a JSON array
+type UpstreamRegistries []UpstreamRegistry
+
+// UnmarshalText parses and validates the JSON array of upstream registries
+func (u *UpstreamRegistries) UnmarshalText(text []byte) error {
+	if strings.TrimSpace(string(text)) == "" {
+		*u = nil
+		return nil
+	}
+
+	var registries []UpstreamRegistry
+	if err := json.Unmarshal(text, &registries); err != nil {
+		return fmt.Errorf("invalid upstream registries: %w", err)
+	}
+
+	for i := range registries {
+		if err := registries[i].validate(); err != nil {
+			return fmt.Errorf("invalid upstream registry %d: %w", i, err)
+		}
+	}
+
+	*u = registries
+	return nil
+}
+
+// Validators converts the upstream registries to the form package validators take
+func (u UpstreamRegistries) Validators() []registries.UpstreamRegistry {
+	upstreams := make([]registries.UpstreamRegistry, len(u))
+	for i, r := range u {
+		upstreams[i] = registries.UpstreamRegistry{
+			RegistryType: r.RegistryType,
+			BaseURL:      r.BaseURL,
+			Ownership:    r.Ownership,
+			Namespaces:   r.Namespaces,
+		}
+		if r.Auth != nil {
+			upstreams[i].Auth = &registries.UpstreamAuth{
+				Type:     r.Auth.Type,
+				Token:    r.Auth.Token,
+				Username: r.Auth.Username,
+				Password: r.Auth.Password,
+			}
+		}
+	}
+	return upstreams
+}
+
+func (r *UpstreamRegistry) validate() error {
+	switch r.RegistryType {
+	case model.RegistryTypeNPM, model.RegistryTypePyPI, model.RegistryTypeNuGet:
+	default:
+		return fmt.Errorf("unsupported registryType %q (expected npm, pypi or nuget)", r.RegistryType)
+	}
+
+	parsedURL, err := url.Parse(r.BaseURL)
+	if err != nil || parsedURL.Host == "" || (parsedURL.Scheme != "https" && parsedURL.Scheme != "http") {
+		return fmt.Errorf("baseUrl %q must be an absolute http(s) URL", r.BaseURL)
+	}
+	r.BaseURL = strings.TrimSuffix(r.BaseURL, "/")
+
+	switch r.Ownership {
+	case "":
+		r.Ownership = UpstreamOwnershipMetadata
+	case UpstreamOwnershipMetadata, UpstreamOwnershipNone:
+	default:
+		return fmt.Errorf("unsupported ownership %q (expected %s or %s)", r.Ownership, UpstreamOwnershipMetadata, UpstreamOwnershipNone)
+	}
+
+	if r.Auth != nil {
+		switch r.Auth.Type {
+		case UpstreamAuthBearer:
+			if r.Auth.Token == "" {
+				return fmt.Errorf("bearer auth requires a token")
+			}
+		case UpstreamAuthBasic:
+			if r.Auth.Username == "" {
+				return fmt.Errorf("basic auth requires a username")
+			}
+		default:
+			return fmt.Errorf("unsupported auth type %q (expected %s or %s)", r.Auth.Type, UpstreamAuthBearer, UpstreamAuthBasic)
+		}
+	}
+
+	return nil
+}
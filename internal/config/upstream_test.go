@@ -0,0 +1,70 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/pkg/validators/registries"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpstreamRegistries_UnmarshalText(t *testing.T) {
+	t.Run("valid configuration", func(t *testing.T) {
+		var upstreams config.UpstreamRegistries
+		err := upstreams.UnmarshalText([]byte(`[
+			{"registryType": "npm", "baseUrl": "https://artifactory.example.com/api/npm/npm/", "auth": {"type": "bearer", "token": "t"}},
+			{"registryType": "pypi", "baseUrl": "https://pypi.internal.example.com", "ownership": "none", "namespaces": ["com.example/"]}
+		]`))
+		require.NoError(t, err)
+		require.Len(t, upstreams, 2)
+
+		// Trailing slashes are trimmed and ownership defaults to metadata checks
+		assert.Equal(t, "https://artifactory.example.com/api/npm/npm", upstreams[0].BaseURL)
+		assert.Equal(t, config.UpstreamOwnershipMetadata, upstreams[0].Ownership)
+
+		assert.Equal(t, []registries.UpstreamRegistry{
+			{
+				RegistryType: "npm",
+				BaseURL:      "https://artifactory.example.com/api/npm/npm",
+				Auth:         &registries.UpstreamAuth{Type: registries.UpstreamAuthBearer, Token: "t"},
+				Ownership:    registries.UpstreamOwnershipMetadata,
+			},
+			{
+				RegistryType: "pypi",
+				BaseURL:      "https://pypi.internal.example.com",
+				Ownership:    registries.UpstreamOwnershipNone,
+				Namespaces:   []string{"com.example/"},
+			},
+		}, upstreams.Validators())
+	})
+
+	t.Run("empty configuration", func(t *testing.T) {
+		var upstreams config.UpstreamRegistries
+		require.NoError(t, upstreams.UnmarshalText([]byte("")))
+		assert.Empty(t, upstreams)
+	})
+
+	invalid := map[string]string{
+		"malformed JSON":       `{"registryType": "npm"}`,
+		"unsupported type":     `[{"registryType": "oci", "baseUrl": "https://registry.example.com"}]`,
+		"relative base URL":    `[{"registryType": "npm", "baseUrl": "registry.example.com"}]`,
+		"unsupported auth":     `[{"registryType": "npm", "baseUrl": "https://registry.example.com", "auth": {"type": "digest"}}]`,
+		"bearer without token": `[{"registryType": "npm", "baseUrl": "https://registry.example.com", "auth": {"type": "bearer"}}]`,
+		"unknown ownership":    `[{"registryType": "npm", "baseUrl": "https://registry.example.com", "ownership": "trust-me"}]`,
+	}
+	for name, raw := range invalid {
+		t.Run(name, func(t *testing.T) {
+			var upstreams config.UpstreamRegistries
+			assert.Error(t, upstreams.UnmarshalText([]byte(raw)))
+		})
+	}
+}
+
+func TestNewConfig_UpstreamRegistries(t *testing.T) {
+	t.Setenv("MCP_REGISTRY_UPSTREAM_REGISTRIES", `[{"registryType": "nuget", "baseUrl": "https://nuget.internal.example.com/v3"}]`)
+
+	cfg := config.NewConfig()
+	require.Len(t, cfg.UpstreamRegistries, 1)
+	assert.Equal(t, "nuget", cfg.UpstreamRegistries[0].RegistryType)
+}
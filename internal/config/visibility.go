@@ -0,0 +1,88 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Read visibility levels for server namespaces
+const (
+	// VisibilityPublic servers can be read by anyone, and is the default for unconfigured namespaces
+	VisibilityPublic = "public"
+	// VisibilityAuthenticated servers can be read by anyone presenting a valid registry token
+	VisibilityAuthenticated = "authenticated"
+	// VisibilityPrivate servers can only be read with a read, publish or edit permission covering the server
+	VisibilityPrivate = "private"
+)
+
+// NamespaceVisibilityRule sets the read visibility of servers matching a namespace pattern
+type NamespaceVisibilityRule struct {
+	// Namespace uses the same pattern syntax as token permissions, e.g. "com.example/*", "com.example.*" or an exact server name
+	Namespace  string `json:"namespace"`
+	Visibility string `json:"visibility"`
+}
+
+// NamespaceVisibility is the list of namespace visibility rules, parsed from a JSON array
+type NamespaceVisibility []NamespaceVisibilityRule
+
+// UnmarshalText parses and validates the JSON array of namespace visibility rules
+func (v *NamespaceVisibility) UnmarshalText(text []byte) error {
+	if strings.TrimSpace(string(text)) == "" {
+		*v = nil
+		return nil
+	}
+
+	var rules []NamespaceVisibilityRule
+	if err := json.Unmarshal(text, &rules); err != nil {
+		return fmt.Errorf("invalid namespace visibility: %w", err)
+	}
+
+	for i, rule := range rules {
+		if rule.Namespace == "" {
+			return fmt.Errorf("invalid namespace visibility rule %d: namespace is required", i)
+		}
+		switch rule.Visibility {
+		case VisibilityPublic, VisibilityAuthenticated, VisibilityPrivate:
+		default:
+			return fmt.Errorf("invalid namespace visibility rule %d: unsupported visibility %q (expected %s, %s or %s)",
+				i, rule.Visibility, VisibilityPublic, VisibilityAuthenticated, VisibilityPrivate)
+		}
+	}
+
+	*v = rules
+	return nil
+}
+
+// Match returns the most specific rule matching the server name, or nil if the server is public by default.
+// Exact names are more specific than any wildcard, and longer wildcard prefixes are more specific than shorter ones.
+func (v NamespaceVisibility) Match(serverName string) *NamespaceVisibilityRule {
+	var best *NamespaceVisibilityRule
+	bestSpecificity := -1
+	for i := range v {
+		if !NamespaceCovers(v[i].Namespace, serverName) {
+			continue
+		}
+		specificity := len(v[i].Namespace)
+		if !strings.HasSuffix(v[i].Namespace, "*") {
+			specificity = len(serverName) + 1
+		}
+		if specificity > bestSpecificity {
+			best = &v[i]
+			bestSpecificity = specificity
+		}
+	}
+	return best
+}
+
+// NamespaceCovers reports whether every server name matched by inner is also matched by outer.
+// Both are namespace patterns; a plain server name is a pattern matching only itself.
+func NamespaceCovers(outer, inner string) bool {
+	if outer == "*" {
+		return true
+	}
+	if !strings.HasSuffix(outer, "*") {
+		return outer == inner
+	}
+	return strings.HasPrefix(inner, strings.TrimSuffix(outer, "*"))
+}
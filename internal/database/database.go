@@ -27,6 +27,17 @@ type ServerFilter struct {
 	SubstringName *string    // for substring search on name
 	Version       *string    // for exact version matching
 	IsLatest      *bool      // for filtering latest versions only
+	ToolName      *string    // for filtering servers exposing a tool with this exact name
+	Platform      *string    // for filtering servers with an OCI image supporting this os/arch platform
+	// for hiding namespaces the caller cannot read
+	HiddenNamespaces []NamespaceExclusion
+}
+
+// NamespaceExclusion hides servers matching a namespace pattern, except those matching one of the exception patterns.
+// Patterns use the same syntax as token permissions, e.g. "com.example/*" or an exact server name.
+type NamespaceExclusion struct {
+	Pattern string
+	Except  []string
 }
 
 // Database defines the interface for database operations
@@ -35,8 +46,8 @@ type Database interface {
 	CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *apiv0.ServerJSON, officialMeta *apiv0.RegistryExtensions) (*apiv0.ServerResponse, error)
 	// UpdateServer updates an existing server record
 	UpdateServer(ctx context.Context, tx pgx.Tx, serverName, version string, serverJSON *apiv0.ServerJSON) (*apiv0.ServerResponse, error)
-	// SetServerStatus updates the status of a specific server version
-	SetServerStatus(ctx context.Context, tx pgx.Tx, serverName, version string, status string) (*apiv0.ServerResponse, error)
+	// SetServerStatus updates the status of a specific server version, replacing its status message
+	SetServerStatus(ctx context.Context, tx pgx.Tx, serverName, version string, status, statusMessage string) (*apiv0.ServerResponse, error)
 	// ListServers retrieve server entries with optional filtering
 	ListServers(ctx context.Context, tx pgx.Tx, filter *ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error)
 	// GetServerByName retrieve a single server by its name
@@ -51,6 +62,16 @@ type Database interface {
 	CountServerVersions(ctx context.Context, tx pgx.Tx, serverName string) (int, error)
 	// CheckVersionExists check if a specific version exists for a server
 	CheckVersionExists(ctx context.Context, tx pgx.Tx, serverName, version string) (bool, error)
+	// SetServerCapabilities stores the capabilities advertised by a specific server version
+	SetServerCapabilities(ctx context.Context, tx pgx.Tx, serverName, version string, capabilities *apiv0.Capabilities) error
+	// SetServerOCIImages stores the digests and platforms a specific server version's OCI packages resolved to
+	SetServerOCIImages(ctx context.Context, tx pgx.Tx, serverName, version string, images []apiv0.OCIImage) error
+	// UpsertServer inserts a server version or replaces it, keeping the given official metadata (including timestamps) as-is
+	UpsertServer(ctx context.Context, tx pgx.Tx, serverJSON *apiv0.ServerJSON, officialMeta *apiv0.RegistryExtensions) (*apiv0.ServerResponse, error)
+	// GetSyncHighWaterMark retrieve the latest upstream update applied when syncing from an upstream registry
+	GetSyncHighWaterMark(ctx context.Context, tx pgx.Tx, upstreamURL string) (time.Time, error)
+	// SetSyncHighWaterMark stores the latest upstream update applied when syncing from an upstream registry
+	SetSyncHighWaterMark(ctx context.Context, tx pgx.Tx, upstreamURL string, mark time.Time) error
 	// UnmarkAsLatest marks the current latest version of a server as no longer latest
 	UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName string) error
 	// AcquirePublishLock acquires an exclusive advisory lock for publishing a server
@@ -0,0 +1,7 @@
+-- Store the tools, prompts and resources exposed by each server version
+-- Populated by the registry at publish time, either discovered from a remote or declared by the publisher
+
+ALTER TABLE servers ADD COLUMN capabilities JSONB;
+
+-- Support containment queries such as capabilities @> '{"tools":[{"name":"create_issue"}]}'
+CREATE INDEX idx_servers_capabilities ON servers USING GIN (capabilities jsonb_path_ops);
@@ -0,0 +1,7 @@
+-- Store the manifest digest and platforms each OCI package resolved to at publish time
+-- Tags are mutable, so this records exactly which image was validated
+
+ALTER TABLE servers ADD COLUMN oci_images JSONB;
+
+-- Support containment queries such as oci_images @> '[{"platforms":["linux/arm64"]}]'
+CREATE INDEX idx_servers_oci_images ON servers USING GIN (oci_images jsonb_path_ops);
@@ -0,0 +1,8 @@
+-- Track how far a downstream registry has synced from each upstream registry
+-- high_water_mark is the latest upstream updatedAt applied, used as the next updated_since
+
+CREATE TABLE sync_state (
+    upstream_url TEXT PRIMARY KEY,
+    high_water_mark TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
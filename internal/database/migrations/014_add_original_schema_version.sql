@@ -0,0 +1,4 @@
+-- Record the schema version a server.json was published with when the registry upgraded it
+-- to the current schema on publish. NULL for server.json published with the current schema.
+
+ALTER TABLE servers ADD COLUMN original_schema_version TEXT;
@@ -0,0 +1,4 @@
+-- Record why a server version was given its current status, for example why it was deprecated.
+-- NULL when no reason was given. Cleared whenever the status changes without a new message.
+
+ALTER TABLE servers ADD COLUMN status_message TEXT;
@@ -29,6 +29,9 @@ type Executor interface {
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 }
 
+// serverColumns lists the columns selected for every server row, in the order expected by scanServerResponse
+const serverColumns = "server_name, version, status, published_at, updated_at, is_latest, value, capabilities, oci_images, original_schema_version, status_message"
+
 // getExecutor returns the appropriate executor (transaction or pool)
 func (db *PostgreSQL) getExecutor(tx pgx.Tx) Executor {
 	if tx != nil {
@@ -95,43 +98,11 @@ func (db *PostgreSQL) ListServers(
 	}
 
 	// Build WHERE clause for filtering using dedicated columns
-	var whereConditions []string
-	args := []any{}
-	argIndex := 1
-
-	// Add filters using dedicated columns for better performance
-	if filter != nil {
-		if filter.Name != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("server_name = $%d", argIndex))
-			args = append(args, *filter.Name)
-			argIndex++
-		}
-		if filter.RemoteURL != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("EXISTS (SELECT 1 FROM jsonb_array_elements(value->'remotes') AS remote WHERE remote->>'url' = $%d)", argIndex))
-			args = append(args, *filter.RemoteURL)
-			argIndex++
-		}
-		if filter.UpdatedSince != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("updated_at > $%d", argIndex))
-			args = append(args, *filter.UpdatedSince)
-			argIndex++
-		}
-		if filter.SubstringName != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("server_name ILIKE $%d", argIndex))
-			args = append(args, "%"+*filter.SubstringName+"%")
-			argIndex++
-		}
-		if filter.Version != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("version = $%d", argIndex))
-			args = append(args, *filter.Version)
-			argIndex++
-		}
-		if filter.IsLatest != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("is_latest = $%d", argIndex))
-			args = append(args, *filter.IsLatest)
-			argIndex++
-		}
+	whereConditions, args, err := buildFilterConditions(filter)
+	if err != nil {
+		return nil, "", err
 	}
+	argIndex := len(args) + 1
 
 	// Add cursor pagination using compound serverName:version cursor
 	if cursor != "" {
@@ -161,12 +132,12 @@ func (db *PostgreSQL) ListServers(
 
 	// Query servers table with hybrid column/JSON data
 	query := fmt.Sprintf(`
-        SELECT server_name, version, status, published_at, updated_at, is_latest, value
+        SELECT %s
         FROM servers
         %s
         ORDER BY server_name, version
         LIMIT $%d
-    `, whereClause, argIndex)
+    `, serverColumns, whereClause, argIndex)
 	args = append(args, limit)
 
 	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
@@ -177,35 +148,11 @@ func (db *PostgreSQL) ListServers(
 
 	var results []*apiv0.ServerResponse
 	for rows.Next() {
-		var serverName, version, status string
-		var publishedAt, updatedAt time.Time
-		var isLatest bool
-		var valueJSON []byte
-
-		err := rows.Scan(&serverName, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+		serverResponse, err := scanServerResponse(rows)
 		if err != nil {
 			return nil, "", fmt.Errorf("failed to scan server row: %w", err)
 		}
 
-		// Parse the ServerJSON from JSONB
-		var serverJSON apiv0.ServerJSON
-		if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
-			return nil, "", fmt.Errorf("failed to unmarshal server JSON: %w", err)
-		}
-
-		// Build ServerResponse with separated metadata
-		serverResponse := &apiv0.ServerResponse{
-			Server: serverJSON,
-			Meta: apiv0.ResponseMeta{
-				Official: &apiv0.RegistryExtensions{
-					Status:      model.Status(status),
-					PublishedAt: publishedAt,
-					UpdatedAt:   updatedAt,
-					IsLatest:    isLatest,
-				},
-			},
-		}
-
 		results = append(results, serverResponse)
 	}
 
@@ -223,6 +170,104 @@ func (db *PostgreSQL) ListServers(
 	return results, nextCursor, nil
 }
 
+// buildFilterConditions turns a server filter into WHERE conditions and their positional arguments
+func buildFilterConditions(filter *ServerFilter) ([]string, []any, error) {
+	var whereConditions []string
+	args := []any{}
+	argIndex := 1
+
+	if filter == nil {
+		return whereConditions, args, nil
+	}
+
+	// Add filters using dedicated columns for better performance
+	if filter.Name != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("server_name = $%d", argIndex))
+		args = append(args, *filter.Name)
+		argIndex++
+	}
+	if filter.RemoteURL != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("EXISTS (SELECT 1 FROM jsonb_array_elements(value->'remotes') AS remote WHERE remote->>'url' = $%d)", argIndex))
+		args = append(args, *filter.RemoteURL)
+		argIndex++
+	}
+	if filter.UpdatedSince != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("updated_at > $%d", argIndex))
+		args = append(args, *filter.UpdatedSince)
+		argIndex++
+	}
+	if filter.SubstringName != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("server_name ILIKE $%d", argIndex))
+		args = append(args, "%"+*filter.SubstringName+"%")
+		argIndex++
+	}
+	if filter.Version != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("version = $%d", argIndex))
+		args = append(args, *filter.Version)
+		argIndex++
+	}
+	if filter.IsLatest != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("is_latest = $%d", argIndex))
+		args = append(args, *filter.IsLatest)
+		argIndex++
+	}
+	if filter.ToolName != nil {
+		// Containment query so the GIN index on capabilities can be used
+		toolJSON, err := json.Marshal(apiv0.Capabilities{Tools: []apiv0.Capability{{Name: *filter.ToolName}}})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal tool filter: %w", err)
+		}
+		whereConditions = append(whereConditions, fmt.Sprintf("capabilities @> $%d::jsonb", argIndex))
+		args = append(args, string(toolJSON))
+		argIndex++
+	}
+	if filter.Platform != nil {
+		// Containment query so the GIN index on oci_images can be used
+		// Only platforms is given, as OCIImage would also match on an empty identifier and digest
+		platformJSON, err := json.Marshal([]map[string]any{{"platforms": []string{*filter.Platform}}})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal platform filter: %w", err)
+		}
+		whereConditions = append(whereConditions, fmt.Sprintf("oci_images @> $%d::jsonb", argIndex))
+		args = append(args, string(platformJSON))
+		argIndex++
+	}
+	for _, exclusion := range filter.HiddenNamespaces {
+		condition, exclusionArgs := namespaceExclusionCondition(exclusion, argIndex)
+		whereConditions = append(whereConditions, condition)
+		args = append(args, exclusionArgs...)
+		argIndex += len(exclusionArgs)
+	}
+
+	return whereConditions, args, nil
+}
+
+// namespaceExclusionCondition builds a condition excluding servers hidden by the exclusion, numbering parameters from argIndex
+func namespaceExclusionCondition(exclusion NamespaceExclusion, argIndex int) (string, []any) {
+	hidden := fmt.Sprintf("server_name LIKE $%d", argIndex)
+	args := []any{namespaceLikePattern(exclusion.Pattern)}
+
+	if len(exclusion.Except) > 0 {
+		exceptions := make([]string, len(exclusion.Except))
+		for i, pattern := range exclusion.Except {
+			exceptions[i] = fmt.Sprintf("server_name LIKE $%d", argIndex+1+i)
+			args = append(args, namespaceLikePattern(pattern))
+		}
+		hidden += " AND NOT (" + strings.Join(exceptions, " OR ") + ")"
+	}
+
+	return "NOT (" + hidden + ")", args
+}
+
+// namespaceLikePattern converts a permission-style namespace pattern to a LIKE pattern
+func namespaceLikePattern(pattern string) string {
+	escaper := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	if strings.HasSuffix(pattern, "*") {
+		return escaper.Replace(strings.TrimSuffix(pattern, "*")) + "%"
+	}
+	return escaper.Replace(pattern)
+}
+
 // GetServerByName retrieves the latest version of a server by server name
 func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerResponse, error) {
 	if ctx.Err() != nil {
@@ -230,19 +275,14 @@ func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName
 	}
 
 	query := `
-		SELECT server_name, version, status, published_at, updated_at, is_latest, value
+		SELECT ` + serverColumns + `
 		FROM servers
 		WHERE server_name = $1 AND is_latest = true
 		ORDER BY published_at DESC
 		LIMIT 1
 	`
 
-	var name, version, status string
-	var publishedAt, updatedAt time.Time
-	var isLatest bool
-	var valueJSON []byte
-
-	err := db.getExecutor(tx).QueryRow(ctx, query, serverName).Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+	serverResponse, err := scanServerResponse(db.getExecutor(tx).QueryRow(ctx, query, serverName))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -250,25 +290,6 @@ func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName
 		return nil, fmt.Errorf("failed to get server by name: %w", err)
 	}
 
-	// Parse the ServerJSON from JSONB
-	var serverJSON apiv0.ServerJSON
-	if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
-	}
-
-	// Build ServerResponse with separated metadata
-	serverResponse := &apiv0.ServerResponse{
-		Server: serverJSON,
-		Meta: apiv0.ResponseMeta{
-			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(status),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
-			},
-		},
-	}
-
 	return serverResponse, nil
 }
 
@@ -279,18 +300,13 @@ func (db *PostgreSQL) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx,
 	}
 
 	query := `
-		SELECT server_name, version, status, published_at, updated_at, is_latest, value
+		SELECT ` + serverColumns + `
 		FROM servers
 		WHERE server_name = $1 AND version = $2
 		LIMIT 1
 	`
 
-	var name, vers, status string
-	var publishedAt, updatedAt time.Time
-	var isLatest bool
-	var valueJSON []byte
-
-	err := db.getExecutor(tx).QueryRow(ctx, query, serverName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+	serverResponse, err := scanServerResponse(db.getExecutor(tx).QueryRow(ctx, query, serverName, version))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -298,25 +314,6 @@ func (db *PostgreSQL) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx,
 		return nil, fmt.Errorf("failed to get server by name and version: %w", err)
 	}
 
-	// Parse the ServerJSON from JSONB
-	var serverJSON apiv0.ServerJSON
-	if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
-	}
-
-	// Build ServerResponse with separated metadata
-	serverResponse := &apiv0.ServerResponse{
-		Server: serverJSON,
-		Meta: apiv0.ResponseMeta{
-			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(status),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
-			},
-		},
-	}
-
 	return serverResponse, nil
 }
 
@@ -327,7 +324,7 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 	}
 
 	query := `
-		SELECT server_name, version, status, published_at, updated_at, is_latest, value
+		SELECT ` + serverColumns + `
 		FROM servers
 		WHERE server_name = $1
 		ORDER BY published_at DESC
@@ -341,35 +338,11 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 
 	var results []*apiv0.ServerResponse
 	for rows.Next() {
-		var name, version, status string
-		var publishedAt, updatedAt time.Time
-		var isLatest bool
-		var valueJSON []byte
-
-		err := rows.Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+		serverResponse, err := scanServerResponse(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan server row: %w", err)
 		}
 
-		// Parse the ServerJSON from JSONB
-		var serverJSON apiv0.ServerJSON
-		if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
-		}
-
-		// Build ServerResponse with separated metadata
-		serverResponse := &apiv0.ServerResponse{
-			Server: serverJSON,
-			Meta: apiv0.ResponseMeta{
-				Official: &apiv0.RegistryExtensions{
-					Status:      model.Status(status),
-					PublishedAt: publishedAt,
-					UpdatedAt:   updatedAt,
-					IsLatest:    isLatest,
-				},
-			},
-		}
-
 		results = append(results, serverResponse)
 	}
 
@@ -407,8 +380,8 @@ func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *a
 
 	// Insert the new server version using composite primary key
 	insertQuery := `
-		INSERT INTO servers (server_name, version, status, published_at, updated_at, is_latest, value)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO servers (server_name, version, status, published_at, updated_at, is_latest, value, original_schema_version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''))
 	`
 
 	_, err = db.getExecutor(tx).Exec(ctx, insertQuery,
@@ -419,6 +392,7 @@ func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *a
 		officialMeta.UpdatedAt,
 		officialMeta.IsLatest,
 		valueJSON,
+		officialMeta.OriginalSchemaVersion,
 	)
 
 	if err != nil {
@@ -436,6 +410,61 @@ func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *a
 	return serverResponse, nil
 }
 
+// UpsertServer inserts a server version or replaces an existing one, storing the official metadata unchanged
+func (db *PostgreSQL) UpsertServer(ctx context.Context, tx pgx.Tx, serverJSON *apiv0.ServerJSON, officialMeta *apiv0.RegistryExtensions) (*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if serverJSON == nil || officialMeta == nil {
+		return nil, fmt.Errorf("serverJSON and officialMeta are required")
+	}
+
+	if serverJSON.Name == "" || serverJSON.Version == "" {
+		return nil, fmt.Errorf("server name and version are required")
+	}
+
+	valueJSON, err := json.Marshal(serverJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal server JSON: %w", err)
+	}
+
+	upsertQuery := `
+		INSERT INTO servers (server_name, version, status, published_at, updated_at, is_latest, value, original_schema_version, status_message)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), NULLIF($9, ''))
+		ON CONFLICT (server_name, version) DO UPDATE SET
+			status = EXCLUDED.status,
+			published_at = EXCLUDED.published_at,
+			updated_at = EXCLUDED.updated_at,
+			is_latest = EXCLUDED.is_latest,
+			value = EXCLUDED.value,
+			original_schema_version = EXCLUDED.original_schema_version,
+			status_message = EXCLUDED.status_message
+	`
+
+	_, err = db.getExecutor(tx).Exec(ctx, upsertQuery,
+		serverJSON.Name,
+		serverJSON.Version,
+		string(officialMeta.Status),
+		officialMeta.PublishedAt,
+		officialMeta.UpdatedAt,
+		officialMeta.IsLatest,
+		valueJSON,
+		officialMeta.OriginalSchemaVersion,
+		officialMeta.StatusMessage,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert server: %w", err)
+	}
+
+	return &apiv0.ServerResponse{
+		Server: *serverJSON,
+		Meta: apiv0.ResponseMeta{
+			Official: officialMeta,
+		},
+	}, nil
+}
+
 // UpdateServer updates an existing server record with new server details
 func (db *PostgreSQL) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, version string, serverJSON *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
 	if ctx.Err() != nil {
@@ -463,14 +492,9 @@ func (db *PostgreSQL) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, v
 		UPDATE servers
 		SET value = $1, updated_at = NOW()
 		WHERE server_name = $2 AND version = $3
-		RETURNING server_name, version, status, published_at, updated_at, is_latest
-	`
-
-	var name, vers, status string
-	var publishedAt, updatedAt time.Time
-	var isLatest bool
+		RETURNING ` + serverColumns
 
-	err = db.getExecutor(tx).QueryRow(ctx, query, valueJSON, serverName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest)
+	serverResponse, err := scanServerResponse(db.getExecutor(tx).QueryRow(ctx, query, valueJSON, serverName, version))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -478,24 +502,11 @@ func (db *PostgreSQL) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, v
 		return nil, fmt.Errorf("failed to update server: %w", err)
 	}
 
-	// Return the updated ServerResponse
-	serverResponse := &apiv0.ServerResponse{
-		Server: *serverJSON,
-		Meta: apiv0.ResponseMeta{
-			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(status),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
-			},
-		},
-	}
-
 	return serverResponse, nil
 }
 
-// SetServerStatus updates the status of a specific server version
-func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName, version string, status string) (*apiv0.ServerResponse, error) {
+// SetServerStatus updates the status of a specific server version, replacing its status message
+func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName, version string, status, statusMessage string) (*apiv0.ServerResponse, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
@@ -503,17 +514,11 @@ func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName
 	// Update the status column
 	query := `
 		UPDATE servers
-		SET status = $1, updated_at = NOW()
+		SET status = $1, status_message = NULLIF($4, ''), updated_at = NOW()
 		WHERE server_name = $2 AND version = $3
-		RETURNING server_name, version, status, value, published_at, updated_at, is_latest
-	`
+		RETURNING ` + serverColumns
 
-	var name, vers, currentStatus string
-	var publishedAt, updatedAt time.Time
-	var isLatest bool
-	var valueJSON []byte
-
-	err := db.getExecutor(tx).QueryRow(ctx, query, status, serverName, version).Scan(&name, &vers, &currentStatus, &valueJSON, &publishedAt, &updatedAt, &isLatest)
+	serverResponse, err := scanServerResponse(db.getExecutor(tx).QueryRow(ctx, query, status, serverName, version, statusMessage))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -521,26 +526,65 @@ func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName
 		return nil, fmt.Errorf("failed to update server status: %w", err)
 	}
 
-	// Unmarshal the JSON data
-	var serverJSON apiv0.ServerJSON
-	if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	return serverResponse, nil
+}
+
+// SetServerCapabilities stores the capabilities advertised by a specific server version
+func (db *PostgreSQL) SetServerCapabilities(ctx context.Context, tx pgx.Tx, serverName, version string, capabilities *apiv0.Capabilities) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
 	}
 
-	// Return the updated ServerResponse
-	serverResponse := &apiv0.ServerResponse{
-		Server: serverJSON,
-		Meta: apiv0.ResponseMeta{
-			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(currentStatus),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
-			},
-		},
+	var capabilitiesJSON []byte
+	if capabilities != nil {
+		var err error
+		capabilitiesJSON, err = json.Marshal(capabilities)
+		if err != nil {
+			return fmt.Errorf("failed to marshal capabilities: %w", err)
+		}
 	}
 
-	return serverResponse, nil
+	query := `UPDATE servers SET capabilities = $1 WHERE server_name = $2 AND version = $3`
+
+	result, err := db.getExecutor(tx).Exec(ctx, query, capabilitiesJSON, serverName, version)
+	if err != nil {
+		return fmt.Errorf("failed to set server capabilities: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// SetServerOCIImages stores the digests and platforms a specific server version's OCI packages resolved to
+func (db *PostgreSQL) SetServerOCIImages(ctx context.Context, tx pgx.Tx, serverName, version string, images []apiv0.OCIImage) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	var imagesJSON []byte
+	if len(images) > 0 {
+		var err error
+		imagesJSON, err = json.Marshal(images)
+		if err != nil {
+			return fmt.Errorf("failed to marshal OCI images: %w", err)
+		}
+	}
+
+	query := `UPDATE servers SET oci_images = $1 WHERE server_name = $2 AND version = $3`
+
+	result, err := db.getExecutor(tx).Exec(ctx, query, imagesJSON, serverName, version)
+	if err != nil {
+		return fmt.Errorf("failed to set server OCI images: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
 }
 
 // InTransaction executes a function within a database transaction
@@ -612,22 +656,13 @@ func (db *PostgreSQL) GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, se
 		return nil, ctx.Err()
 	}
 
-	executor := db.getExecutor(tx)
-
 	query := `
-		SELECT server_name, version, status, value, published_at, updated_at, is_latest
+		SELECT ` + serverColumns + `
 		FROM servers
 		WHERE server_name = $1 AND is_latest = true
 	`
 
-	row := executor.QueryRow(ctx, query, serverName)
-
-	var name, version, status string
-	var publishedAt, updatedAt time.Time
-	var isLatest bool
-	var jsonValue []byte
-
-	err := row.Scan(&name, &version, &status, &jsonValue, &publishedAt, &updatedAt, &isLatest)
+	serverResponse, err := scanServerResponse(db.getExecutor(tx).QueryRow(ctx, query, serverName))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -635,24 +670,6 @@ func (db *PostgreSQL) GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, se
 		return nil, fmt.Errorf("failed to scan server row: %w", err)
 	}
 
-	// Parse the JSON value to get the server details
-	var serverJSON apiv0.ServerJSON
-	if err := json.Unmarshal(jsonValue, &serverJSON); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
-	}
-
-	// Build ServerResponse with separated metadata
-	serverResponse := &apiv0.ServerResponse{
-		Server: serverJSON,
-		Meta: apiv0.ResponseMeta{
-			Official: &apiv0.RegistryExtensions{
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
-			},
-		},
-	}
-
 	return serverResponse, nil
 }
 
@@ -694,6 +711,47 @@ func (db *PostgreSQL) CheckVersionExists(ctx context.Context, tx pgx.Tx, serverN
 	return exists, nil
 }
 
+// GetSyncHighWaterMark retrieves the latest upstream update applied from an upstream registry
+func (db *PostgreSQL) GetSyncHighWaterMark(ctx context.Context, tx pgx.Tx, upstreamURL string) (time.Time, error) {
+	if ctx.Err() != nil {
+		return time.Time{}, ctx.Err()
+	}
+
+	query := `SELECT high_water_mark FROM sync_state WHERE upstream_url = $1`
+
+	var mark time.Time
+	err := db.getExecutor(tx).QueryRow(ctx, query, upstreamURL).Scan(&mark)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return time.Time{}, ErrNotFound
+		}
+		return time.Time{}, fmt.Errorf("failed to get sync high-water mark: %w", err)
+	}
+
+	return mark, nil
+}
+
+// SetSyncHighWaterMark stores the latest upstream update applied from an upstream registry
+func (db *PostgreSQL) SetSyncHighWaterMark(ctx context.Context, tx pgx.Tx, upstreamURL string, mark time.Time) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO sync_state (upstream_url, high_water_mark, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (upstream_url) DO UPDATE SET
+			high_water_mark = EXCLUDED.high_water_mark,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := db.getExecutor(tx).Exec(ctx, query, upstreamURL, mark); err != nil {
+		return fmt.Errorf("failed to set sync high-water mark: %w", err)
+	}
+
+	return nil
+}
+
 // UnmarkAsLatest marks the current latest version of a server as no longer latest
 func (db *PostgreSQL) UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName string) error {
 	if ctx.Err() != nil {
@@ -712,6 +770,60 @@ func (db *PostgreSQL) UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName
 	return nil
 }
 
+// scanServerResponse scans a row selected with serverColumns into a ServerResponse
+func scanServerResponse(row pgx.Row) (*apiv0.ServerResponse, error) {
+	var serverName, version, status string
+	var publishedAt, updatedAt time.Time
+	var isLatest bool
+	var valueJSON, capabilitiesJSON, ociImagesJSON []byte
+	var originalSchemaVersion, statusMessage *string
+
+	if err := row.Scan(&serverName, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON, &capabilitiesJSON, &ociImagesJSON, &originalSchemaVersion, &statusMessage); err != nil {
+		return nil, err
+	}
+
+	// Parse the ServerJSON from JSONB
+	var serverJSON apiv0.ServerJSON
+	if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	}
+
+	// Build ServerResponse with separated metadata
+	serverResponse := &apiv0.ServerResponse{
+		Server: serverJSON,
+		Meta: apiv0.ResponseMeta{
+			Official: &apiv0.RegistryExtensions{
+				Status:      model.Status(status),
+				PublishedAt: publishedAt,
+				UpdatedAt:   updatedAt,
+				IsLatest:    isLatest,
+			},
+		},
+	}
+	if originalSchemaVersion != nil {
+		serverResponse.Meta.Official.OriginalSchemaVersion = *originalSchemaVersion
+	}
+	if statusMessage != nil {
+		serverResponse.Meta.Official.StatusMessage = *statusMessage
+	}
+
+	if len(capabilitiesJSON) > 0 {
+		var capabilities apiv0.Capabilities
+		if err := json.Unmarshal(capabilitiesJSON, &capabilities); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal server capabilities: %w", err)
+		}
+		serverResponse.Meta.Capabilities = &capabilities
+	}
+
+	if len(ociImagesJSON) > 0 {
+		if err := json.Unmarshal(ociImagesJSON, &serverResponse.Meta.OCIImages); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal server OCI images: %w", err)
+		}
+	}
+
+	return serverResponse, nil
+}
+
 // Close closes the database connection
 func (db *PostgreSQL) Close() error {
 	db.pool.Close()
@@ -528,7 +528,7 @@ func TestPostgreSQL_SetServerStatus(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := db.SetServerStatus(ctx, nil, tt.serverName, tt.version, tt.newStatus)
+			result, err := db.SetServerStatus(ctx, nil, tt.serverName, tt.version, tt.newStatus, "")
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -546,6 +546,241 @@ func TestPostgreSQL_SetServerStatus(t *testing.T) {
 	}
 }
 
+func TestPostgreSQL_UpsertServer(t *testing.T) {
+	db := database.NewTestDB(t)
+	ctx := context.Background()
+
+	serverJSON := &apiv0.ServerJSON{
+		Name:        "com.example/upsert-test-server",
+		Description: "A server for upsert testing",
+		Version:     "1.0.0",
+	}
+	publishedAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Microsecond)
+
+	t.Run("insert keeps the status message", func(t *testing.T) {
+		_, err := db.UpsertServer(ctx, nil, serverJSON, &apiv0.RegistryExtensions{
+			Status:        model.StatusDeprecated,
+			StatusMessage: "Use 2.0.0 instead",
+			PublishedAt:   publishedAt,
+			UpdatedAt:     publishedAt,
+			IsLatest:      true,
+		})
+		require.NoError(t, err)
+
+		result, err := db.GetServerByNameAndVersion(ctx, nil, serverJSON.Name, serverJSON.Version)
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusDeprecated, result.Meta.Official.Status)
+		assert.Equal(t, "Use 2.0.0 instead", result.Meta.Official.StatusMessage)
+		assert.True(t, result.Meta.Official.PublishedAt.Equal(publishedAt))
+	})
+
+	t.Run("overwrite replaces the status message", func(t *testing.T) {
+		_, err := db.UpsertServer(ctx, nil, serverJSON, &apiv0.RegistryExtensions{
+			Status:      model.StatusActive,
+			PublishedAt: publishedAt,
+			UpdatedAt:   time.Now(),
+			IsLatest:    true,
+		})
+		require.NoError(t, err)
+
+		result, err := db.GetServerByNameAndVersion(ctx, nil, serverJSON.Name, serverJSON.Version)
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusActive, result.Meta.Official.Status)
+		assert.Empty(t, result.Meta.Official.StatusMessage)
+	})
+}
+
+func TestPostgreSQL_SetServerCapabilities(t *testing.T) {
+	db := database.NewTestDB(t)
+	ctx := context.Background()
+
+	officialMeta := &apiv0.RegistryExtensions{
+		Status:      model.StatusActive,
+		PublishedAt: time.Now(),
+		UpdatedAt:   time.Now(),
+		IsLatest:    true,
+	}
+	for _, name := range []string{"com.example/issues-server", "com.example/files-server"} {
+		_, err := db.CreateServer(ctx, nil, &apiv0.ServerJSON{
+			Name:        name,
+			Description: "A server for capability testing",
+			Version:     "1.0.0",
+		}, officialMeta)
+		require.NoError(t, err)
+	}
+
+	capabilities := &apiv0.Capabilities{
+		Source: apiv0.CapabilitiesSourceDiscovered,
+		Tools: []apiv0.Capability{
+			{Name: "create_issue", Description: "Create an issue"},
+			{Name: "close_issue"},
+		},
+	}
+	err := db.SetServerCapabilities(ctx, nil, "com.example/issues-server", "1.0.0", capabilities)
+	require.NoError(t, err)
+
+	t.Run("capabilities are returned on reads", func(t *testing.T) {
+		result, err := db.GetServerByNameAndVersion(ctx, nil, "com.example/issues-server", "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, capabilities, result.Meta.Capabilities)
+
+		other, err := db.GetServerByName(ctx, nil, "com.example/files-server")
+		require.NoError(t, err)
+		assert.Nil(t, other.Meta.Capabilities)
+	})
+
+	t.Run("filter by tool name", func(t *testing.T) {
+		toolName := "create_issue"
+		results, _, err := db.ListServers(ctx, nil, &database.ServerFilter{ToolName: &toolName}, "", 10)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "com.example/issues-server", results[0].Server.Name)
+
+		toolName = "create"
+		results, _, err = db.ListServers(ctx, nil, &database.ServerFilter{ToolName: &toolName}, "", 10)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("non-existent server", func(t *testing.T) {
+		err := db.SetServerCapabilities(ctx, nil, "com.example/non-existent", "1.0.0", capabilities)
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+}
+
+func TestPostgreSQL_SetServerOCIImages(t *testing.T) {
+	db := database.NewTestDB(t)
+	ctx := context.Background()
+
+	officialMeta := &apiv0.RegistryExtensions{
+		Status:      model.StatusActive,
+		PublishedAt: time.Now(),
+		UpdatedAt:   time.Now(),
+		IsLatest:    true,
+	}
+	for _, name := range []string{"com.example/multi-arch-server", "com.example/amd64-server", "com.example/armv7-server"} {
+		_, err := db.CreateServer(ctx, nil, &apiv0.ServerJSON{
+			Name:        name,
+			Description: "A server for OCI image testing",
+			Version:     "1.0.0",
+		}, officialMeta)
+		require.NoError(t, err)
+	}
+
+	multiArch := []apiv0.OCIImage{{
+		Identifier: "docker.io/example/multi-arch-server:1.0.0",
+		Digest:     "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+		Platforms:  []string{"linux/amd64", "linux/arm64", "linux/arm64/v8"},
+	}}
+	require.NoError(t, db.SetServerOCIImages(ctx, nil, "com.example/multi-arch-server", "1.0.0", multiArch))
+	require.NoError(t, db.SetServerOCIImages(ctx, nil, "com.example/amd64-server", "1.0.0", []apiv0.OCIImage{{
+		Identifier: "docker.io/example/amd64-server:1.0.0",
+		Digest:     "sha256:2222222222222222222222222222222222222222222222222222222222222222",
+		Platforms:  []string{"linux/amd64"},
+	}}))
+	require.NoError(t, db.SetServerOCIImages(ctx, nil, "com.example/armv7-server", "1.0.0", []apiv0.OCIImage{{
+		Identifier: "docker.io/example/armv7-server:1.0.0",
+		Digest:     "sha256:3333333333333333333333333333333333333333333333333333333333333333",
+		Platforms:  []string{"linux/arm", "linux/arm/v7"},
+	}}))
+
+	t.Run("images are returned on reads", func(t *testing.T) {
+		result, err := db.GetServerByNameAndVersion(ctx, nil, "com.example/multi-arch-server", "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, multiArch, result.Meta.OCIImages)
+	})
+
+	t.Run("filter by platform", func(t *testing.T) {
+		platform := "linux/arm64"
+		results, _, err := db.ListServers(ctx, nil, &database.ServerFilter{Platform: &platform}, "", 10)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "com.example/multi-arch-server", results[0].Server.Name)
+
+		platform = "linux/amd64"
+		results, _, err = db.ListServers(ctx, nil, &database.ServerFilter{Platform: &platform}, "", 10)
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+	})
+
+	t.Run("filter by platform with variant", func(t *testing.T) {
+		for platform, expected := range map[string][]string{
+			"linux/arm":      {"com.example/armv7-server"},
+			"linux/arm/v7":   {"com.example/armv7-server"},
+			"linux/arm/v6":   nil,
+			"linux/arm64/v8": {"com.example/multi-arch-server"},
+		} {
+			results, _, err := db.ListServers(ctx, nil, &database.ServerFilter{Platform: &platform}, "", 10)
+			require.NoError(t, err)
+			var names []string
+			for _, result := range results {
+				names = append(names, result.Server.Name)
+			}
+			assert.Equal(t, expected, names, platform)
+		}
+	})
+
+	t.Run("clearing images", func(t *testing.T) {
+		require.NoError(t, db.SetServerOCIImages(ctx, nil, "com.example/amd64-server", "1.0.0", nil))
+
+		result, err := db.GetServerByName(ctx, nil, "com.example/amd64-server")
+		require.NoError(t, err)
+		assert.Empty(t, result.Meta.OCIImages)
+	})
+
+	t.Run("non-existent server", func(t *testing.T) {
+		err := db.SetServerOCIImages(ctx, nil, "com.example/non-existent", "1.0.0", multiArch)
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+}
+
+func TestPostgreSQL_ListServersHiddenNamespaces(t *testing.T) {
+	db := database.NewTestDB(t)
+	ctx := context.Background()
+
+	officialMeta := &apiv0.RegistryExtensions{
+		Status:      model.StatusActive,
+		PublishedAt: time.Now(),
+		UpdatedAt:   time.Now(),
+		IsLatest:    true,
+	}
+	for _, name := range []string{"com.example/internal", "com.example/team-tool", "com.example_x/lookalike", "io.github.user/public"} {
+		_, err := db.CreateServer(ctx, nil, &apiv0.ServerJSON{
+			Name:        name,
+			Description: "A server for visibility testing",
+			Version:     "1.0.0",
+		}, officialMeta)
+		require.NoError(t, err)
+	}
+
+	listNames := func(t *testing.T, hidden []database.NamespaceExclusion) []string {
+		t.Helper()
+		results, _, err := db.ListServers(ctx, nil, &database.ServerFilter{HiddenNamespaces: hidden}, "", 10)
+		require.NoError(t, err)
+		names := make([]string, len(results))
+		for i, result := range results {
+			names[i] = result.Server.Name
+		}
+		return names
+	}
+
+	t.Run("hidden namespace", func(t *testing.T) {
+		// The underscore must not act as a LIKE wildcard
+		names := listNames(t, []database.NamespaceExclusion{{Pattern: "com.example/*"}})
+		assert.ElementsMatch(t, []string{"com.example_x/lookalike", "io.github.user/public"}, names)
+	})
+
+	t.Run("hidden namespace with exceptions", func(t *testing.T) {
+		names := listNames(t, []database.NamespaceExclusion{{Pattern: "com.example/*", Except: []string{"com.example/team-*"}}})
+		assert.ElementsMatch(t, []string{"com.example/team-tool", "com.example_x/lookalike", "io.github.user/public"}, names)
+	})
+
+	t.Run("multiple hidden namespaces", func(t *testing.T) {
+		names := listNames(t, []database.NamespaceExclusion{{Pattern: "com.example/*"}, {Pattern: "io.github.user/public"}})
+		assert.ElementsMatch(t, []string{"com.example_x/lookalike"}, names)
+	})
+}
+
 func TestPostgreSQL_TransactionHandling(t *testing.T) {
 	db := database.NewTestDB(t)
 	ctx := context.Background()
@@ -867,7 +1102,7 @@ func TestPostgreSQL_EdgeCases(t *testing.T) {
 		}
 
 		for _, status := range statuses {
-			result, err := db.SetServerStatus(ctx, nil, serverName, version, status)
+			result, err := db.SetServerStatus(ctx, nil, serverName, version, status, "")
 			assert.NoError(t, err, "Should allow transition to %s", status)
 			assert.Equal(t, model.Status(status), result.Meta.Official.Status)
 		}
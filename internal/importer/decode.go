@@ -0,0 +1,177 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// importEntry is a single server version read from an import source
+type importEntry struct {
+	server apiv0.ServerJSON
+	// official is the registry metadata from registry-format entries, if present
+	official *apiv0.RegistryExtensions
+	// err is set when the entry could not be decoded; server holds whatever name and version could be read
+	err error
+}
+
+// decodeEntries streams entries from a JSON array, newline-delimited JSON or registry list responses,
+// decompressing gzip input transparently. Syntax errors stop decoding; malformed entries are passed on with err set.
+func decodeEntries(r io.Reader, apply func(importEntry) error) error {
+	reader := bufio.NewReader(r)
+
+	if magic, _ := reader.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return fmt.Errorf("failed to open gzip stream: %w", err)
+		}
+		defer gz.Close()
+		reader = bufio.NewReader(gz)
+	}
+
+	first, err := peekNonSpace(reader)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(reader)
+	switch first {
+	case '[':
+		return decodeArray(decoder, apply)
+	case '{':
+		return decodeObjects(decoder, apply)
+	default:
+		return fmt.Errorf("unrecognised input: expected a JSON array or JSON objects, got %q", first)
+	}
+}
+
+// decodeArray streams the entries of a JSON array
+func decodeArray(decoder *json.Decoder, apply func(importEntry) error) error {
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+	for decoder.More() {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return err
+		}
+		if err := apply(parseEntry(raw)); err != nil {
+			return err
+		}
+	}
+	_, err := decoder.Token()
+	return err
+}
+
+// decodeObjects streams consecutive JSON objects, each either an entry or a registry list response
+func decodeObjects(decoder *json.Decoder, apply func(importEntry) error) error {
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		var probe struct {
+			Servers json.RawMessage `json:"servers"`
+		}
+		if json.Unmarshal(raw, &probe) == nil && probe.Servers != nil {
+			if _, err := decodeListResponse(bytes.NewReader(raw), apply); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := apply(parseEntry(raw)); err != nil {
+			return err
+		}
+	}
+}
+
+// decodeListResponse streams the servers of a registry list response, returning its next cursor
+func decodeListResponse(r io.Reader, apply func(importEntry) error) (string, error) {
+	decoder := json.NewDecoder(r)
+	if _, err := decoder.Token(); err != nil {
+		return "", err
+	}
+
+	nextCursor := ""
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+
+		switch key {
+		case "servers":
+			if err := decodeArray(decoder, apply); err != nil {
+				return "", err
+			}
+		case "metadata":
+			var metadata apiv0.Metadata
+			if err := decoder.Decode(&metadata); err != nil {
+				return "", err
+			}
+			nextCursor = metadata.NextCursor
+		default:
+			var skip json.RawMessage
+			if err := decoder.Decode(&skip); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	_, err := decoder.Token()
+	return nextCursor, err
+}
+
+// parseEntry decodes a plain server.json entry or a registry-format {"server": ..., "_meta": ...} entry
+func parseEntry(raw json.RawMessage) importEntry {
+	var probe struct {
+		Server  json.RawMessage `json:"server"`
+		Name    string          `json:"name"`
+		Version string          `json:"version"`
+	}
+	if err := json.Unmarshal(raw, &probe); err != nil {
+		return importEntry{err: fmt.Errorf("invalid entry: %w", err)}
+	}
+
+	if probe.Server != nil {
+		var response apiv0.ServerResponse
+		if err := json.Unmarshal(raw, &response); err != nil {
+			return importEntry{server: response.Server, err: fmt.Errorf("invalid entry: %w", err)}
+		}
+		return importEntry{server: response.Server, official: response.Meta.Official}
+	}
+
+	var server apiv0.ServerJSON
+	if err := json.Unmarshal(raw, &server); err != nil {
+		return importEntry{server: apiv0.ServerJSON{Name: probe.Name, Version: probe.Version}, err: fmt.Errorf("invalid entry: %w", err)}
+	}
+	return importEntry{server: server}
+}
+
+// peekNonSpace skips leading whitespace and returns the next byte without consuming it
+func peekNonSpace(reader *bufio.Reader) (byte, error) {
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return b, reader.UnreadByte()
+	}
+}
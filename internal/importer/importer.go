@@ -2,19 +2,83 @@ package importer
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
-	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/validators"
 )
 
+// ExistingPolicy controls what happens when an imported version already exists
+type ExistingPolicy string
+
+const (
+	// ExistingSkip leaves existing versions untouched
+	ExistingSkip ExistingPolicy = "skip"
+	// ExistingOverwrite replaces existing versions with the imported data
+	ExistingOverwrite ExistingPolicy = "overwrite"
+	// ExistingFail reports existing versions as failures
+	ExistingFail ExistingPolicy = "fail"
+)
+
+// ParseExistingPolicy parses an existing-version policy name
+func ParseExistingPolicy(value string) (ExistingPolicy, error) {
+	switch policy := ExistingPolicy(value); policy {
+	case ExistingSkip, ExistingOverwrite, ExistingFail:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid existing version policy %q (expected skip, overwrite or fail)", value)
+	}
+}
+
+// Options controls how an import is applied
+type Options struct {
+	// Existing is the policy for versions that are already in the registry, defaulting to ExistingSkip
+	Existing ExistingPolicy
+	// PreserveTimestamps keeps the status, timestamps and latest flag from registry-format input
+	// (entries with _meta.io.modelcontextprotocol.registry/official) instead of treating them as new publishes
+	PreserveTimestamps bool
+	// DryRun reports what would happen without writing anything
+	DryRun bool
+
+	// progress is called after each entry is applied, so jobs can report partial results
+	progress func(outcome, ReportEntry)
+}
+
+// ReportEntry identifies an imported server version and, for skips and failures, why
+type ReportEntry struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// Report is the machine-readable outcome of an import
+type Report struct {
+	DryRun      bool          `json:"dryRun"`
+	Created     []ReportEntry `json:"created"`
+	Overwritten []ReportEntry `json:"overwritten"`
+	Skipped     []ReportEntry `json:"skipped"`
+	Failed      []ReportEntry `json:"failed"`
+}
+
+func newReport(dryRun bool) *Report {
+	return &Report{
+		DryRun:      dryRun,
+		Created:     []ReportEntry{},
+		Overwritten: []ReportEntry{},
+		Skipped:     []ReportEntry{},
+		Failed:      []ReportEntry{},
+	}
+}
+
 // Service handles importing seed data into the registry
 type Service struct {
 	registry service.RegistryService
@@ -25,107 +89,205 @@ func NewService(registry service.RegistryService) *Service {
 	return &Service{registry: registry}
 }
 
-// ImportFromPath imports seed data from various sources:
-// 1. Local file paths (*.json files) - expects ServerJSON array format
-// 2. Direct HTTP URLs to seed.json files - expects ServerJSON array format
-// 3. Registry root URLs (automatically appends /v0/servers and paginates)
-func (s *Service) ImportFromPath(ctx context.Context, path string) error {
-	servers, err := readSeedFile(ctx, path)
+// Import streams server versions from a source and applies them according to the options:
+// 1. Local file paths, or "-" for standard input
+// 2. Direct HTTP URLs to seed files
+// 3. Registry API URLs containing /v0/servers (paginated automatically)
+//
+// Files may be a JSON array, newline-delimited JSON, or a registry list response, optionally gzip-compressed.
+// Entries may be plain server.json objects or registry-format {"server": ..., "_meta": ...} objects.
+func (s *Service) Import(ctx context.Context, path string, opts Options) (*Report, error) {
+	if opts.Existing == "" {
+		opts.Existing = ExistingSkip
+	}
+	report := newReport(opts.DryRun)
+
+	apply := s.applier(ctx, opts, report)
+
+	var err error
+	if isHTTP(path) && strings.Contains(path, "/v0/servers") {
+		err = importFromRegistryAPI(ctx, path, apply)
+	} else {
+		err = s.importFromSource(ctx, path, apply)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to read seed data: %w", err)
+		return report, fmt.Errorf("failed to read seed data: %w", err)
 	}
 
-	// Import each server using registry service CreateServer
-	var successfullyCreated []string
-	var failedCreations []string
+	return report, nil
+}
 
-	for _, server := range servers {
-		_, err := s.registry.CreateServer(ctx, server)
-		if err != nil {
-			failedCreations = append(failedCreations, fmt.Sprintf("%s: %v", server.Name, err))
-			log.Printf("Failed to create server %s: %v", server.Name, err)
-		} else {
-			successfullyCreated = append(successfullyCreated, server.Name)
-		}
+// ImportReader imports server versions from a stream in any of the formats supported by Import
+func (s *Service) ImportReader(ctx context.Context, r io.Reader, opts Options) (*Report, error) {
+	if opts.Existing == "" {
+		opts.Existing = ExistingSkip
 	}
+	report := newReport(opts.DryRun)
 
-	// Report import results after actual creation attempts
-	if len(failedCreations) > 0 {
-		log.Printf("Import completed with errors: %d servers created successfully, %d servers failed",
-			len(successfullyCreated), len(failedCreations))
-		log.Printf("Failed servers: %v", failedCreations)
-		return fmt.Errorf("failed to import %d servers", len(failedCreations))
+	if err := decodeEntries(r, s.applier(ctx, opts, report)); err != nil {
+		return report, fmt.Errorf("failed to read seed data: %w", err)
 	}
 
-	log.Printf("Import completed successfully: all %d servers created", len(successfullyCreated))
-	return nil
+	return report, nil
 }
 
-// readSeedFile reads seed data from various sources
-func readSeedFile(ctx context.Context, path string) ([]*apiv0.ServerJSON, error) {
-	var data []byte
-	var err error
+// applier returns a callback that applies each decoded entry and records it in the report,
+// calling opts.progress (if set) after each one
+func (s *Service) applier(ctx context.Context, opts Options, report *Report) func(importEntry) error {
+	return func(entry importEntry) error {
+		result, reportEntry := s.applyEntry(ctx, entry, opts)
+		report.record(result, reportEntry)
+		if opts.progress != nil {
+			opts.progress(result, reportEntry)
+		}
+		return ctx.Err()
+	}
+}
 
-	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
-		// Handle HTTP URLs
-		if strings.HasSuffix(path, "/v0/servers") || strings.Contains(path, "/v0/servers") {
-			// This is a registry API endpoint - fetch paginated data
-			return fetchFromRegistryAPI(ctx, path)
+func (s *Service) importFromSource(ctx context.Context, path string, apply func(importEntry) error) error {
+	var source io.ReadCloser
+	switch {
+	case path == "-":
+		source = io.NopCloser(os.Stdin)
+	case isHTTP(path):
+		body, err := fetchFromHTTP(ctx, path)
+		if err != nil {
+			return err
 		}
-		// This is a direct file URL
-		data, err = fetchFromHTTP(ctx, path)
-	} else {
-		// Handle local file paths
-		data, err = os.ReadFile(path)
+		source = body
+	default:
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		source = file
 	}
+	defer source.Close()
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to read seed data from %s: %w", path, err)
+	return decodeEntries(source, apply)
+}
+
+// outcome is what happened to a single imported entry
+type outcome int
+
+const (
+	outcomeCreated outcome = iota
+	outcomeOverwritten
+	outcomeSkipped
+	outcomeFailed
+)
+
+// record adds an entry to the list in the report matching its outcome
+func (r *Report) record(result outcome, entry ReportEntry) {
+	switch result {
+	case outcomeCreated:
+		r.Created = append(r.Created, entry)
+	case outcomeOverwritten:
+		r.Overwritten = append(r.Overwritten, entry)
+	case outcomeSkipped:
+		r.Skipped = append(r.Skipped, entry)
+	case outcomeFailed:
+		r.Failed = append(r.Failed, entry)
 	}
+}
 
-	// Parse ServerJSON array format
-	var serverResponses []apiv0.ServerJSON
-	if err := json.Unmarshal(data, &serverResponses); err != nil {
-		return nil, fmt.Errorf("failed to parse seed data as ServerJSON array format: %w", err)
+// applyEntry imports a single entry, returning its outcome and, for skips and failures, the reason
+func (s *Service) applyEntry(ctx context.Context, entry importEntry, opts Options) (outcome, ReportEntry) {
+	reportEntry := ReportEntry{Name: entry.server.Name, Version: entry.server.Version}
+	fail := func(err error) (outcome, ReportEntry) {
+		reportEntry.Reason = err.Error()
+		return outcomeFailed, reportEntry
 	}
 
-	if len(serverResponses) == 0 {
-		return []*apiv0.ServerJSON{}, nil
+	if entry.err != nil {
+		return fail(entry.err)
+	}
+	if err := validators.ValidateServerJSON(&entry.server); err != nil {
+		return fail(err)
 	}
 
-	// Validate servers and collect warnings instead of failing the whole batch
-	var validRecords []*apiv0.ServerJSON
-	var invalidServers []string
-	var validationFailures []string
+	existing, err := s.registry.GetServerByNameAndVersion(ctx, entry.server.Name, entry.server.Version)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return fail(err)
+	}
 
-	for _, response := range serverResponses {
-		if err := validators.ValidateServerJSON(&response); err != nil {
-			// Log warning and track invalid server instead of failing
-			invalidServers = append(invalidServers, response.Name)
-			validationFailures = append(validationFailures, fmt.Sprintf("Server '%s': %v", response.Name, err))
-			log.Printf("Warning: Skipping invalid server '%s': %v", response.Name, err)
-			continue
+	if existing != nil {
+		switch opts.Existing {
+		case ExistingSkip:
+			reportEntry.Reason = "version already exists"
+			return outcomeSkipped, reportEntry
+		case ExistingFail:
+			return fail(fmt.Errorf("version already exists"))
+		case ExistingOverwrite:
 		}
+	}
 
-		// Add valid ServerJSON to records
-		validRecords = append(validRecords, &response)
+	var officialMeta *apiv0.RegistryExtensions
+	if opts.PreserveTimestamps && entry.official != nil {
+		officialMeta = preservedMetadata(entry.official, time.Now())
 	}
 
-	// Print summary of validation results
-	if len(invalidServers) > 0 {
-		log.Printf("Validation summary: %d servers passed validation, %d invalid servers skipped", len(validRecords), len(invalidServers))
-		log.Printf("Invalid servers: %v", invalidServers)
-		for _, failure := range validationFailures {
-			log.Printf("  - %s", failure)
+	switch {
+	case opts.DryRun && existing == nil && officialMeta == nil:
+		// A real import would publish this version, so a dry run reports what publishing would reject
+		if err := s.checkPublish(ctx, &entry.server); err != nil {
+			return fail(err)
 		}
-	} else {
-		log.Printf("Validation summary: All %d servers passed validation", len(validRecords))
+	case opts.DryRun:
+	case existing == nil && officialMeta == nil:
+		if _, err := s.registry.CreateServer(ctx, &entry.server); err != nil {
+			return fail(err)
+		}
+	default:
+		if _, err := s.registry.ImportServer(ctx, &entry.server, officialMeta); err != nil {
+			return fail(err)
+		}
+	}
+
+	if existing != nil {
+		return outcomeOverwritten, reportEntry
+	}
+	return outcomeCreated, reportEntry
+}
+
+// preservedMetadata copies the registry metadata of an imported entry, using the import time for
+// timestamps the entry leaves out (hand-written seed files often only give publishedAt)
+func preservedMetadata(official *apiv0.RegistryExtensions, now time.Time) *apiv0.RegistryExtensions {
+	meta := *official
+	if meta.PublishedAt.IsZero() {
+		meta.PublishedAt = now
+	}
+	if meta.UpdatedAt.IsZero() {
+		meta.UpdatedAt = now
+	}
+	return &meta
+}
+
+// checkPublish runs the checks CreateServer runs, returning every failing check as a single error
+func (s *Service) checkPublish(ctx context.Context, server *apiv0.ServerJSON) error {
+	checks, err := s.registry.CheckPublish(ctx, server)
+	if err != nil {
+		return err
 	}
 
-	return validRecords, nil
+	var failures []string
+	for _, check := range checks {
+		if check.Status == apiv0.CheckStatusFail {
+			failures = append(failures, check.Name+": "+check.Message)
+		}
+	}
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "; "))
+	}
+	return nil
 }
 
-func fetchFromHTTP(ctx context.Context, url string) ([]byte, error) {
+func isHTTP(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
+
+// fetchFromHTTP opens a streaming GET request, returning the response body for the caller to close
+func fetchFromHTTP(ctx context.Context, url string) (io.ReadCloser, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
@@ -135,56 +297,46 @@ func fetchFromHTTP(ctx context.Context, url string) ([]byte, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch from HTTP: %w", err)
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
 		return nil, fmt.Errorf("HTTP request failed with status: %d", resp.StatusCode)
 	}
 
-	return io.ReadAll(resp.Body)
+	return resp.Body, nil
 }
 
-func fetchFromRegistryAPI(ctx context.Context, baseURL string) ([]*apiv0.ServerJSON, error) {
-	var allRecords []*apiv0.ServerJSON
+// importFromRegistryAPI pages through a registry's list endpoint, streaming each page
+func importFromRegistryAPI(ctx context.Context, baseURL string, apply func(importEntry) error) error {
 	cursor := ""
 
 	for {
-		url := baseURL
+		pageURL := baseURL
 		if cursor != "" {
-			if strings.Contains(url, "?") {
-				url += "&cursor=" + cursor
+			if strings.Contains(pageURL, "?") {
+				pageURL += "&cursor=" + url.QueryEscape(cursor)
 			} else {
-				url += "?cursor=" + cursor
+				pageURL += "?cursor=" + url.QueryEscape(cursor)
 			}
 		}
 
-		data, err := fetchFromHTTP(ctx, url)
+		body, err := fetchFromHTTP(ctx, pageURL)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch page from registry API: %w", err)
-		}
-
-		var response struct {
-			Servers  []apiv0.ServerResponse `json:"servers"`
-			Metadata *struct {
-				NextCursor string `json:"nextCursor,omitempty"`
-			} `json:"metadata,omitempty"`
+			return fmt.Errorf("failed to fetch page from registry API: %w", err)
 		}
 
-		if err := json.Unmarshal(data, &response); err != nil {
-			return nil, fmt.Errorf("failed to parse registry API response: %w", err)
-		}
-
-		// Extract ServerJSON from each ServerResponse
-		for _, serverResponse := range response.Servers {
-			allRecords = append(allRecords, &serverResponse.Server)
+		nextCursor, err := decodeListResponse(body, apply)
+		body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to parse registry API response: %w", err)
 		}
 
 		// Check if there's a next page
-		if response.Metadata == nil || response.Metadata.NextCursor == "" {
+		if nextCursor == "" {
 			break
 		}
-		cursor = response.Metadata.NextCursor
+		cursor = nextCursor
 	}
 
-	return allRecords, nil
+	return nil
 }
@@ -48,8 +48,9 @@ func TestImportService_LocalFile(t *testing.T) {
 
 	// Create importer service and test import
 	importerService := importer.NewService(registryService)
-	err = importerService.ImportFromPath(context.Background(), tempFile)
+	report, err := importerService.Import(context.Background(), tempFile, importer.Options{})
 	require.NoError(t, err)
+	assert.Empty(t, report.Failed)
 
 	// Verify the server was imported using registry service
 	servers, _, err := registryService.ListServers(context.Background(), nil, "", 10)
@@ -94,8 +95,9 @@ func TestImportService_HTTPFile(t *testing.T) {
 
 	// Create importer service and test import
 	importerService := importer.NewService(registryService)
-	err = importerService.ImportFromPath(context.Background(), httpServer.URL+"/seed.json")
+	report, err := importerService.Import(context.Background(), httpServer.URL+"/seed.json", importer.Options{})
 	require.NoError(t, err)
+	assert.Empty(t, report.Failed)
 
 	// Verify the server was imported
 	servers, _, err := registryService.ListServers(context.Background(), nil, "", 10)
@@ -163,8 +165,9 @@ func TestImportService_RegistryPagination(t *testing.T) {
 
 	// Create importer service and test registry import
 	importerService := importer.NewService(targetRegistryService)
-	err := importerService.ImportFromPath(context.Background(), httpServer.URL+"/v0/servers")
+	report, err := importerService.Import(context.Background(), httpServer.URL+"/v0/servers", importer.Options{})
 	require.NoError(t, err)
+	assert.Empty(t, report.Failed)
 
 	// Verify servers were imported
 	importedServers, _, err := targetRegistryService.ListServers(context.Background(), nil, "", 10)
@@ -230,7 +233,7 @@ func TestImportService_ErrorHandling(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := importerService.ImportFromPath(context.Background(), tt.path)
+			_, err := importerService.Import(context.Background(), tt.path, importer.Options{})
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -0,0 +1,225 @@
+package importer
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// JobState is the lifecycle state of an import job
+type JobState string
+
+const (
+	// JobRunning jobs are still reading or applying entries
+	JobRunning JobState = "running"
+	// JobSucceeded jobs applied every entry
+	JobSucceeded JobState = "succeeded"
+	// JobFailed jobs could not read their source or had entries that failed
+	JobFailed JobState = "failed"
+)
+
+// maxRetainedJobs bounds how many finished jobs are kept in memory
+const maxRetainedJobs = 50
+
+// JobProgress counts the entries processed so far
+type JobProgress struct {
+	Processed   int `json:"processed" doc:"Entries processed so far"`
+	Created     int `json:"created" doc:"Versions created so far"`
+	Overwritten int `json:"overwritten" doc:"Existing versions overwritten so far"`
+	Skipped     int `json:"skipped" doc:"Existing versions skipped so far"`
+	Failed      int `json:"failed" doc:"Entries that failed so far"`
+}
+
+// Job is a snapshot of an import job, as returned by the admin imports API
+type Job struct {
+	ID         string      `json:"id" doc:"Import job ID"`
+	Source     string      `json:"source" doc:"Path, URL or uploaded file name being imported"`
+	State      JobState    `json:"state" doc:"Job state" enum:"running,succeeded,failed"`
+	Error      string      `json:"error,omitempty" doc:"Why the job failed, if the source could not be read or any entry failed"`
+	StartedAt  time.Time   `json:"startedAt" doc:"When the job started"`
+	FinishedAt *time.Time  `json:"finishedAt,omitempty" doc:"When the job finished"`
+	Progress   JobProgress `json:"progress" doc:"Entries processed so far"`
+	Report     *Report     `json:"report" doc:"Per-server outcomes so far"`
+}
+
+// JobManager runs imports in the background and keeps their progress in memory.
+// Jobs are not persisted, so their history is lost on restart.
+type JobManager struct {
+	ctx     context.Context
+	service *Service
+
+	mu    sync.RWMutex
+	jobs  map[string]*Job
+	order []string
+}
+
+// NewJobManager creates a job manager. Running jobs are cancelled when ctx is done.
+func NewJobManager(ctx context.Context, service *Service) *JobManager {
+	return &JobManager{
+		ctx:     ctx,
+		service: service,
+		jobs:    map[string]*Job{},
+	}
+}
+
+// Start begins importing from a path or URL (see Service.Import) and returns the new job
+func (m *JobManager) Start(source string, opts Options) Job {
+	job := m.newJob(source, opts)
+	go m.run(job, opts, func(ctx context.Context, opts Options) (*Report, error) {
+		return m.service.Import(ctx, source, opts)
+	})
+	return m.snapshot(job)
+}
+
+// StartReader begins importing from a stream, closing it when the job finishes.
+// name identifies the source in the job, e.g. an uploaded file name.
+func (m *JobManager) StartReader(name string, r io.ReadCloser, opts Options) Job {
+	job := m.newJob(name, opts)
+	go m.run(job, opts, func(ctx context.Context, opts Options) (*Report, error) {
+		defer r.Close()
+		return m.service.ImportReader(ctx, r, opts)
+	})
+	return m.snapshot(job)
+}
+
+// Get returns a snapshot of a job, and whether it exists
+func (m *JobManager) Get(id string) (Job, bool) {
+	m.mu.RLock()
+	job, ok := m.jobs[id]
+	m.mu.RUnlock()
+	if !ok {
+		return Job{}, false
+	}
+	return m.snapshot(job), true
+}
+
+func (m *JobManager) newJob(source string, opts Options) *Job {
+	job := &Job{
+		ID:        newJobID(),
+		Source:    source,
+		State:     JobRunning,
+		StartedAt: time.Now(),
+		Report:    newReport(opts.DryRun),
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs[job.ID] = job
+	m.order = append(m.order, job.ID)
+	m.pruneLocked()
+	return job
+}
+
+// pruneLocked drops the oldest finished jobs beyond maxRetainedJobs
+func (m *JobManager) pruneLocked() {
+	excess := len(m.order) - maxRetainedJobs
+	kept := m.order[:0]
+	for _, id := range m.order {
+		if excess > 0 && m.jobs[id].State != JobRunning {
+			delete(m.jobs, id)
+			excess--
+			continue
+		}
+		kept = append(kept, id)
+	}
+	m.order = kept
+}
+
+func (m *JobManager) run(job *Job, opts Options, importFn func(context.Context, Options) (*Report, error)) {
+	opts.progress = func(result outcome, entry ReportEntry) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		job.Report.record(result, entry)
+		job.Progress.Processed++
+		switch result {
+		case outcomeCreated:
+			job.Progress.Created++
+		case outcomeOverwritten:
+			job.Progress.Overwritten++
+		case outcomeSkipped:
+			job.Progress.Skipped++
+		case outcomeFailed:
+			job.Progress.Failed++
+		}
+	}
+
+	_, err := importFn(m.ctx, opts)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	job.FinishedAt = &now
+	switch {
+	case err != nil:
+		job.State = JobFailed
+		job.Error = err.Error()
+	case job.Progress.Failed > 0:
+		job.State = JobFailed
+		job.Error = "some entries failed to import"
+	default:
+		job.State = JobSucceeded
+	}
+	log.Printf("Import job %s from %s %s: %d created, %d overwritten, %d skipped, %d failed",
+		job.ID, job.Source, job.State, job.Progress.Created, job.Progress.Overwritten, job.Progress.Skipped, job.Progress.Failed)
+}
+
+// snapshot copies a job so it can be read while the import continues
+func (m *JobManager) snapshot(job *Job) Job {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	copied := *job
+	copied.Report = &Report{
+		DryRun:      job.Report.DryRun,
+		Created:     append([]ReportEntry{}, job.Report.Created...),
+		Overwritten: append([]ReportEntry{}, job.Report.Overwritten...),
+		Skipped:     append([]ReportEntry{}, job.Report.Skipped...),
+		Failed:      append([]ReportEntry{}, job.Report.Failed...),
+	}
+	return copied
+}
+
+func newJobID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().UTC().Format("20060102T150405.000000000")
+	}
+	return hex.EncodeToString(b)
+}
+
+// removeOnClose deletes a temporary file once it has been read and closed
+type removeOnClose struct {
+	*os.File
+}
+
+func (f removeOnClose) Close() error {
+	err := f.File.Close()
+	if removeErr := os.Remove(f.Name()); err == nil {
+		err = removeErr
+	}
+	return err
+}
+
+// SpoolUpload copies an upload to a temporary file, so it outlives the request that carried it.
+// The returned reader deletes the file when closed.
+func SpoolUpload(r io.Reader) (io.ReadCloser, error) {
+	file, err := os.CreateTemp("", "registry-import-*")
+	if err != nil {
+		return nil, err
+	}
+	spooled := removeOnClose{file}
+	if _, err := io.Copy(file, r); err != nil {
+		spooled.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		spooled.Close()
+		return nil, err
+	}
+	return spooled, nil
+}
@@ -0,0 +1,84 @@
+package importer_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func waitForJob(t *testing.T, jobs *importer.JobManager, id string) importer.Job {
+	t.Helper()
+	var job importer.Job
+	require.Eventually(t, func() bool {
+		var ok bool
+		job, ok = jobs.Get(id)
+		return ok && job.State != importer.JobRunning
+	}, 5*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestJobManager(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful import", func(t *testing.T) {
+		registry := newMemoryRegistryService()
+		jobs := importer.NewJobManager(ctx, importer.NewService(registry))
+
+		started := jobs.StartReader("seed.json", io.NopCloser(strings.NewReader("["+alphaJSON+","+betaJSON+"]")), importer.Options{})
+		assert.NotEmpty(t, started.ID)
+		assert.Equal(t, "seed.json", started.Source)
+
+		job := waitForJob(t, jobs, started.ID)
+		assert.Equal(t, importer.JobSucceeded, job.State)
+		assert.Empty(t, job.Error)
+		require.NotNil(t, job.FinishedAt)
+		assert.Equal(t, importer.JobProgress{Processed: 2, Created: 2}, job.Progress)
+		assert.Len(t, job.Report.Created, 2)
+		assert.Len(t, registry.servers, 2)
+	})
+
+	t.Run("failed entries fail the job", func(t *testing.T) {
+		jobs := importer.NewJobManager(ctx, importer.NewService(newMemoryRegistryService()))
+
+		started := jobs.StartReader("seed.json", io.NopCloser(strings.NewReader(`[{"name":"invalid name","description":"x","version":"1.0.0"},`+betaJSON+`]`)), importer.Options{})
+
+		job := waitForJob(t, jobs, started.ID)
+		assert.Equal(t, importer.JobFailed, job.State)
+		assert.Equal(t, 1, job.Progress.Created)
+		assert.Equal(t, 1, job.Progress.Failed)
+		require.Len(t, job.Report.Failed, 1)
+		assert.Equal(t, "invalid name", job.Report.Failed[0].Name)
+	})
+
+	t.Run("unreadable source fails the job", func(t *testing.T) {
+		jobs := importer.NewJobManager(ctx, importer.NewService(newMemoryRegistryService()))
+
+		started := jobs.Start("/nonexistent/seed.json", importer.Options{})
+
+		job := waitForJob(t, jobs, started.ID)
+		assert.Equal(t, importer.JobFailed, job.State)
+		assert.Contains(t, job.Error, "failed to read seed data")
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		jobs := importer.NewJobManager(ctx, importer.NewService(newMemoryRegistryService()))
+		_, ok := jobs.Get("missing")
+		assert.False(t, ok)
+	})
+}
+
+func TestSpoolUpload(t *testing.T) {
+	spooled, err := importer.SpoolUpload(strings.NewReader("uploaded"))
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(spooled)
+	require.NoError(t, err)
+	assert.Equal(t, "uploaded", string(data))
+	require.NoError(t, spooled.Close())
+}
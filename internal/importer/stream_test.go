@@ -0,0 +1,224 @@
+package importer_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/validators"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRegistryService stores imported versions in memory, recording how each was written
+type memoryRegistryService struct {
+	service.RegistryService
+	servers  map[string]*apiv0.ServerResponse
+	imported map[string]*apiv0.RegistryExtensions
+}
+
+func newMemoryRegistryService() *memoryRegistryService {
+	return &memoryRegistryService{
+		servers:  map[string]*apiv0.ServerResponse{},
+		imported: map[string]*apiv0.RegistryExtensions{},
+	}
+}
+
+func (m *memoryRegistryService) GetServerByNameAndVersion(_ context.Context, name, version string) (*apiv0.ServerResponse, error) {
+	if server, ok := m.servers[name+"@"+version]; ok {
+		return server, nil
+	}
+	return nil, database.ErrNotFound
+}
+
+func (m *memoryRegistryService) CreateServer(_ context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+	server := &apiv0.ServerResponse{Server: *req}
+	m.servers[req.Name+"@"+req.Version] = server
+	return server, nil
+}
+
+func (m *memoryRegistryService) ImportServer(_ context.Context, req *apiv0.ServerJSON, officialMeta *apiv0.RegistryExtensions) (*apiv0.ServerResponse, error) {
+	server := &apiv0.ServerResponse{Server: *req, Meta: apiv0.ResponseMeta{Official: officialMeta}}
+	m.servers[req.Name+"@"+req.Version] = server
+	m.imported[req.Name+"@"+req.Version] = officialMeta
+	return server, nil
+}
+
+func (m *memoryRegistryService) CheckPublish(ctx context.Context, req *apiv0.ServerJSON) ([]apiv0.PublishCheck, error) {
+	return validators.CheckPublishRequest(ctx, *req, validators.Options{}), nil
+}
+
+const (
+	alphaJSON = `{"$schema":"https://static.modelcontextprotocol.io/schemas/2025-10-17/server.schema.json","name":"com.example/alpha","description":"Alpha","version":"1.0.0"}`
+	betaJSON  = `{"$schema":"https://static.modelcontextprotocol.io/schemas/2025-10-17/server.schema.json","name":"com.example/beta","description":"Beta","version":"2.0.0"}`
+)
+
+func gzipped(t *testing.T, data string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, err := writer.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	return buf.String()
+}
+
+func TestImportReader_Formats(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"JSON array", "[" + alphaJSON + "," + betaJSON + "]"},
+		{"NDJSON", alphaJSON + "\n" + betaJSON + "\n"},
+		{"registry list response", `{"servers":[{"server":` + alphaJSON + `},{"server":` + betaJSON + `}],"metadata":{"count":2}}`},
+	}
+
+	for _, tt := range tests {
+		for _, compressed := range []bool{false, true} {
+			input := tt.input
+			name := tt.name
+			if compressed {
+				input = gzipped(t, input)
+				name += " (gzip)"
+			}
+
+			t.Run(name, func(t *testing.T) {
+				registry := newMemoryRegistryService()
+				report, err := importer.NewService(registry).ImportReader(context.Background(), strings.NewReader(input), importer.Options{})
+				require.NoError(t, err)
+
+				assert.Equal(t, []importer.ReportEntry{
+					{Name: "com.example/alpha", Version: "1.0.0"},
+					{Name: "com.example/beta", Version: "2.0.0"},
+				}, report.Created)
+				assert.Empty(t, report.Failed)
+				assert.Len(t, registry.servers, 2)
+			})
+		}
+	}
+
+	t.Run("empty input", func(t *testing.T) {
+		report, err := importer.NewService(newMemoryRegistryService()).ImportReader(context.Background(), strings.NewReader("  \n"), importer.Options{})
+		require.NoError(t, err)
+		assert.Empty(t, report.Created)
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		_, err := importer.NewService(newMemoryRegistryService()).ImportReader(context.Background(), strings.NewReader("[{invalid"), importer.Options{})
+		assert.ErrorContains(t, err, "failed to read seed data")
+	})
+}
+
+func TestImportReader_ExistingPolicies(t *testing.T) {
+	input := "[" + alphaJSON + "," + betaJSON + "]"
+
+	tests := []struct {
+		policy      importer.ExistingPolicy
+		created     int
+		overwritten int
+		skipped     int
+		failed      int
+	}{
+		{importer.ExistingSkip, 1, 0, 1, 0},
+		{importer.ExistingOverwrite, 1, 1, 0, 0},
+		{importer.ExistingFail, 1, 0, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			registry := newMemoryRegistryService()
+			_, err := registry.CreateServer(context.Background(), &apiv0.ServerJSON{Name: "com.example/alpha", Version: "1.0.0"})
+			require.NoError(t, err)
+
+			report, err := importer.NewService(registry).ImportReader(context.Background(), strings.NewReader(input), importer.Options{Existing: tt.policy})
+			require.NoError(t, err)
+
+			assert.Len(t, report.Created, tt.created)
+			assert.Len(t, report.Overwritten, tt.overwritten)
+			assert.Len(t, report.Skipped, tt.skipped)
+			assert.Len(t, report.Failed, tt.failed)
+			if tt.failed > 0 {
+				assert.Equal(t, "version already exists", report.Failed[0].Reason)
+			}
+		})
+	}
+}
+
+func TestImportReader_DryRun(t *testing.T) {
+	// Publisher-provided metadata over 4KB passes server.json validation but fails the publish checks
+	oversized := `{"$schema":"https://static.modelcontextprotocol.io/schemas/2025-10-17/server.schema.json","name":"com.example/oversized",` +
+		`"description":"Oversized","version":"1.0.0","_meta":{"io.modelcontextprotocol.registry/publisher-provided":{"blob":"` + strings.Repeat("x", 5000) + `"}}}`
+
+	registry := newMemoryRegistryService()
+	report, err := importer.NewService(registry).ImportReader(context.Background(),
+		strings.NewReader("["+alphaJSON+","+oversized+"]"), importer.Options{DryRun: true})
+	require.NoError(t, err)
+
+	assert.True(t, report.DryRun)
+	require.Len(t, report.Created, 1)
+	assert.Equal(t, "com.example/alpha", report.Created[0].Name)
+	require.Len(t, report.Failed, 1)
+	assert.Equal(t, "com.example/oversized", report.Failed[0].Name)
+	assert.Contains(t, report.Failed[0].Reason, "publisher-extensions")
+	assert.Empty(t, registry.servers)
+}
+
+func TestImportReader_PreserveTimestamps(t *testing.T) {
+	publishedAt := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	input := `{"server":` + alphaJSON + `,"_meta":{"io.modelcontextprotocol.registry/official":{"status":"deprecated","publishedAt":"2025-09-01T12:00:00Z","updatedAt":"2025-09-02T12:00:00Z","isLatest":true}}}`
+
+	t.Run("preserved", func(t *testing.T) {
+		registry := newMemoryRegistryService()
+		_, err := importer.NewService(registry).ImportReader(context.Background(), strings.NewReader(input), importer.Options{PreserveTimestamps: true})
+		require.NoError(t, err)
+
+		meta := registry.imported["com.example/alpha@1.0.0"]
+		require.NotNil(t, meta)
+		assert.True(t, publishedAt.Equal(meta.PublishedAt))
+		assert.Equal(t, "deprecated", string(meta.Status))
+	})
+
+	t.Run("missing timestamps fall back to the import time", func(t *testing.T) {
+		registry := newMemoryRegistryService()
+		partial := `{"server":` + alphaJSON + `,"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active","publishedAt":"2025-09-01T12:00:00Z","isLatest":true}}}`
+		before := time.Now()
+		_, err := importer.NewService(registry).ImportReader(context.Background(), strings.NewReader(partial), importer.Options{PreserveTimestamps: true})
+		require.NoError(t, err)
+
+		meta := registry.imported["com.example/alpha@1.0.0"]
+		require.NotNil(t, meta)
+		assert.True(t, publishedAt.Equal(meta.PublishedAt))
+		assert.False(t, meta.UpdatedAt.Before(before), "updatedAt is the import time")
+	})
+
+	t.Run("treated as a new publish by default", func(t *testing.T) {
+		registry := newMemoryRegistryService()
+		_, err := importer.NewService(registry).ImportReader(context.Background(), strings.NewReader(input), importer.Options{})
+		require.NoError(t, err)
+
+		assert.Contains(t, registry.servers, "com.example/alpha@1.0.0")
+		assert.NotContains(t, registry.imported, "com.example/alpha@1.0.0")
+	})
+}
+
+func TestImportReader_InvalidEntries(t *testing.T) {
+	input := `[{"name":"com.example/broken","version":"1.0.0","description":42},{"name":"invalid name","description":"x","version":"1.0.0"},` + betaJSON + `]`
+
+	registry := newMemoryRegistryService()
+	report, err := importer.NewService(registry).ImportReader(context.Background(), strings.NewReader(input), importer.Options{})
+	require.NoError(t, err)
+
+	require.Len(t, report.Failed, 2)
+	assert.Equal(t, "com.example/broken", report.Failed[0].Name)
+	assert.Contains(t, report.Failed[0].Reason, "invalid entry")
+	assert.Equal(t, "invalid name", report.Failed[1].Name)
+	assert.NotEmpty(t, report.Failed[1].Reason)
+	assert.Len(t, report.Created, 1)
+}
@@ -0,0 +1,348 @@
+// Package mirror keeps a downstream registry in sync with an upstream registry
+package mirror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/schema"
+	"github.com/modelcontextprotocol/registry/pkg/validators"
+)
+
+// pageSize is the number of servers requested per upstream page (the API maximum)
+const pageSize = 100
+
+// Status describes the state of the syncer, as reported by the sync status endpoint
+type Status struct {
+	Upstream      string     `json:"upstream" doc:"Upstream registry URL"`
+	Running       bool       `json:"running" doc:"Whether a sync run is in progress"`
+	HighWaterMark *time.Time `json:"highWaterMark,omitempty" doc:"Latest upstream updatedAt applied, used as the next updated_since"`
+	LastRunAt     *time.Time `json:"lastRunAt,omitempty" doc:"When the last sync run finished"`
+	LastSuccessAt *time.Time `json:"lastSuccessAt,omitempty" doc:"When the last successful sync run finished"`
+	LastError     string     `json:"lastError,omitempty" doc:"Error from the last sync run, if it failed"`
+	LastApplied   int        `json:"lastApplied" doc:"Server versions applied by the last sync run"`
+	LastSkipped   int        `json:"lastSkipped" doc:"Server versions skipped by the last sync run because the local copy was as recent"`
+	LastInvalid   int        `json:"lastInvalid" doc:"Server versions skipped by the last sync run because they failed validation"`
+}
+
+// Result summarises a single sync run
+type Result struct {
+	Applied int
+	Skipped int
+	// Invalid counts versions that failed validation, even after upgrading earlier schema versions.
+	// The high-water mark still advances past them, so they are logged and reported rather than retried.
+	Invalid       int
+	HighWaterMark time.Time
+}
+
+// outcome is what applying a single upstream server version did
+type outcome int
+
+const (
+	outcomeApplied outcome = iota
+	outcomeSkipped
+	outcomeInvalid
+)
+
+// upstreamServer is a server version from an upstream list response. The server.json is kept raw,
+// so that versions written against an earlier schema can be upgraded before they are validated.
+type upstreamServer struct {
+	Server json.RawMessage    `json:"server"`
+	Meta   apiv0.ResponseMeta `json:"_meta"`
+}
+
+// upstreamPage is a page of an upstream list response
+type upstreamPage struct {
+	Servers  []upstreamServer `json:"servers"`
+	Metadata apiv0.Metadata   `json:"metadata"`
+}
+
+// Syncer polls an upstream registry for servers updated since its high-water mark and applies them locally
+type Syncer struct {
+	db       database.Database
+	upstream string
+	interval time.Duration
+	client   *http.Client
+	metrics  *telemetry.Metrics
+
+	mu     sync.RWMutex
+	status Status
+}
+
+// NewSyncer creates a syncer for the upstream registry base URL (e.g. https://registry.modelcontextprotocol.io).
+// The interval must be positive. metrics may be nil.
+func NewSyncer(db database.Database, upstreamURL string, interval time.Duration, metrics *telemetry.Metrics) (*Syncer, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("sync interval must be positive, got %s", interval)
+	}
+
+	upstreamURL = strings.TrimSuffix(upstreamURL, "/")
+	return &Syncer{
+		db:       db,
+		upstream: upstreamURL,
+		interval: interval,
+		client:   &http.Client{Timeout: 30 * time.Second},
+		metrics:  metrics,
+		status:   Status{Upstream: upstreamURL},
+	}, nil
+}
+
+// Status returns a snapshot of the syncer's state
+func (s *Syncer) Status() Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.status
+}
+
+// Run syncs immediately and then every interval until the context is cancelled
+func (s *Syncer) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if result, err := s.SyncOnce(ctx); err != nil {
+			log.Printf("Sync from %s failed: %v", s.upstream, err)
+		} else if result.Applied > 0 {
+			log.Printf("Synced %d server versions from %s", result.Applied, s.upstream)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SyncOnce fetches every server updated upstream since the high-water mark and applies it.
+// The high-water mark only advances once every page has been applied, so a failed run is retried in full.
+func (s *Syncer) SyncOnce(ctx context.Context) (*Result, error) {
+	s.mu.Lock()
+	s.status.Running = true
+	s.mu.Unlock()
+
+	result, err := s.sync(ctx)
+	s.recordRun(ctx, result, err)
+
+	return result, err
+}
+
+func (s *Syncer) sync(ctx context.Context) (*Result, error) {
+	mark, err := s.db.GetSyncHighWaterMark(ctx, nil, s.upstream)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return nil, err
+	}
+
+	result := &Result{HighWaterMark: mark}
+	cursor := ""
+	for {
+		page, err := s.fetchPage(ctx, mark, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range page.Servers {
+			server := &page.Servers[i]
+			applied, err := s.apply(ctx, server)
+			if err != nil {
+				return nil, err
+			}
+			switch applied {
+			case outcomeApplied:
+				result.Applied++
+			case outcomeSkipped:
+				result.Skipped++
+			case outcomeInvalid:
+				result.Invalid++
+			}
+			// Skipped and invalid versions still advance the mark so they are not fetched again on every run
+			if server.Meta.Official != nil && server.Meta.Official.UpdatedAt.After(result.HighWaterMark) {
+				result.HighWaterMark = server.Meta.Official.UpdatedAt
+			}
+		}
+
+		if page.Metadata.NextCursor == "" {
+			break
+		}
+		cursor = page.Metadata.NextCursor
+	}
+
+	if result.HighWaterMark.After(mark) {
+		if err := s.db.SetSyncHighWaterMark(ctx, nil, s.upstream, result.HighWaterMark); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// fetchPage fetches one page of servers updated upstream after the high-water mark
+func (s *Syncer) fetchPage(ctx context.Context, since time.Time, cursor string) (*upstreamPage, error) {
+	query := url.Values{}
+	query.Set("limit", fmt.Sprintf("%d", pageSize))
+	if !since.IsZero() {
+		query.Set("updated_since", since.UTC().Format(time.RFC3339Nano))
+	}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.upstream+"/v0/servers?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upstream request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch servers from upstream: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("upstream returned status %d", resp.StatusCode)
+	}
+
+	var page upstreamPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to parse upstream servers: %w", err)
+	}
+
+	return &page, nil
+}
+
+// apply stores an upstream server version unless the local copy is already at least as recent,
+// upgrading server.json written against an earlier schema version first
+func (s *Syncer) apply(ctx context.Context, upstream *upstreamServer) (outcome, error) {
+	server, originalSchemaVersion, err := upgradeServerJSON(upstream.Server)
+	if err == nil {
+		err = validators.ValidateServerJSON(server)
+	}
+	if err != nil {
+		log.Printf("Warning: skipping invalid upstream server %s %s: %v", server.Name, server.Version, err)
+		return outcomeInvalid, nil
+	}
+
+	official := upstream.Meta.Official
+	if official == nil {
+		log.Printf("Warning: skipping upstream server %s %s without registry metadata", server.Name, server.Version)
+		return outcomeSkipped, nil
+	}
+	if originalSchemaVersion != "" && official.OriginalSchemaVersion == "" {
+		official.OriginalSchemaVersion = originalSchemaVersion
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (outcome, error) {
+		if err := s.db.AcquirePublishLock(ctx, tx, server.Name); err != nil {
+			return outcomeSkipped, err
+		}
+
+		existing, err := s.db.GetServerByNameAndVersion(ctx, tx, server.Name, server.Version)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return outcomeSkipped, err
+		}
+		if existing != nil && existing.Meta.Official != nil && !official.UpdatedAt.After(existing.Meta.Official.UpdatedAt) {
+			return outcomeSkipped, nil
+		}
+
+		if official.IsLatest {
+			if err := s.db.UnmarkAsLatest(ctx, tx, server.Name); err != nil {
+				return outcomeSkipped, err
+			}
+		}
+
+		if _, err := s.db.UpsertServer(ctx, tx, server, official); err != nil {
+			return outcomeSkipped, err
+		}
+
+		// List responses omit capabilities, so existing ones are only replaced when upstream sends them
+		if upstream.Meta.Capabilities != nil {
+			if err := s.db.SetServerCapabilities(ctx, tx, server.Name, server.Version, upstream.Meta.Capabilities); err != nil {
+				return outcomeSkipped, err
+			}
+		}
+		if err := s.db.SetServerOCIImages(ctx, tx, server.Name, server.Version, upstream.Meta.OCIImages); err != nil {
+			return outcomeSkipped, err
+		}
+
+		return outcomeApplied, nil
+	})
+}
+
+// recordRun updates the status and metrics after a sync run
+func (s *Syncer) recordRun(ctx context.Context, result *Result, err error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	s.status.Running = false
+	s.status.LastRunAt = &now
+	if err != nil {
+		s.status.LastError = err.Error()
+	} else {
+		s.status.LastError = ""
+		s.status.LastSuccessAt = &now
+		s.status.LastApplied = result.Applied
+		s.status.LastSkipped = result.Skipped
+		s.status.LastInvalid = result.Invalid
+		if !result.HighWaterMark.IsZero() {
+			mark := result.HighWaterMark
+			s.status.HighWaterMark = &mark
+		}
+	}
+	s.mu.Unlock()
+
+	if s.metrics == nil {
+		return
+	}
+
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+	}
+	upstream := attribute.String("upstream", s.upstream)
+	s.metrics.SyncRuns.Add(ctx, 1, metric.WithAttributes(upstream, attribute.String("result", outcome)))
+	if err == nil {
+		s.metrics.SyncServersApplied.Add(ctx, int64(result.Applied), metric.WithAttributes(upstream))
+		s.metrics.SyncServersInvalid.Add(ctx, int64(result.Invalid), metric.WithAttributes(upstream))
+		if !result.HighWaterMark.IsZero() {
+			s.metrics.SyncHighWaterMark.Record(ctx, result.HighWaterMark.Unix(), metric.WithAttributes(upstream))
+		}
+	}
+}
+
+// upgradeServerJSON decodes an upstream server.json, upgrading it first if it was written against an
+// earlier schema version. It returns the version it was upgraded from, or "" if it was already current.
+func upgradeServerJSON(data json.RawMessage) (*apiv0.ServerJSON, string, error) {
+	server := &apiv0.ServerJSON{}
+	if err := json.Unmarshal(data, server); err != nil {
+		return server, "", err
+	}
+	if !schema.NeedsUpgrade(server.Schema) {
+		return server, "", nil
+	}
+
+	upgraded, version, err := schema.Upgrade(data)
+	if err != nil {
+		return server, "", err
+	}
+	server = &apiv0.ServerJSON{}
+	if err := json.Unmarshal(upgraded, server); err != nil {
+		return server, "", err
+	}
+	return server, version, nil
+}
@@ -0,0 +1,185 @@
+package mirror_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/mirror"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newUpstreamRegistry serves the read API of a second in-process registry
+func newUpstreamRegistry(t *testing.T) (service.RegistryService, *httptest.Server) {
+	t.Helper()
+
+	cfg := &config.Config{EnableRegistryValidation: false}
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Upstream Registry", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, cfg)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return registryService, server
+}
+
+func testServer(name, version string) *apiv0.ServerJSON {
+	return &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        name,
+		Description: "Upstream test server",
+		Version:     version,
+	}
+}
+
+func TestNewSyncer_RejectsNonPositiveInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Minute} {
+		_, err := mirror.NewSyncer(nil, "https://registry.example.com", interval, nil)
+		assert.ErrorContains(t, err, "sync interval must be positive", "interval %s", interval)
+	}
+}
+
+func TestSyncer_SyncOnce(t *testing.T) {
+	ctx := context.Background()
+	upstream, upstreamServer := newUpstreamRegistry(t)
+	downstreamDB := database.NewTestDB(t)
+	syncer, err := mirror.NewSyncer(downstreamDB, upstreamServer.URL+"/", time.Minute, nil)
+	require.NoError(t, err)
+
+	_, err = upstream.CreateServer(ctx, testServer("com.example/alpha", "1.0.0"))
+	require.NoError(t, err)
+	_, err = upstream.CreateServer(ctx, testServer("com.example/beta", "1.0.0"))
+	require.NoError(t, err)
+
+	t.Run("initial sync copies every server with upstream timestamps", func(t *testing.T) {
+		result, err := syncer.SyncOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Applied)
+
+		upstreamAlpha, err := upstream.GetServerByName(ctx, "com.example/alpha")
+		require.NoError(t, err)
+		downstreamAlpha, err := downstreamDB.GetServerByName(ctx, nil, "com.example/alpha")
+		require.NoError(t, err)
+		assert.True(t, upstreamAlpha.Meta.Official.PublishedAt.Equal(downstreamAlpha.Meta.Official.PublishedAt))
+		assert.True(t, upstreamAlpha.Meta.Official.UpdatedAt.Equal(downstreamAlpha.Meta.Official.UpdatedAt))
+
+		mark, err := downstreamDB.GetSyncHighWaterMark(ctx, nil, upstreamServer.URL)
+		require.NoError(t, err)
+		assert.True(t, mark.Equal(result.HighWaterMark))
+	})
+
+	t.Run("repeat sync is a no-op", func(t *testing.T) {
+		result, err := syncer.SyncOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Applied)
+	})
+
+	t.Run("new versions and status changes are applied", func(t *testing.T) {
+		_, err := upstream.CreateServer(ctx, testServer("com.example/alpha", "1.1.0"))
+		require.NoError(t, err)
+		deprecated := string(model.StatusDeprecated)
+		_, err = upstream.UpdateServer(ctx, "com.example/beta", "1.0.0", testServer("com.example/beta", "1.0.0"), &deprecated, "")
+		require.NoError(t, err)
+
+		result, err := syncer.SyncOnce(ctx)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, result.Applied, 2)
+
+		latest, err := downstreamDB.GetServerByName(ctx, nil, "com.example/alpha")
+		require.NoError(t, err)
+		assert.Equal(t, "1.1.0", latest.Server.Version)
+
+		versions, err := downstreamDB.GetAllVersionsByServerName(ctx, nil, "com.example/alpha")
+		require.NoError(t, err)
+		assert.Len(t, versions, 2)
+
+		beta, err := downstreamDB.GetServerByNameAndVersion(ctx, nil, "com.example/beta", "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusDeprecated, beta.Meta.Official.Status)
+	})
+
+	t.Run("status reflects the last run", func(t *testing.T) {
+		status := syncer.Status()
+		assert.Equal(t, upstreamServer.URL, status.Upstream)
+		assert.False(t, status.Running)
+		assert.Empty(t, status.LastError)
+		require.NotNil(t, status.LastSuccessAt)
+		require.NotNil(t, status.HighWaterMark)
+	})
+}
+
+func TestSyncer_UpstreamFailure(t *testing.T) {
+	ctx := context.Background()
+	downstreamDB := database.NewTestDB(t)
+
+	upstreamServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer upstreamServer.Close()
+
+	syncer, err := mirror.NewSyncer(downstreamDB, upstreamServer.URL, time.Minute, nil)
+	require.NoError(t, err)
+	_, err = syncer.SyncOnce(ctx)
+	require.Error(t, err)
+
+	status := syncer.Status()
+	assert.Contains(t, status.LastError, "status 502")
+	assert.Nil(t, status.LastSuccessAt)
+
+	_, err = downstreamDB.GetSyncHighWaterMark(ctx, nil, upstreamServer.URL)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func TestSyncer_UpgradesEarlierSchemaVersions(t *testing.T) {
+	ctx := context.Background()
+	downstreamDB := database.NewTestDB(t)
+
+	updatedAt := time.Now().UTC().Truncate(time.Second).Format(time.RFC3339)
+	official := `{"io.modelcontextprotocol.registry/official": {"status": "active", "publishedAt": "` + updatedAt + `", "updatedAt": "` + updatedAt + `", "isLatest": true}}`
+	upstreamServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"servers": [
+			{"server": {
+				"$schema": "https://static.modelcontextprotocol.io/schemas/2025-07-09/server.schema.json",
+				"name": "com.example/legacy",
+				"description": "Published against an earlier schema",
+				"version": "1.0.0",
+				"website_url": "https://example.com"
+			}, "_meta": ` + official + `},
+			{"server": {
+				"$schema": "` + model.CurrentSchemaURL + `",
+				"name": "com.example/invalid",
+				"description": "Not a valid version",
+				"version": "latest"
+			}, "_meta": ` + official + `}
+		], "metadata": {"count": 2}}`))
+	}))
+	defer upstreamServer.Close()
+
+	syncer, err := mirror.NewSyncer(downstreamDB, upstreamServer.URL, time.Minute, nil)
+	require.NoError(t, err)
+	result, err := syncer.SyncOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Applied)
+	assert.Equal(t, 1, result.Invalid)
+	assert.Equal(t, 1, syncer.Status().LastInvalid)
+
+	legacy, err := downstreamDB.GetServerByNameAndVersion(ctx, nil, "com.example/legacy", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, model.CurrentSchemaURL, legacy.Server.Schema)
+	assert.Equal(t, "https://example.com", legacy.Server.WebsiteURL)
+	assert.Equal(t, "2025-07-09", legacy.Meta.Official.OriginalSchemaVersion)
+}
@@ -4,18 +4,32 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/capabilities"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
-	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validators"
 )
 
 const maxServerVersionsPerServer = 10000
 
+// capabilityDiscoveryTimeout bounds the time spent discovering the capabilities of a server's remotes on publish
+const capabilityDiscoveryTimeout = 30 * time.Second
+
+// validatorOptions converts the registry configuration to the options publish validation takes
+func validatorOptions(cfg *config.Config) validators.Options {
+	return validators.Options{
+		EnableRegistryValidation: cfg.EnableRegistryValidation,
+		OCIRequireDigest:         cfg.OCIRequireDigest,
+		UpstreamRegistries:       cfg.UpstreamRegistries.Validators(),
+	}
+}
+
 // registryServiceImpl implements the RegistryService interface using our Database
 type registryServiceImpl struct {
 	db  database.Database
@@ -78,19 +92,33 @@ func (s *registryServiceImpl) GetAllVersionsByServerName(ctx context.Context, se
 
 // CreateServer creates a new server version
 func (s *registryServiceImpl) CreateServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
-	// Wrap the entire operation in a transaction
-	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
-		return s.createServerInTransaction(ctx, tx, req)
-	})
+	return s.createServer(ctx, req, "")
 }
 
-// createServerInTransaction contains the actual CreateServer logic within a transaction
-func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx pgx.Tx, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
-	// Validate the request
-	if err := validators.ValidatePublishRequest(ctx, *req, s.cfg); err != nil {
+// CreateUpgradedServer creates a new server version from a server.json upgraded from an earlier schema version
+func (s *registryServiceImpl) CreateUpgradedServer(ctx context.Context, req *apiv0.ServerJSON, originalSchemaVersion string) (*apiv0.ServerResponse, error) {
+	return s.createServer(ctx, req, originalSchemaVersion)
+}
+
+// createServer validates the request and works out its capabilities, then creates the server version in a transaction.
+// Validation and discovery talk to package registries and remotes, so they run before the transaction is opened.
+func (s *registryServiceImpl) createServer(ctx context.Context, req *apiv0.ServerJSON, originalSchemaVersion string) (*apiv0.ServerResponse, error) {
+	// Validate the request, keeping the digests and platforms its OCI packages resolved to
+	ociImages, err := validators.ResolvePublishRequest(ctx, *req, validatorOptions(s.cfg))
+	if err != nil {
 		return nil, err
 	}
 
+	serverCapabilities := s.resolveCapabilities(ctx, *req)
+
+	// Wrap the database operations in a transaction
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
+		return s.createServerInTransaction(ctx, tx, req, originalSchemaVersion, ociImages, serverCapabilities)
+	})
+}
+
+// createServerInTransaction contains the actual CreateServer logic within a transaction
+func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx pgx.Tx, req *apiv0.ServerJSON, originalSchemaVersion string, ociImages []apiv0.OCIImage, serverCapabilities *apiv0.Capabilities) (*apiv0.ServerResponse, error) {
 	publishTime := time.Now()
 	serverJSON := *req
 
@@ -152,14 +180,89 @@ func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx
 
 	// Create metadata for the new server
 	officialMeta := &apiv0.RegistryExtensions{
-		Status:      model.StatusActive, /* New versions are active by default */
-		PublishedAt: publishTime,
-		UpdatedAt:   publishTime,
-		IsLatest:    isNewLatest,
+		Status:                model.StatusActive, /* New versions are active by default */
+		PublishedAt:           publishTime,
+		UpdatedAt:             publishTime,
+		IsLatest:              isNewLatest,
+		OriginalSchemaVersion: originalSchemaVersion,
 	}
 
 	// Insert new server version
-	return s.db.CreateServer(ctx, tx, &serverJSON, officialMeta)
+	serverResponse, err := s.db.CreateServer(ctx, tx, &serverJSON, officialMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	if serverCapabilities != nil {
+		if err := s.db.SetServerCapabilities(ctx, tx, serverJSON.Name, serverJSON.Version, serverCapabilities); err != nil {
+			return nil, err
+		}
+		serverResponse.Meta.Capabilities = serverCapabilities
+	}
+
+	if len(ociImages) > 0 {
+		if err := s.db.SetServerOCIImages(ctx, tx, serverJSON.Name, serverJSON.Version, ociImages); err != nil {
+			return nil, err
+		}
+		serverResponse.Meta.OCIImages = ociImages
+	}
+
+	return serverResponse, nil
+}
+
+// resolveCapabilities discovers the capabilities of the server's remotes when enabled,
+// falling back to the capabilities declared in server.json
+func (s *registryServiceImpl) resolveCapabilities(ctx context.Context, serverJSON apiv0.ServerJSON) *apiv0.Capabilities {
+	if s.cfg.EnableCapabilityDiscovery {
+		// One budget for every remote, so a slow server cannot hold up the publish for long
+		ctx, cancel := context.WithTimeout(ctx, capabilityDiscoveryTimeout)
+		defer cancel()
+		for _, remote := range serverJSON.Remotes {
+			discovered, err := capabilities.Discover(ctx, remote)
+			if err != nil {
+				log.Printf("Capability discovery failed for %s %s (%s): %v", serverJSON.Name, serverJSON.Version, remote.URL, err)
+				continue
+			}
+			return discovered
+		}
+	}
+
+	if serverJSON.Meta != nil && serverJSON.Meta.Capabilities != nil {
+		declared := *serverJSON.Meta.Capabilities
+		declared.Source = apiv0.CapabilitiesSourceDeclared
+		return &declared
+	}
+
+	return nil
+}
+
+// CheckPublish runs the same checks as CreateServer, reporting the outcome of each instead of stopping at the first failure
+func (s *registryServiceImpl) CheckPublish(ctx context.Context, req *apiv0.ServerJSON) ([]apiv0.PublishCheck, error) {
+	checks := validators.CheckPublishRequest(ctx, *req, validatorOptions(s.cfg))
+
+	checks = append(checks, validators.NewPublishCheck("duplicate-remotes", s.validateNoDuplicateRemoteURLs(ctx, nil, *req)))
+
+	versionCount, err := s.db.CountServerVersions(ctx, nil, req.Name)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return nil, err
+	}
+	var versionLimitErr error
+	if versionCount >= maxServerVersionsPerServer {
+		versionLimitErr = database.ErrMaxServersReached
+	}
+	checks = append(checks, validators.NewPublishCheck("version-limit", versionLimitErr))
+
+	versionExists, err := s.db.CheckVersionExists(ctx, nil, req.Name, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	var versionExistsErr error
+	if versionExists {
+		versionExistsErr = fmt.Errorf("%w: version %s has already been published", database.ErrInvalidVersion, req.Version)
+	}
+	checks = append(checks, validators.NewPublishCheck("version-uniqueness", versionExistsErr))
+
+	return checks, nil
 }
 
 // validateNoDuplicateRemoteURLs checks that no other server is using the same remote URLs
@@ -185,18 +288,75 @@ func (s *registryServiceImpl) validateNoDuplicateRemoteURLs(ctx context.Context,
 	return nil
 }
 
-// UpdateServer updates an existing server with new details
-func (s *registryServiceImpl) UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string) (*apiv0.ServerResponse, error) {
-	// Wrap the entire operation in a transaction
+// ImportServer stores a server version from an import without publish-time registry validation
+func (s *registryServiceImpl) ImportServer(ctx context.Context, req *apiv0.ServerJSON, officialMeta *apiv0.RegistryExtensions) (*apiv0.ServerResponse, error) {
+	if err := validators.ValidateServerJSON(req); err != nil {
+		return nil, err
+	}
+
 	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
-		return s.updateServerInTransaction(ctx, tx, serverName, version, req, newStatus)
+		if err := s.db.AcquirePublishLock(ctx, tx, req.Name); err != nil {
+			return nil, err
+		}
+
+		if officialMeta == nil {
+			meta, err := s.importMetadata(ctx, tx, req)
+			if err != nil {
+				return nil, err
+			}
+			officialMeta = meta
+		}
+
+		if officialMeta.IsLatest {
+			if err := s.db.UnmarkAsLatest(ctx, tx, req.Name); err != nil {
+				return nil, err
+			}
+		}
+
+		return s.db.UpsertServer(ctx, tx, req, officialMeta)
 	})
 }
 
-// updateServerInTransaction contains the actual UpdateServer logic within a transaction
-func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx pgx.Tx, serverName, version string, req *apiv0.ServerJSON, newStatus *string) (*apiv0.ServerResponse, error) {
+// importMetadata works out official metadata for an imported version, keeping the status and
+// publish time of a version being replaced
+func (s *registryServiceImpl) importMetadata(ctx context.Context, tx pgx.Tx, req *apiv0.ServerJSON) (*apiv0.RegistryExtensions, error) {
+	now := time.Now()
+	meta := &apiv0.RegistryExtensions{
+		Status:      model.StatusActive,
+		PublishedAt: now,
+		UpdatedAt:   now,
+		IsLatest:    true,
+	}
+
+	existing, err := s.db.GetServerByNameAndVersion(ctx, tx, req.Name, req.Version)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return nil, err
+	}
+	if existing != nil && existing.Meta.Official != nil {
+		meta.Status = existing.Meta.Official.Status
+		meta.PublishedAt = existing.Meta.Official.PublishedAt
+	}
+
+	currentLatest, err := s.db.GetCurrentLatestVersion(ctx, tx, req.Name)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return nil, err
+	}
+	if currentLatest != nil && currentLatest.Server.Version != req.Version {
+		var existingPublishedAt time.Time
+		if currentLatest.Meta.Official != nil {
+			existingPublishedAt = currentLatest.Meta.Official.PublishedAt
+		}
+		meta.IsLatest = CompareVersions(req.Version, currentLatest.Server.Version, meta.PublishedAt, existingPublishedAt) > 0
+	}
+
+	return meta, nil
+}
+
+// UpdateServer updates an existing server with new details.
+// Validation talks to package registries, so it runs before the transaction is opened, as for createServer.
+func (s *registryServiceImpl) UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string, statusMessage string) (*apiv0.ServerResponse, error) {
 	// Get current server to check if it's deleted or being deleted
-	currentServer, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
+	currentServer, err := s.db.GetServerByNameAndVersion(ctx, nil, serverName, version)
 	if err != nil {
 		return nil, err
 	}
@@ -209,10 +369,19 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 	skipRegistryValidation := currentlyDeleted || beingDeleted
 
 	// Validate the request, potentially skipping registry validation for deleted servers
-	if err := s.validateUpdateRequest(ctx, *req, skipRegistryValidation); err != nil {
+	ociImages, err := s.validateUpdateRequest(ctx, *req, skipRegistryValidation)
+	if err != nil {
 		return nil, err
 	}
 
+	// Wrap the database operations in a transaction
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
+		return s.updateServerInTransaction(ctx, tx, serverName, version, req, newStatus, statusMessage, skipRegistryValidation, ociImages)
+	})
+}
+
+// updateServerInTransaction contains the actual UpdateServer logic within a transaction
+func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx pgx.Tx, serverName, version string, req *apiv0.ServerJSON, newStatus *string, statusMessage string, skipRegistryValidation bool, ociImages []apiv0.OCIImage) (*apiv0.ServerResponse, error) {
 	// Acquire advisory lock to prevent concurrent edits of servers with same name
 	if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
 		return nil, err
@@ -232,9 +401,17 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 		return nil, err
 	}
 
+	// Record what the OCI packages resolve to now, unless registry validation was skipped
+	if !skipRegistryValidation && s.cfg.EnableRegistryValidation {
+		if err := s.db.SetServerOCIImages(ctx, tx, serverName, version, ociImages); err != nil {
+			return nil, err
+		}
+		updatedServerResponse.Meta.OCIImages = ociImages
+	}
+
 	// Handle status change if provided
 	if newStatus != nil {
-		updatedWithStatus, err := s.db.SetServerStatus(ctx, tx, serverName, version, *newStatus)
+		updatedWithStatus, err := s.db.SetServerStatus(ctx, tx, serverName, version, *newStatus, statusMessage)
 		if err != nil {
 			return nil, err
 		}
@@ -244,24 +421,24 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 	return updatedServerResponse, nil
 }
 
-// validateUpdateRequest validates an update request with optional registry validation skipping
-func (s *registryServiceImpl) validateUpdateRequest(ctx context.Context, req apiv0.ServerJSON, skipRegistryValidation bool) error {
-	// Always validate the server JSON structure
-	if err := validators.ValidateServerJSON(&req); err != nil {
-		return err
-	}
+// validateUpdateRequest validates an update request with optional registry validation skipping,
+// returning the digests and platforms its OCI packages resolved to.
+// If the request is invalid, the returned error is a *validators.ValidationError listing every issue found.
+func (s *registryServiceImpl) validateUpdateRequest(ctx context.Context, req apiv0.ServerJSON, skipRegistryValidation bool) ([]apiv0.OCIImage, error) {
+	// Always validate the server JSON structure, against the JSON Schema first
+	result := validators.CheckJSONSchema(&req)
+	result.Merge(validators.CheckServerJSON(&req))
 
 	// Skip registry validation if requested (for deleted servers)
 	if skipRegistryValidation || !s.cfg.EnableRegistryValidation {
-		return nil
+		return nil, result.Err()
 	}
 
 	// Perform registry validation for all packages
-	for i, pkg := range req.Packages {
-		if err := validators.ValidatePackage(ctx, pkg, req.Name); err != nil {
-			return fmt.Errorf("registry validation failed for package %d (%s): %w", i, pkg.Identifier, err)
-		}
-	}
+	ociImages := validators.ResolvePackages(ctx, result, req, s.cfg.UpstreamRegistries.Validators())
 
-	return nil
+	if err := result.Err(); err != nil {
+		return nil, err
+	}
+	return ociImages, nil
 }
@@ -196,6 +196,33 @@ func TestGetServerByName(t *testing.T) {
 	}
 }
 
+func TestCreateServer_DeclaredCapabilities(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+
+	created, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/declared-server",
+		Description: "Server with declared capabilities",
+		Version:     "1.0.0",
+		Meta: &apiv0.ServerMeta{
+			Capabilities: &apiv0.Capabilities{
+				Tools: []apiv0.Capability{{Name: "create_issue"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, created.Meta.Capabilities)
+	assert.Equal(t, apiv0.CapabilitiesSourceDeclared, created.Meta.Capabilities.Source)
+
+	result, err := service.GetServerByName(ctx, "com.example/declared-server")
+	require.NoError(t, err)
+	require.NotNil(t, result.Meta.Capabilities)
+	assert.Equal(t, apiv0.CapabilitiesSourceDeclared, result.Meta.Capabilities.Source)
+	assert.Equal(t, []apiv0.Capability{{Name: "create_issue"}}, result.Meta.Capabilities.Tools)
+}
+
 func TestGetServerByNameAndVersion(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
@@ -528,7 +555,7 @@ func TestUpdateServer(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := service.UpdateServer(ctx, tt.serverName, tt.version, tt.updatedServer, tt.newStatus)
+			result, err := service.UpdateServer(ctx, tt.serverName, tt.version, tt.updatedServer, tt.newStatus, "")
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -581,7 +608,7 @@ func TestUpdateServer_SkipValidationForDeletedServers(t *testing.T) {
 
 	// First, set server to deleted status
 	deletedStatus := string(model.StatusDeleted)
-	_, err = service.UpdateServer(ctx, serverName, version, invalidServer, &deletedStatus)
+	_, err = service.UpdateServer(ctx, serverName, version, invalidServer, &deletedStatus, "")
 	require.NoError(t, err, "should be able to set server to deleted (validation should be skipped)")
 
 	// Verify server is now deleted
@@ -606,7 +633,7 @@ func TestUpdateServer_SkipValidationForDeletedServers(t *testing.T) {
 	}
 
 	// This should succeed despite invalid packages because server is deleted
-	result, err := service.UpdateServer(ctx, serverName, version, updatedInvalidServer, nil)
+	result, err := service.UpdateServer(ctx, serverName, version, updatedInvalidServer, nil, "")
 	assert.NoError(t, err, "updating deleted server should skip registry validation")
 	assert.NotNil(t, result)
 	assert.Equal(t, "Updated description for deleted server", result.Server.Description)
@@ -636,7 +663,7 @@ func TestUpdateServer_SkipValidationForDeletedServers(t *testing.T) {
 
 	// Update server and set to deleted in same operation - should skip validation
 	newDeletedStatus := string(model.StatusDeleted)
-	result2, err := service.UpdateServer(ctx, "com.example/being-deleted-test", "1.0.0", activeServer, &newDeletedStatus)
+	result2, err := service.UpdateServer(ctx, "com.example/being-deleted-test", "1.0.0", activeServer, &newDeletedStatus, "")
 	assert.NoError(t, err, "updating server being set to deleted should skip registry validation")
 	assert.NotNil(t, result2)
 	assert.Equal(t, model.StatusDeleted, result2.Meta.Official.Status)
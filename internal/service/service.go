@@ -19,6 +19,14 @@ type RegistryService interface {
 	GetAllVersionsByServerName(ctx context.Context, serverName string) ([]*apiv0.ServerResponse, error)
 	// CreateServer creates a new server version
 	CreateServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error)
-	// UpdateServer updates an existing server and optionally its status
-	UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string) (*apiv0.ServerResponse, error)
+	// CreateUpgradedServer creates a new server version from a server.json that was upgraded from an
+	// earlier schema version, recording that version
+	CreateUpgradedServer(ctx context.Context, req *apiv0.ServerJSON, originalSchemaVersion string) (*apiv0.ServerResponse, error)
+	// CheckPublish runs every publish check for a server version without writing anything
+	CheckPublish(ctx context.Context, req *apiv0.ServerJSON) ([]apiv0.PublishCheck, error)
+	// ImportServer stores a server version from an import, replacing the version if it already exists.
+	// officialMeta is kept as-is when given; otherwise it is set as for a new publish.
+	ImportServer(ctx context.Context, req *apiv0.ServerJSON, officialMeta *apiv0.RegistryExtensions) (*apiv0.ServerResponse, error)
+	// UpdateServer updates an existing server and optionally its status, with a message saying why
+	UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string, statusMessage string) (*apiv0.ServerResponse, error)
 }
@@ -0,0 +1,183 @@
+// Package staticexport writes the registry's read API as static JSON files, so a CDN can serve
+// a read-only copy of the registry
+package staticexport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// DefaultPageSize matches the default limit of GET /v0/servers
+const DefaultPageSize = 30
+
+// IndexFile is the file name used for each API path, since most paths are also directories
+const IndexFile = "index.json"
+
+// Options controls what is exported
+type Options struct {
+	// PageSize is the number of servers per list page, defaulting to DefaultPageSize
+	PageSize int
+	// HiddenNamespaces are left out of the export, as for an anonymous API caller
+	HiddenNamespaces []database.NamespaceExclusion
+}
+
+// Result counts the files written by an export
+type Result struct {
+	Pages    int
+	Servers  int
+	Versions int
+}
+
+// Export writes the read API under dir, mirroring its paths:
+//
+//	v0/servers/index.json                                first page of GET /v0/servers
+//	v0/servers/pages/{n}/index.json                      later pages, whose cursor is the page number
+//	v0/servers/{serverName}/versions/index.json          GET /v0/servers/{serverName}/versions
+//	v0/servers/{serverName}/versions/{version}/index.json
+//	v0/servers/{serverName}/versions/latest/index.json
+//
+// Server names and versions are URL path-escaped, as in API requests. The export replaces any previous
+// v0/servers tree in dir, so servers and pages that no longer exist are not left behind.
+func Export(ctx context.Context, registry service.RegistryService, dir string, opts Options) (*Result, error) {
+	if opts.PageSize <= 0 {
+		opts.PageSize = DefaultPageSize
+	}
+	filter := &database.ServerFilter{HiddenNamespaces: opts.HiddenNamespaces}
+	result := &Result{}
+
+	// Write into a staging directory next to v0/servers, then swap it in once the export is complete
+	apiDir := filepath.Join(dir, "v0")
+	if err := os.MkdirAll(apiDir, 0o755); err != nil { //nolint:gosec // Exported files are meant to be served publicly
+		return nil, fmt.Errorf("failed to create %s: %w", apiDir, err)
+	}
+	serversDir, err := os.MkdirTemp(apiDir, ".servers-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	// Removing the staging directory is a no-op once it has been swapped in
+	defer os.RemoveAll(serversDir)
+	if err := os.Chmod(serversDir, 0o755); err != nil { //nolint:gosec // Exported files are meant to be served publicly
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+
+	var names []string
+	seen := map[string]bool{}
+	cursor := ""
+	for page := 1; ; page++ {
+		servers, nextCursor, err := registry.ListServers(ctx, filter, cursor, opts.PageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list servers: %w", err)
+		}
+
+		// Static hosts cannot route on query parameters, so the opaque cursor is the next page number
+		// and requests for ?cursor={n} are expected to be rewritten to pages/{n}
+		response := apiv0.ServerListResponse{
+			Servers:  make([]apiv0.ServerResponse, len(servers)),
+			Metadata: apiv0.Metadata{Count: len(servers)},
+		}
+		if nextCursor != "" {
+			response.Metadata.NextCursor = strconv.Itoa(page + 1)
+		}
+		for i, server := range servers {
+			response.Servers[i] = *server
+			// Capabilities are only included on detail responses, as in the API
+			response.Servers[i].Meta.Capabilities = nil
+			if !seen[server.Server.Name] {
+				seen[server.Server.Name] = true
+				names = append(names, server.Server.Name)
+			}
+		}
+
+		pagePath := serversDir
+		if page > 1 {
+			pagePath = filepath.Join(serversDir, "pages", strconv.Itoa(page))
+		}
+		if err := writeJSON(pagePath, response); err != nil {
+			return nil, err
+		}
+		result.Pages++
+
+		if nextCursor == "" {
+			break
+		}
+		cursor = nextCursor
+	}
+
+	for _, name := range names {
+		versions, err := exportServer(ctx, registry, filepath.Join(serversDir, url.PathEscape(name)), name)
+		if err != nil {
+			return nil, err
+		}
+		result.Servers++
+		result.Versions += versions
+	}
+
+	finalDir := filepath.Join(apiDir, "servers")
+	if err := os.RemoveAll(finalDir); err != nil {
+		return nil, fmt.Errorf("failed to remove previous export: %w", err)
+	}
+	if err := os.Rename(serversDir, finalDir); err != nil {
+		return nil, fmt.Errorf("failed to move export into place: %w", err)
+	}
+
+	return result, nil
+}
+
+// exportServer writes the versions list, each version and the latest alias for a server,
+// returning the number of versions written
+func exportServer(ctx context.Context, registry service.RegistryService, serverDir, name string) (int, error) {
+	versions, err := registry.GetAllVersionsByServerName(ctx, name)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get versions of %s: %w", name, err)
+	}
+
+	versionsDir := filepath.Join(serverDir, "versions")
+	list := apiv0.ServerListResponse{
+		Servers:  make([]apiv0.ServerResponse, len(versions)),
+		Metadata: apiv0.Metadata{Count: len(versions)},
+	}
+	for i, version := range versions {
+		list.Servers[i] = *version
+		if err := writeJSON(filepath.Join(versionsDir, url.PathEscape(version.Server.Version)), version); err != nil {
+			return 0, err
+		}
+	}
+	if err := writeJSON(versionsDir, list); err != nil {
+		return 0, err
+	}
+
+	latest, err := registry.GetServerByName(ctx, name)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest version of %s: %w", name, err)
+	}
+	if err := writeJSON(filepath.Join(versionsDir, "latest"), latest); err != nil {
+		return 0, err
+	}
+
+	return len(versions), nil
+}
+
+// writeJSON writes v as the index file of dir, creating the directory if needed
+func writeJSON(dir string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", dir, err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:gosec // Exported files are meant to be served publicly
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	path := filepath.Join(dir, IndexFile)
+	if err := os.WriteFile(path, data, 0o644); err != nil { //nolint:gosec // Exported files are meant to be served publicly
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
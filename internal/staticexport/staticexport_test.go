@@ -0,0 +1,170 @@
+package staticexport_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/staticexport"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listRegistryService serves a fixed, ordered list of server versions
+type listRegistryService struct {
+	service.RegistryService
+	servers []*apiv0.ServerResponse
+}
+
+func (l *listRegistryService) ListServers(_ context.Context, filter *database.ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error) {
+	var visible []*apiv0.ServerResponse
+	for _, server := range l.servers {
+		hidden := false
+		for _, exclusion := range filter.HiddenNamespaces {
+			if strings.HasPrefix(server.Server.Name, strings.TrimSuffix(exclusion.Pattern, "*")) {
+				hidden = true
+			}
+		}
+		if !hidden {
+			visible = append(visible, server)
+		}
+	}
+
+	start := 0
+	if cursor != "" {
+		start, _ = strconv.Atoi(cursor)
+	}
+	end := min(start+limit, len(visible))
+	next := ""
+	if end < len(visible) {
+		next = strconv.Itoa(end)
+	}
+	return visible[start:end], next, nil
+}
+
+func (l *listRegistryService) GetAllVersionsByServerName(_ context.Context, name string) ([]*apiv0.ServerResponse, error) {
+	var versions []*apiv0.ServerResponse
+	for _, server := range l.servers {
+		if server.Server.Name == name {
+			versions = append(versions, server)
+		}
+	}
+	return versions, nil
+}
+
+func (l *listRegistryService) GetServerByName(_ context.Context, name string) (*apiv0.ServerResponse, error) {
+	for _, server := range l.servers {
+		if server.Server.Name == name && server.Meta.Official.IsLatest {
+			return server, nil
+		}
+	}
+	return nil, database.ErrNotFound
+}
+
+func testVersion(name, version string, isLatest bool) *apiv0.ServerResponse {
+	return &apiv0.ServerResponse{
+		Server: apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Exported server",
+			Version:     version,
+			Packages: []model.Package{{
+				RegistryType: model.RegistryTypeNPM,
+				Identifier:   "@example/server",
+				Version:      version,
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+				EnvironmentVariables: []model.KeyValueInput{{
+					Name: "API_KEY",
+				}},
+			}},
+		},
+		Meta: apiv0.ResponseMeta{
+			Official:     &apiv0.RegistryExtensions{Status: model.StatusActive, IsLatest: isLatest},
+			Capabilities: &apiv0.Capabilities{Source: apiv0.CapabilitiesSourceDeclared},
+		},
+	}
+}
+
+func readJSON[T any](t *testing.T, path string) T {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var v T
+	require.NoError(t, json.Unmarshal(data, &v))
+	return v
+}
+
+func TestExport(t *testing.T) {
+	registry := &listRegistryService{servers: []*apiv0.ServerResponse{
+		testVersion("com.example/alpha", "1.0.0", false),
+		testVersion("com.example/alpha", "1.1.0", true),
+		testVersion("com.example/beta", "2.0.0", true),
+		testVersion("com.internal/secret", "1.0.0", true),
+	}}
+	dir := t.TempDir()
+
+	result, err := staticexport.Export(context.Background(), registry, dir, staticexport.Options{
+		PageSize:         2,
+		HiddenNamespaces: []database.NamespaceExclusion{{Pattern: "com.internal/*"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &staticexport.Result{Pages: 2, Servers: 2, Versions: 3}, result)
+
+	serversDir := filepath.Join(dir, "v0", "servers")
+
+	t.Run("list pages link by page number", func(t *testing.T) {
+		first := readJSON[apiv0.ServerListResponse](t, filepath.Join(serversDir, "index.json"))
+		assert.Equal(t, 2, first.Metadata.Count)
+		assert.Equal(t, "2", first.Metadata.NextCursor)
+		assert.Nil(t, first.Servers[0].Meta.Capabilities)
+
+		second := readJSON[apiv0.ServerListResponse](t, filepath.Join(serversDir, "pages", "2", "index.json"))
+		assert.Equal(t, 1, second.Metadata.Count)
+		assert.Empty(t, second.Metadata.NextCursor)
+		assert.Equal(t, "com.example/beta", second.Servers[0].Server.Name)
+	})
+
+	t.Run("versions keep every field", func(t *testing.T) {
+		versionsDir := filepath.Join(serversDir, "com.example%2Falpha", "versions")
+
+		versions := readJSON[apiv0.ServerListResponse](t, filepath.Join(versionsDir, "index.json"))
+		assert.Equal(t, 2, versions.Metadata.Count)
+
+		version := readJSON[apiv0.ServerResponse](t, filepath.Join(versionsDir, "1.0.0", "index.json"))
+		assert.Equal(t, "1.0.0", version.Server.Version)
+		require.Len(t, version.Server.Packages, 1)
+		assert.Equal(t, "API_KEY", version.Server.Packages[0].EnvironmentVariables[0].Name)
+		assert.NotNil(t, version.Meta.Capabilities)
+
+		latest := readJSON[apiv0.ServerResponse](t, filepath.Join(versionsDir, "latest", "index.json"))
+		assert.Equal(t, "1.1.0", latest.Server.Version)
+	})
+
+	t.Run("hidden namespaces are left out", func(t *testing.T) {
+		assert.NoDirExists(t, filepath.Join(serversDir, "com.internal%2Fsecret"))
+	})
+
+	t.Run("re-exporting removes servers and pages that no longer exist", func(t *testing.T) {
+		registry.servers = registry.servers[2:3]
+		result, err := staticexport.Export(context.Background(), registry, dir, staticexport.Options{PageSize: 2})
+		require.NoError(t, err)
+		assert.Equal(t, &staticexport.Result{Pages: 1, Servers: 1, Versions: 1}, result)
+
+		assert.NoDirExists(t, filepath.Join(serversDir, "pages"))
+		assert.NoDirExists(t, filepath.Join(serversDir, "com.example%2Falpha"))
+		assert.DirExists(t, filepath.Join(serversDir, "com.example%2Fbeta"))
+
+		entries, err := os.ReadDir(filepath.Join(dir, "v0"))
+		require.NoError(t, err)
+		require.Len(t, entries, 1, "no staging directory is left behind")
+		assert.Equal(t, "servers", entries[0].Name())
+	})
+}
@@ -32,6 +32,18 @@ type Metrics struct {
 
 	// Up tracks the health of the service
 	Up metric.Int64Gauge
+
+	// SyncRuns tracks the number of upstream sync runs, by result
+	SyncRuns metric.Int64Counter
+
+	// SyncServersApplied tracks the number of server versions applied from an upstream registry
+	SyncServersApplied metric.Int64Counter
+
+	// SyncServersInvalid tracks the number of upstream server versions skipped because they failed validation
+	SyncServersInvalid metric.Int64Counter
+
+	// SyncHighWaterMark tracks the latest upstream update applied, as a Unix timestamp
+	SyncHighWaterMark metric.Int64Gauge
 }
 
 // ShutdownFunc is a delegate that shuts down the OpenTelemetry components.
@@ -73,11 +85,48 @@ func NewMetrics(meter metric.Meter) (*Metrics, error) {
 		return nil, fmt.Errorf("failed to create service up gauge: %w", err)
 	}
 
+	syncRuns, err := meter.Int64Counter(
+		Namespace+".sync.runs",
+		metric.WithDescription("Total number of upstream sync runs"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sync run counter: %w", err)
+	}
+
+	syncApplied, err := meter.Int64Counter(
+		Namespace+".sync.servers_applied",
+		metric.WithDescription("Total number of server versions applied from the upstream registry"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sync servers applied counter: %w", err)
+	}
+
+	syncInvalid, err := meter.Int64Counter(
+		Namespace+".sync.servers_invalid",
+		metric.WithDescription("Total number of upstream server versions skipped because they failed validation"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sync servers invalid counter: %w", err)
+	}
+
+	syncHighWaterMark, err := meter.Int64Gauge(
+		Namespace+".sync.high_water_mark",
+		metric.WithDescription("Latest upstream update applied by sync, as a Unix timestamp"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sync high-water mark gauge: %w", err)
+	}
+
 	return &Metrics{
-		Requests:        req,
-		RequestDuration: reqDuration,
-		ErrorCount:      errCount,
-		Up:              up,
+		Requests:           req,
+		RequestDuration:    reqDuration,
+		ErrorCount:         errCount,
+		Up:                 up,
+		SyncRuns:           syncRuns,
+		SyncServersApplied: syncApplied,
+		SyncServersInvalid: syncInvalid,
+		SyncHighWaterMark:  syncHighWaterMark,
 	}, nil
 }
 
@@ -7,14 +7,50 @@ import (
 )
 
 type RegistryExtensions struct {
-	Status      model.Status `json:"status" enum:"active,deprecated,deleted" doc:"Server lifecycle status"`
-	PublishedAt time.Time    `json:"publishedAt" format:"date-time" doc:"Timestamp when the server was first published to the registry"`
-	UpdatedAt   time.Time    `json:"updatedAt,omitempty" format:"date-time" doc:"Timestamp when the server entry was last updated"`
-	IsLatest    bool         `json:"isLatest" doc:"Whether this is the latest version of the server"`
+	Status                model.Status `json:"status" enum:"active,deprecated,deleted" doc:"Server lifecycle status"`
+	PublishedAt           time.Time    `json:"publishedAt" format:"date-time" doc:"Timestamp when the server was first published to the registry"`
+	UpdatedAt             time.Time    `json:"updatedAt,omitempty" format:"date-time" doc:"Timestamp when the server entry was last updated"`
+	IsLatest              bool         `json:"isLatest" doc:"Whether this is the latest version of the server"`
+	StatusMessage         string       `json:"statusMessage,omitempty" doc:"Why the server version was given its current status" example:"Use 2.0.0, which fixes a security issue"`
+	OriginalSchemaVersion string       `json:"originalSchemaVersion,omitempty" doc:"Schema version the server.json was published with, when the registry upgraded it to the current schema" example:"2025-09-16"`
 }
 
 type ResponseMeta struct {
-	Official *RegistryExtensions `json:"io.modelcontextprotocol.registry/official,omitempty" doc:"Official MCP registry metadata"`
+	Official     *RegistryExtensions `json:"io.modelcontextprotocol.registry/official,omitempty" doc:"Official MCP registry metadata"`
+	Capabilities *Capabilities       `json:"io.modelcontextprotocol.registry/capabilities,omitempty" doc:"Tools, prompts and resources exposed by the server"`
+	OCIImages    []OCIImage          `json:"io.modelcontextprotocol.registry/oci-images,omitempty" doc:"Digests and platforms the server's OCI packages resolved to at publish time"`
+}
+
+// OCIImage records what an OCI package identifier resolved to when the server version was published
+type OCIImage struct {
+	Identifier string   `json:"identifier" doc:"OCI package identifier as published" example:"docker.io/owner/weather-mcp:1.0.2"`
+	Digest     string   `json:"digest" doc:"Manifest digest the identifier resolved to. Pull by this digest to get exactly the image that was published." example:"sha256:3f2b9c0e8d7a6b5c4d3e2f1a0b9c8d7e6f5a4b3c2d1e0f9a8b7c6d5e4f3a2b1c"`
+	Platforms  []string `json:"platforms,omitempty" doc:"Platforms supported by the image as os/arch, also listed with the variant for images built for one (e.g. linux/arm64 and linux/arm64/v8)"`
+}
+
+// Capability describes a single tool, prompt or resource exposed by a server
+type Capability struct {
+	Name        string `json:"name" doc:"Name of the tool, prompt or resource" example:"create_issue"`
+	Description string `json:"description,omitempty" doc:"Human-readable description as reported by the server" example:"Create a new issue in a repository"`
+	URI         string `json:"uri,omitempty" doc:"Resource URI (resources only)" example:"file:///logs/app.log"`
+}
+
+// CapabilitiesSource indicates where the registry obtained a server's capabilities from
+type CapabilitiesSource string
+
+const (
+	// CapabilitiesSourceDiscovered means the registry listed the capabilities from a remote itself
+	CapabilitiesSourceDiscovered CapabilitiesSource = "discovered"
+	// CapabilitiesSourceDeclared means the capabilities were declared by the publisher in server.json
+	CapabilitiesSourceDeclared CapabilitiesSource = "declared"
+)
+
+// Capabilities lists the tools, prompts and resources exposed by a server
+type Capabilities struct {
+	Source    CapabilitiesSource `json:"source,omitempty" enum:"discovered,declared" doc:"Whether the registry discovered these capabilities from a remote or they were declared by the publisher"`
+	Tools     []Capability       `json:"tools,omitempty" doc:"Tools exposed by the server (from tools/list)"`
+	Prompts   []Capability       `json:"prompts,omitempty" doc:"Prompts exposed by the server (from prompts/list)"`
+	Resources []Capability       `json:"resources,omitempty" doc:"Resources exposed by the server (from resources/list)"`
 }
 
 type ServerResponse struct {
@@ -29,6 +65,7 @@ type ServerListResponse struct {
 
 type ServerMeta struct {
 	PublisherProvided map[string]interface{} `json:"io.modelcontextprotocol.registry/publisher-provided,omitempty" doc:"Publisher-provided metadata for downstream registries"`
+	Capabilities      *Capabilities          `json:"io.modelcontextprotocol.registry/capabilities,omitempty" doc:"Publisher-declared tools, prompts and resources. Used when the registry cannot discover them from a remote."`
 }
 
 type ServerJSON struct {
@@ -49,3 +86,25 @@ type Metadata struct {
 	NextCursor string `json:"nextCursor,omitempty" doc:"Pagination cursor for retrieving the next page of results. Use this exact value in the cursor query parameter of your next request."`
 	Count      int    `json:"count" doc:"Number of items in current page"`
 }
+
+// CheckStatus is the outcome of a single publish check
+type CheckStatus string
+
+const (
+	CheckStatusPass CheckStatus = "pass"
+	CheckStatusFail CheckStatus = "fail"
+	CheckStatusSkip CheckStatus = "skip"
+)
+
+// PublishCheck is the result of one check run against a publish request
+type PublishCheck struct {
+	Name    string      `json:"name" doc:"Name of the check" example:"version-uniqueness"`
+	Status  CheckStatus `json:"status" enum:"pass,fail,skip" doc:"Outcome of the check"`
+	Message string      `json:"message,omitempty" doc:"Details about the outcome, including the reason for failures and skips" example:"version 1.0.2 has already been published"`
+}
+
+// PublishReport lists the outcome of every check run for a dry-run publish
+type PublishReport struct {
+	Valid  bool           `json:"valid" doc:"Whether the server would be accepted if published"`
+	Checks []PublishCheck `json:"checks" doc:"Checks run against the server, in order"`
+}
@@ -0,0 +1,69 @@
+// Package domainkey reads and writes the Ed25519 public key records that prove control of a domain
+// for DNS and HTTP authentication, published as a DNS TXT record or at WellKnownPath
+package domainkey
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// WellKnownPath is where HTTP authentication fetches the key record from on the domain
+const WellKnownPath = "/.well-known/mcp-registry-auth"
+
+var recordPattern = regexp.MustCompile(`v=MCPv1;\s*k=ed25519;\s*p=([A-Za-z0-9+/=]+)`)
+
+// ParseMCPKeysFromStrings returns the public keys of the MCP key records in inputs, such as the TXT
+// records of a domain. Inputs without a record, and records with invalid keys, are skipped.
+func ParseMCPKeysFromStrings(inputs []string) []ed25519.PublicKey {
+	var publicKeys []ed25519.PublicKey
+
+	for _, input := range inputs {
+		matches := recordPattern.FindStringSubmatch(input)
+		if len(matches) == 2 {
+			// Decode base64 public key
+			publicKeyBytes, err := base64.StdEncoding.DecodeString(matches[1])
+			if err != nil {
+				continue // Skip invalid keys
+			}
+
+			if len(publicKeyBytes) != ed25519.PublicKeySize {
+				continue // Skip invalid key sizes
+			}
+
+			publicKeys = append(publicKeys, ed25519.PublicKey(publicKeyBytes))
+		}
+	}
+
+	return publicKeys
+}
+
+// Record formats the key record publishing a public key
+func Record(publicKey ed25519.PublicKey) string {
+	return "v=MCPv1; k=ed25519; p=" + base64.StdEncoding.EncodeToString(publicKey)
+}
+
+// GenerateSeed returns a new private key as the 64-character hex seed the publisher logs in with
+func GenerateSeed() (string, error) {
+	seed := make([]byte, ed25519.SeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	return hex.EncodeToString(seed), nil
+}
+
+// PrivateKeyFromSeed decodes a 64-character hex seed into a private key
+func PrivateKeyFromSeed(hexSeed string) (ed25519.PrivateKey, error) {
+	seed, err := hex.DecodeString(strings.TrimSpace(hexSeed))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex seed format: %w", err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid seed length: expected %d bytes, got %d", ed25519.SeedSize, len(seed))
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
@@ -0,0 +1,66 @@
+package domainkey_test
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/pkg/domainkey"
+)
+
+func TestParseMCPKeysFromStrings(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	encoded := base64.StdEncoding.EncodeToString(publicKey)
+
+	tests := []struct {
+		name   string
+		inputs []string
+		want   int
+	}{
+		{"record", []string{"v=MCPv1; k=ed25519; p=" + encoded}, 1},
+		{"without spaces", []string{"v=MCPv1;k=ed25519;p=" + encoded}, 1},
+		{"among other TXT records", []string{"google-site-verification=abc", "v=MCPv1; k=ed25519; p=" + encoded}, 1},
+		{"invalid base64", []string{"v=MCPv1; k=ed25519; p=not-base64!"}, 0},
+		{"wrong key size", []string{"v=MCPv1; k=ed25519; p=" + base64.StdEncoding.EncodeToString([]byte("short"))}, 0},
+		{"other key type", []string{"v=MCPv1; k=rsa; p=" + encoded}, 0},
+		{"none", nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := domainkey.ParseMCPKeysFromStrings(tt.inputs)
+			require.Len(t, keys, tt.want)
+			if tt.want > 0 {
+				assert.True(t, keys[0].Equal(publicKey))
+			}
+		})
+	}
+}
+
+func TestRecordRoundTrip(t *testing.T) {
+	seed, err := domainkey.GenerateSeed()
+	require.NoError(t, err)
+	assert.Len(t, seed, 64)
+
+	privateKey, err := domainkey.PrivateKeyFromSeed(seed + "\n")
+	require.NoError(t, err)
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+
+	record := domainkey.Record(publicKey)
+	assert.Regexp(t, `^v=MCPv1; k=ed25519; p=[A-Za-z0-9+/]+=*$`, record)
+
+	keys := domainkey.ParseMCPKeysFromStrings([]string{record})
+	require.Len(t, keys, 1)
+	assert.True(t, keys[0].Equal(publicKey))
+}
+
+func TestPrivateKeyFromSeed_Invalid(t *testing.T) {
+	_, err := domainkey.PrivateKeyFromSeed("not hex")
+	assert.ErrorContains(t, err, "invalid hex seed format")
+
+	_, err = domainkey.PrivateKeyFromSeed("abcd")
+	assert.ErrorContains(t, err, "invalid seed length: expected 32 bytes, got 2")
+}
@@ -0,0 +1,322 @@
+// Package launch renders the command line and environment that start a server.json package,
+// filling in its arguments and environment variables from user-supplied input values
+package launch
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// ErrNoRuntime is returned for packages that cannot be started with a command, such as MCPB bundles
+// that the client has to install itself
+var ErrNoRuntime = errors.New("no runtime is known for this package type")
+
+// defaultRuntimes is the command used to run each package type without a runtime hint
+var defaultRuntimes = map[string]string{
+	model.RegistryTypeNPM:   model.RuntimeHintNPX,
+	model.RegistryTypePyPI:  model.RuntimeHintUVX,
+	model.RegistryTypeNuGet: model.RuntimeHintDNX,
+	model.RegistryTypeOCI:   model.RuntimeHintDocker,
+}
+
+// runtimePrefixArgs are passed to a runtime before the package's runtime arguments,
+// so it runs the package non-interactively over stdio
+var runtimePrefixArgs = map[string][]string{
+	model.RuntimeHintNPX:    {"-y"},
+	model.RuntimeHintDNX:    {"--yes"},
+	model.RuntimeHintDocker: {"run", "-i", "--rm"},
+}
+
+var templateVariable = regexp.MustCompile(`\{([^}]+)\}`)
+
+// Options supplies input values for rendering
+type Options struct {
+	// Values are user-supplied input values by input ID (see ArgumentID). Repeated arguments,
+	// and the variables of repeated arguments, accept several values.
+	Values map[string][]string
+
+	// Placeholder, if set, stands in for inputs the user has to provide but did not: secrets, and required
+	// inputs without a default. Rendering then never fails for missing inputs.
+	Placeholder func(id string, input model.Input) string
+}
+
+// Launch is how to start a package
+type Launch struct {
+	Command string            `json:"command" doc:"Command to run" example:"npx"`
+	Args    []string          `json:"args" doc:"Arguments to pass to the command"`
+	Env     map[string]string `json:"env,omitempty" doc:"Environment variables to set"`
+	URL     string            `json:"url,omitempty" doc:"URL to connect to once started, for packages with streamable-http or sse transports"`
+}
+
+// InputProblem describes an input value that is missing or invalid
+type InputProblem struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
+
+// InvalidInputsError lists every missing or invalid input value
+type InvalidInputsError struct {
+	Problems []InputProblem
+}
+
+func (e *InvalidInputsError) Error() string {
+	messages := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		messages[i] = problem.ID + ": " + problem.Message
+	}
+	return "invalid inputs: " + strings.Join(messages, "; ")
+}
+
+// ArgumentID is the input ID of the argument at index in its list: its value hint,
+// or its name without leading dashes, or "arg<n>" for unnamed positional arguments
+func ArgumentID(argument model.Argument, index int) string {
+	if argument.ValueHint != "" {
+		return argument.ValueHint
+	}
+	if id := strings.TrimLeft(argument.Name, "-"); id != "" {
+		return id
+	}
+	return fmt.Sprintf("arg%d", index+1)
+}
+
+// TransportVariables lists the names a package's transport URL can use as {variables}:
+// environment variable names, and argument names and value hints
+func TransportVariables(pkg *model.Package) []string {
+	var variables []string
+	for _, env := range pkg.EnvironmentVariables {
+		variables = append(variables, env.Name)
+	}
+	for _, arg := range slices.Concat(pkg.RuntimeArguments, pkg.PackageArguments) {
+		if arg.Name != "" {
+			variables = append(variables, arg.Name)
+		}
+		if arg.ValueHint != "" {
+			variables = append(variables, arg.ValueHint)
+		}
+	}
+	return variables
+}
+
+// Package renders the command, arguments and environment that start a package.
+// It returns an *InvalidInputsError listing every required input that is missing and every value that
+// is not valid for its input, or ErrNoRuntime if the package cannot be started with a command.
+func Package(pkg *model.Package, opts Options) (*Launch, error) {
+	command := pkg.RunTimeHint
+	if command == "" {
+		command = defaultRuntimes[pkg.RegistryType]
+	}
+	if command == "" {
+		return nil, fmt.Errorf("%w: %s", ErrNoRuntime, pkg.RegistryType)
+	}
+
+	r := newResolver(opts)
+	env := r.keyValues(pkg.EnvironmentVariables)
+
+	args := append([]string{}, runtimePrefixArgs[command]...)
+	if command == model.RuntimeHintDocker {
+		// Containers only see environment variables passed through explicitly
+		for _, variable := range pkg.EnvironmentVariables {
+			if _, ok := env[variable.Name]; ok {
+				args = append(args, "-e", variable.Name)
+			}
+		}
+	}
+	args = append(args, r.arguments(pkg.RuntimeArguments)...)
+	args = append(args, packageSpec(pkg))
+	args = append(args, r.arguments(pkg.PackageArguments)...)
+
+	launch := &Launch{Command: command, Args: args, Env: env}
+	if pkg.Transport.URL != "" {
+		launch.URL = templateVariable.ReplaceAllStringFunc(pkg.Transport.URL, func(match string) string {
+			if value, ok := r.resolved[match[1:len(match)-1]]; ok {
+				return value
+			}
+			return match
+		})
+	}
+
+	if err := r.err(); err != nil {
+		return nil, err
+	}
+	return launch, nil
+}
+
+// Headers renders HTTP headers, such as those of a remote, from their inputs
+func Headers(headers []model.KeyValueInput, opts Options) (map[string]string, error) {
+	r := newResolver(opts)
+	values := r.keyValues(headers)
+	if err := r.err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// packageSpec is how the package is named on the runtime's command line
+func packageSpec(pkg *model.Package) string {
+	if pkg.Version == "" {
+		return pkg.Identifier
+	}
+	switch pkg.RegistryType {
+	case model.RegistryTypeNPM, model.RegistryTypeNuGet:
+		return pkg.Identifier + "@" + pkg.Version
+	case model.RegistryTypePyPI:
+		return pkg.Identifier + "==" + pkg.Version
+	default:
+		return pkg.Identifier
+	}
+}
+
+// resolver fills in input values and records the problems it finds
+type resolver struct {
+	opts     Options
+	problems []InputProblem
+	reported map[string]bool
+	// resolved holds the first value of each argument and environment variable, by every name
+	// a transport URL can refer to it by
+	resolved map[string]string
+}
+
+func newResolver(opts Options) *resolver {
+	return &resolver{opts: opts, reported: map[string]bool{}, resolved: map[string]string{}}
+}
+
+func (r *resolver) err() error {
+	if len(r.problems) == 0 {
+		return nil
+	}
+	return &InvalidInputsError{Problems: r.problems}
+}
+
+func (r *resolver) problem(id, message string) {
+	if !r.reported[id] {
+		r.reported[id] = true
+		r.problems = append(r.problems, InputProblem{ID: id, Message: message})
+	}
+}
+
+func (r *resolver) record(value string, names ...string) {
+	for _, name := range names {
+		if _, ok := r.resolved[name]; name != "" && !ok {
+			r.resolved[name] = value
+		}
+	}
+}
+
+// value returns the value of an input for one repetition of an argument, and false if the input is
+// optional and unset. Fixed values are used with their {variables} filled in and cannot be overridden.
+func (r *resolver) value(id string, input model.InputWithVariables, repetition int) (string, bool) {
+	if input.Value != "" {
+		return r.substitute(input.Value, input.Variables, repetition), true
+	}
+	if values := r.opts.Values[id]; repetition < len(values) {
+		r.check(id, input.Input, values[repetition])
+		return values[repetition], true
+	}
+	return r.fallback(id, input.Input)
+}
+
+// fallback is used for inputs without a user-supplied value
+func (r *resolver) fallback(id string, input model.Input) (string, bool) {
+	switch {
+	case r.opts.Placeholder != nil && (input.IsSecret || input.IsRequired && input.Default == ""):
+		return r.opts.Placeholder(id, input), true
+	case input.Default != "":
+		return input.Default, true
+	case input.IsRequired:
+		r.problem(id, "required input not provided")
+		return "", true
+	default:
+		return "", false
+	}
+}
+
+// substitute replaces {variables} in a template. Optional variables without a value are left empty,
+// or use the placeholder if there is one, since the template cannot be used without them.
+func (r *resolver) substitute(template string, variables map[string]model.Input, repetition int) string {
+	return templateVariable.ReplaceAllStringFunc(template, func(match string) string {
+		name := match[1 : len(match)-1]
+		variable, ok := variables[name]
+		if !ok {
+			return match
+		}
+		if value, ok := r.value(name, model.InputWithVariables{Input: variable}, repetition); ok {
+			return value
+		}
+		if r.opts.Placeholder != nil {
+			return r.opts.Placeholder(name, variable)
+		}
+		return ""
+	})
+}
+
+// check records a problem if a user-supplied value is not valid for its input
+func (r *resolver) check(id string, input model.Input, value string) {
+	if len(input.Choices) > 0 && !slices.Contains(input.Choices, value) {
+		r.problem(id, fmt.Sprintf("must be one of %s", strings.Join(input.Choices, ", ")))
+		return
+	}
+	switch input.Format {
+	case model.FormatNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			r.problem(id, "must be a number")
+		}
+	case model.FormatBoolean:
+		if _, err := strconv.ParseBool(value); err != nil {
+			r.problem(id, "must be true or false")
+		}
+	case model.FormatString, model.FormatFilePath:
+	}
+}
+
+func (r *resolver) arguments(arguments []model.Argument) []string {
+	var args []string
+	for i, argument := range arguments {
+		id := ArgumentID(argument, i)
+
+		repetitions := 1
+		if argument.IsRepeated {
+			repetitions = max(repetitions, len(r.opts.Values[id]))
+			for name := range argument.Variables {
+				repetitions = max(repetitions, len(r.opts.Values[name]))
+			}
+		} else if len(r.opts.Values[id]) > 1 {
+			r.problem(id, "takes a single value")
+		}
+
+		for repetition := range repetitions {
+			value, ok := r.value(id, argument.InputWithVariables, repetition)
+			if !ok {
+				continue
+			}
+			r.record(value, id, argument.Name, argument.ValueHint)
+			if argument.Type == model.ArgumentTypeNamed {
+				args = append(args, argument.Name)
+			}
+			args = append(args, value)
+		}
+	}
+	return args
+}
+
+func (r *resolver) keyValues(keyValues []model.KeyValueInput) map[string]string {
+	if len(keyValues) == 0 {
+		return nil
+	}
+	values := make(map[string]string, len(keyValues))
+	for _, keyValue := range keyValues {
+		if len(r.opts.Values[keyValue.Name]) > 1 {
+			r.problem(keyValue.Name, "takes a single value")
+		}
+		if value, ok := r.value(keyValue.Name, keyValue.InputWithVariables, 0); ok {
+			values[keyValue.Name] = value
+			r.record(value, keyValue.Name)
+		}
+	}
+	return values
+}
@@ -0,0 +1,179 @@
+package launch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/pkg/launch"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func input(i model.Input) model.InputWithVariables {
+	return model.InputWithVariables{Input: i}
+}
+
+var weatherPackage = &model.Package{
+	RegistryType: model.RegistryTypeNPM,
+	Identifier:   "@example/weather",
+	Version:      "1.2.0",
+	Transport:    model.Transport{Type: model.TransportTypeStdio},
+	RuntimeArguments: []model.Argument{
+		{Type: model.ArgumentTypeNamed, Name: "--node-options", InputWithVariables: input(model.Input{})},
+	},
+	PackageArguments: []model.Argument{
+		{Type: model.ArgumentTypePositional, ValueHint: "data_dir", InputWithVariables: input(model.Input{IsRequired: true, Format: model.FormatFilePath})},
+		{Type: model.ArgumentTypeNamed, Name: "--units", InputWithVariables: input(model.Input{Default: "metric", Choices: []string{"metric", "imperial"}})},
+		{Type: model.ArgumentTypeNamed, Name: "--retries", InputWithVariables: input(model.Input{Format: model.FormatNumber})},
+	},
+	EnvironmentVariables: []model.KeyValueInput{
+		{Name: "WEATHER_API_KEY", InputWithVariables: input(model.Input{IsRequired: true, IsSecret: true})},
+		{Name: "LOG_LEVEL", InputWithVariables: input(model.Input{Default: "info"})},
+	},
+}
+
+func TestPackage(t *testing.T) {
+	t.Run("fills in values and defaults", func(t *testing.T) {
+		started, err := launch.Package(weatherPackage, launch.Options{Values: map[string][]string{
+			"data_dir":        {"/srv/weather"},
+			"WEATHER_API_KEY": {"secret"},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, "npx", started.Command)
+		assert.Equal(t, []string{"-y", "@example/weather@1.2.0", "/srv/weather", "--units", "metric"}, started.Args)
+		assert.Equal(t, map[string]string{"WEATHER_API_KEY": "secret", "LOG_LEVEL": "info"}, started.Env)
+	})
+
+	t.Run("user values override defaults", func(t *testing.T) {
+		started, err := launch.Package(weatherPackage, launch.Options{Values: map[string][]string{
+			"node-options":    {"--max-old-space-size=512"},
+			"data_dir":        {"/srv/weather"},
+			"units":           {"imperial"},
+			"retries":         {"3"},
+			"WEATHER_API_KEY": {"secret"},
+			"LOG_LEVEL":       {"debug"},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"-y", "--node-options", "--max-old-space-size=512", "@example/weather@1.2.0",
+			"/srv/weather", "--units", "imperial", "--retries", "3",
+		}, started.Args)
+		assert.Equal(t, "debug", started.Env["LOG_LEVEL"])
+	})
+
+	t.Run("reports every missing and invalid input", func(t *testing.T) {
+		_, err := launch.Package(weatherPackage, launch.Options{Values: map[string][]string{
+			"units":   {"kelvin"},
+			"retries": {"many"},
+		}})
+		var inputsErr *launch.InvalidInputsError
+		require.ErrorAs(t, err, &inputsErr)
+		assert.ElementsMatch(t, []launch.InputProblem{
+			{ID: "WEATHER_API_KEY", Message: "required input not provided"},
+			{ID: "data_dir", Message: "required input not provided"},
+			{ID: "units", Message: "must be one of metric, imperial"},
+			{ID: "retries", Message: "must be a number"},
+		}, inputsErr.Problems)
+	})
+
+	t.Run("placeholders stand in for inputs to provide", func(t *testing.T) {
+		started, err := launch.Package(weatherPackage, launch.Options{
+			Placeholder: func(id string, _ model.Input) string { return "<" + id + ">" },
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"-y", "@example/weather@1.2.0", "<data_dir>", "--units", "metric"}, started.Args)
+		assert.Equal(t, "<WEATHER_API_KEY>", started.Env["WEATHER_API_KEY"])
+	})
+
+	t.Run("repeated arguments and templates", func(t *testing.T) {
+		pkg := &model.Package{
+			RegistryType: model.RegistryTypeOCI,
+			Identifier:   "ghcr.io/example/files:1.0.0",
+			Transport:    model.Transport{Type: model.TransportTypeStdio},
+			RuntimeArguments: []model.Argument{{
+				Type:       model.ArgumentTypeNamed,
+				Name:       "--mount",
+				IsRepeated: true,
+				InputWithVariables: model.InputWithVariables{
+					Input: model.Input{Value: "type=bind,src={source},dst=/data/{target}"},
+					Variables: map[string]model.Input{
+						"source": {IsRequired: true},
+						"target": {Default: "files"},
+					},
+				},
+			}},
+			PackageArguments: []model.Argument{
+				{Type: model.ArgumentTypePositional, IsRepeated: true, InputWithVariables: input(model.Input{})},
+			},
+			EnvironmentVariables: []model.KeyValueInput{
+				{Name: "TOKEN", InputWithVariables: input(model.Input{IsRequired: true})},
+			},
+		}
+		started, err := launch.Package(pkg, launch.Options{Values: map[string][]string{
+			"source": {"/home/a", "/home/b"},
+			"target": {"a"},
+			"arg1":   {"x", "y"},
+			"TOKEN":  {"t"},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, "docker", started.Command)
+		assert.Equal(t, []string{
+			"run", "-i", "--rm", "-e", "TOKEN",
+			"--mount", "type=bind,src=/home/a,dst=/data/a",
+			"--mount", "type=bind,src=/home/b,dst=/data/files",
+			"ghcr.io/example/files:1.0.0", "x", "y",
+		}, started.Args)
+	})
+
+	t.Run("single-valued inputs reject several values", func(t *testing.T) {
+		_, err := launch.Package(weatherPackage, launch.Options{Values: map[string][]string{
+			"data_dir":        {"/a", "/b"},
+			"WEATHER_API_KEY": {"secret"},
+		}})
+		assert.ErrorContains(t, err, "data_dir: takes a single value")
+	})
+
+	t.Run("runtime hint and transport url", func(t *testing.T) {
+		pkg := &model.Package{
+			RegistryType: model.RegistryTypePyPI,
+			Identifier:   "example-weather",
+			Version:      "1.2.0",
+			RunTimeHint:  "pipx",
+			Transport:    model.Transport{Type: model.TransportTypeStreamableHTTP, URL: "http://localhost:{port}/mcp"},
+			PackageArguments: []model.Argument{
+				{Type: model.ArgumentTypeNamed, Name: "--port", ValueHint: "port", InputWithVariables: input(model.Input{Default: "8080"})},
+			},
+		}
+		started, err := launch.Package(pkg, launch.Options{})
+		require.NoError(t, err)
+		assert.Equal(t, "pipx", started.Command)
+		assert.Equal(t, []string{"example-weather==1.2.0", "--port", "8080"}, started.Args)
+		assert.Equal(t, "http://localhost:8080/mcp", started.URL)
+	})
+
+	t.Run("mcpb packages have no runtime", func(t *testing.T) {
+		_, err := launch.Package(&model.Package{RegistryType: model.RegistryTypeMCPB, Identifier: "https://example.com/weather.mcpb"}, launch.Options{})
+		assert.ErrorIs(t, err, launch.ErrNoRuntime)
+	})
+}
+
+func TestHeaders(t *testing.T) {
+	headers := []model.KeyValueInput{{Name: "Authorization", InputWithVariables: model.InputWithVariables{
+		Input:     model.Input{Value: "Bearer {token}"},
+		Variables: map[string]model.Input{"token": {IsRequired: true, IsSecret: true}},
+	}}}
+
+	values, err := launch.Headers(headers, launch.Options{Values: map[string][]string{"token": {"abc"}}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer abc"}, values)
+
+	_, err = launch.Headers(headers, launch.Options{})
+	assert.ErrorContains(t, err, "token: required input not provided")
+}
+
+func TestArgumentID(t *testing.T) {
+	assert.Equal(t, "path", launch.ArgumentID(model.Argument{Name: "--dir", ValueHint: "path"}, 0))
+	assert.Equal(t, "dir", launch.ArgumentID(model.Argument{Name: "--dir"}, 0))
+	assert.Equal(t, "arg3", launch.ArgumentID(model.Argument{}, 2))
+}
@@ -0,0 +1,136 @@
+// Package schema validates server.json documents against their JSON Schema and upgrades documents written
+// against earlier schema versions to the current one
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Versions lists every published server.json schema version, oldest first
+var Versions = []string{
+	"2025-07-09",
+	"2025-09-16",
+	"2025-09-29",
+	"2025-10-11",
+	model.CurrentSchemaVersion,
+}
+
+// upgrader converts a document from one schema version to the next, in place
+type upgrader func(doc map[string]any)
+
+// upgraders convert a document written against each version to the next version.
+// Versions that only relaxed rules need no upgrader.
+var upgraders = map[string]upgrader{
+	"2025-07-09": renameSnakeCaseFields,
+	"2025-09-16": removeRegistryManagedFields,
+}
+
+var schemaVersionPattern = regexp.MustCompile(`/schemas/(\d{4}-\d{2}-\d{2})/`)
+
+// Version returns the schema version of a $schema URL, or "" if it does not name one
+func Version(schemaURL string) string {
+	if match := schemaVersionPattern.FindStringSubmatch(schemaURL); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// NeedsUpgrade reports whether a $schema URL names an earlier schema version that Upgrade can convert
+func NeedsUpgrade(schemaURL string) bool {
+	index := slices.Index(Versions, Version(schemaURL))
+	return index >= 0 && index < len(Versions)-1
+}
+
+// Upgrade converts a server.json document written against an earlier schema version to the current
+// version, returning the converted document and the version it was written against. Documents that are
+// already current, or whose $schema does not name a known version, are returned unchanged so validation
+// can report on them.
+func Upgrade(data []byte) ([]byte, string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc map[string]any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, "", fmt.Errorf("invalid server.json: %w", err)
+	}
+
+	schemaURL, _ := doc["$schema"].(string)
+	version := Version(schemaURL)
+	if !NeedsUpgrade(schemaURL) {
+		return data, version, nil
+	}
+
+	for _, from := range Versions[slices.Index(Versions, version) : len(Versions)-1] {
+		if upgrade := upgraders[from]; upgrade != nil {
+			upgrade(doc)
+		}
+	}
+	doc["$schema"] = model.CurrentSchemaURL
+
+	upgraded, err := json.Marshal(doc)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode upgraded server.json: %w", err)
+	}
+	return upgraded, version, nil
+}
+
+// snakeCaseFields maps the field names of the 2025-07-09 schema to their 2025-09-16 camelCase names
+var snakeCaseFields = map[string]string{
+	"registry_type":         "registryType",
+	"registry_base_url":     "registryBaseUrl",
+	"file_sha256":           "fileSha256",
+	"runtime_hint":          "runtimeHint",
+	"runtime_arguments":     "runtimeArguments",
+	"package_arguments":     "packageArguments",
+	"environment_variables": "environmentVariables",
+	"is_required":           "isRequired",
+	"is_secret":             "isSecret",
+	"value_hint":            "valueHint",
+	"is_repeated":           "isRepeated",
+	"website_url":           "websiteUrl",
+}
+
+// renameSnakeCaseFields renames snake_case fields to camelCase throughout the document.
+// Publisher-provided _meta is left alone, as its keys are not part of the schema.
+func renameSnakeCaseFields(doc map[string]any) {
+	var rename func(value any)
+	rename = func(value any) {
+		switch value := value.(type) {
+		case map[string]any:
+			for key, child := range value {
+				if key == "_meta" {
+					continue
+				}
+				if renamed, ok := snakeCaseFields[key]; ok {
+					delete(value, key)
+					value[renamed] = child
+				}
+				rename(child)
+			}
+		case []any:
+			for _, child := range value {
+				rename(child)
+			}
+		}
+	}
+	rename(doc)
+}
+
+// removeRegistryManagedFields drops the fields the registry manages itself from 2025-09-29 on:
+// the server status and the official registry metadata
+func removeRegistryManagedFields(doc map[string]any) {
+	delete(doc, "status")
+	meta, ok := doc["_meta"].(map[string]any)
+	if !ok {
+		return
+	}
+	delete(meta, "io.modelcontextprotocol.registry/official")
+	if len(meta) == 0 {
+		delete(doc, "_meta")
+	}
+}
@@ -0,0 +1,109 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/schema"
+)
+
+func TestVersion(t *testing.T) {
+	assert.Equal(t, "2025-09-16", schema.Version("https://static.modelcontextprotocol.io/schemas/2025-09-16/server.schema.json"))
+	assert.Equal(t, "", schema.Version("https://example.com/custom.schema.json"))
+	assert.Equal(t, "", schema.Version(""))
+}
+
+func TestNeedsUpgrade(t *testing.T) {
+	assert.True(t, schema.NeedsUpgrade("https://static.modelcontextprotocol.io/schemas/2025-07-09/server.schema.json"))
+	assert.False(t, schema.NeedsUpgrade(model.CurrentSchemaURL))
+	assert.False(t, schema.NeedsUpgrade("https://static.modelcontextprotocol.io/schemas/2025-01-27/server.schema.json"))
+	assert.False(t, schema.NeedsUpgrade(""))
+}
+
+func TestUpgrade(t *testing.T) {
+	t.Run("2025-07-09 snake_case fields and registry-managed fields", func(t *testing.T) {
+		upgraded, version, err := schema.Upgrade([]byte(`{
+			"$schema": "https://static.modelcontextprotocol.io/schemas/2025-07-09/server.schema.json",
+			"name": "io.github.example/weather",
+			"description": "Weather server",
+			"status": "active",
+			"version": "1.0.0",
+			"website_url": "https://example.com",
+			"packages": [{
+				"registry_type": "npm",
+				"registry_base_url": "https://registry.npmjs.org",
+				"identifier": "@example/weather",
+				"version": "1.0.0",
+				"runtime_hint": "npx",
+				"transport": {"type": "stdio"},
+				"package_arguments": [{"type": "positional", "value_hint": "path", "is_required": true, "is_repeated": true}],
+				"environment_variables": [{"name": "API_KEY", "is_secret": true}]
+			}],
+			"_meta": {
+				"io.modelcontextprotocol.registry/official": {"is_latest": true},
+				"io.modelcontextprotocol.registry/publisher-provided": {"build_id": 42}
+			}
+		}`))
+		require.NoError(t, err)
+		assert.Equal(t, "2025-07-09", version)
+		assert.JSONEq(t, `{
+			"$schema": "`+model.CurrentSchemaURL+`",
+			"name": "io.github.example/weather",
+			"description": "Weather server",
+			"version": "1.0.0",
+			"websiteUrl": "https://example.com",
+			"packages": [{
+				"registryType": "npm",
+				"registryBaseUrl": "https://registry.npmjs.org",
+				"identifier": "@example/weather",
+				"version": "1.0.0",
+				"runtimeHint": "npx",
+				"transport": {"type": "stdio"},
+				"packageArguments": [{"type": "positional", "valueHint": "path", "isRequired": true, "isRepeated": true}],
+				"environmentVariables": [{"name": "API_KEY", "isSecret": true}]
+			}],
+			"_meta": {
+				"io.modelcontextprotocol.registry/publisher-provided": {"build_id": 42}
+			}
+		}`, string(upgraded))
+	})
+
+	t.Run("2025-09-16 drops status and official metadata", func(t *testing.T) {
+		upgraded, version, err := schema.Upgrade([]byte(`{
+			"$schema": "https://static.modelcontextprotocol.io/schemas/2025-09-16/server.schema.json",
+			"name": "io.github.example/weather",
+			"status": "deprecated",
+			"_meta": {"io.modelcontextprotocol.registry/official": {"isLatest": true}}
+		}`))
+		require.NoError(t, err)
+		assert.Equal(t, "2025-09-16", version)
+		assert.JSONEq(t, `{"$schema": "`+model.CurrentSchemaURL+`", "name": "io.github.example/weather"}`, string(upgraded))
+	})
+
+	t.Run("versions that only relaxed rules just get the current schema", func(t *testing.T) {
+		upgraded, version, err := schema.Upgrade([]byte(`{"$schema": "https://static.modelcontextprotocol.io/schemas/2025-10-11/server.schema.json", "name": "io.github.example/weather"}`))
+		require.NoError(t, err)
+		assert.Equal(t, "2025-10-11", version)
+		assert.JSONEq(t, `{"$schema": "`+model.CurrentSchemaURL+`", "name": "io.github.example/weather"}`, string(upgraded))
+	})
+
+	t.Run("current and unknown versions are unchanged", func(t *testing.T) {
+		for _, doc := range []string{
+			`{"$schema": "` + model.CurrentSchemaURL + `", "status": "active"}`,
+			`{"$schema": "https://static.modelcontextprotocol.io/schemas/2025-01-27/server.schema.json", "registry_type": "npm"}`,
+			`{"name": "io.github.example/weather"}`,
+		} {
+			upgraded, _, err := schema.Upgrade([]byte(doc))
+			require.NoError(t, err)
+			assert.Equal(t, doc, string(upgraded))
+		}
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, _, err := schema.Upgrade([]byte(`not json`))
+		assert.ErrorContains(t, err, "invalid server.json")
+	})
+}
@@ -0,0 +1,151 @@
+package schema
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// ErrNoJSONSchema is returned when validating a document whose $schema does not name a schema
+// version the registry has the JSON Schema for
+var ErrNoJSONSchema = errors.New("no JSON Schema is available for this schema version")
+
+// jsonSchemas holds the JSON Schema for each server.json schema version, generated from
+// docs/reference/api/openapi.yaml by 'make generate-schema'
+//
+//go:embed schemas
+var jsonSchemas embed.FS
+
+var (
+	compileMu sync.Mutex
+	compiled  = map[string]*jsonschema.Schema{}
+)
+
+// Violation is a place where a document does not match its JSON Schema
+type Violation struct {
+	// Path is a JSON Pointer (RFC 6901) to the offending value
+	Path    string
+	Message string
+}
+
+// JSONSchema returns the JSON Schema document for a server.json schema version, and false if the registry
+// does not have it
+func JSONSchema(version string) ([]byte, bool) {
+	if version == "" || strings.ContainsAny(version, "/.") {
+		return nil, false
+	}
+	data, err := jsonSchemas.ReadFile(path.Join("schemas", version, "server.schema.json"))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// URL is the $schema URL of a server.json schema version
+func URL(version string) string {
+	return "https://static.modelcontextprotocol.io/schemas/" + version + "/server.schema.json"
+}
+
+// Validate checks a server.json document against the JSON Schema for the version named by its $schema,
+// returning every violation found. It returns ErrNoJSONSchema if the registry does not have that JSON Schema.
+func Validate(data []byte) ([]Violation, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid server.json: %w", err)
+	}
+	object, _ := doc.(map[string]any)
+	schemaURL, _ := object["$schema"].(string)
+
+	compiledSchema, err := compile(Version(schemaURL))
+	if err != nil {
+		return nil, err
+	}
+
+	err = compiledSchema.Validate(doc)
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil, err
+	}
+
+	var violations []Violation
+	collectViolations(validationErr, &violations)
+	sort.SliceStable(violations, func(i, j int) bool { return violations[i].Path < violations[j].Path })
+	return violations, nil
+}
+
+// compile returns the compiled JSON Schema for a version, compiling it on first use
+func compile(version string) (*jsonschema.Schema, error) {
+	compileMu.Lock()
+	defer compileMu.Unlock()
+
+	if compiledSchema, ok := compiled[version]; ok {
+		return compiledSchema, nil
+	}
+	data, ok := JSONSchema(version)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNoJSONSchema, version)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft7
+	if err := compiler.AddResource(URL(version), bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("failed to load JSON Schema %s: %w", version, err)
+	}
+	compiledSchema, err := compiler.Compile(URL(version))
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile JSON Schema %s: %w", version, err)
+	}
+	compiled[version] = compiledSchema
+	return compiledSchema, nil
+}
+
+// collectViolations reports the most specific errors of a validation error tree. For failed anyOf and
+// oneOf keywords, only the errors of the alternative that came closest to matching are reported.
+func collectViolations(err *jsonschema.ValidationError, violations *[]Violation) {
+	if len(err.Causes) == 0 {
+		*violations = append(*violations, Violation{Path: err.InstanceLocation, Message: err.Message})
+		return
+	}
+	if keyword := path.Base(err.KeywordLocation); keyword == "anyOf" || keyword == "oneOf" {
+		if closest := closestAlternative(err); closest != nil {
+			*violations = append(*violations, closest...)
+		} else {
+			*violations = append(*violations, Violation{Path: err.InstanceLocation, Message: "does not match any of the allowed forms"})
+		}
+		return
+	}
+	for _, cause := range err.Causes {
+		collectViolations(cause, violations)
+	}
+}
+
+// closestAlternative returns the violations of the alternative with the fewest violations,
+// or nil if several alternatives are equally close
+func closestAlternative(err *jsonschema.ValidationError) []Violation {
+	var closest []Violation
+	tied := false
+	for _, cause := range err.Causes {
+		var alternative []Violation
+		collectViolations(cause, &alternative)
+		switch {
+		case closest == nil || len(alternative) < len(closest):
+			closest, tied = alternative, false
+		case len(alternative) == len(closest):
+			tied = true
+		}
+	}
+	if tied {
+		return nil
+	}
+	return closest
+}
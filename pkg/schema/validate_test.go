@@ -0,0 +1,77 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/schema"
+)
+
+func TestJSONSchema(t *testing.T) {
+	data, ok := schema.JSONSchema(model.CurrentSchemaVersion)
+	assert.True(t, ok)
+	assert.Contains(t, string(data), model.CurrentSchemaURL)
+
+	for _, version := range []string{"", "2020-01-01", "../schemas", "2025-10-17/.."} {
+		_, ok := schema.JSONSchema(version)
+		assert.False(t, ok, version)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("valid server", func(t *testing.T) {
+		violations, err := schema.Validate([]byte(`{
+			"$schema": "` + model.CurrentSchemaURL + `",
+			"name": "io.github.example/weather",
+			"description": "Weather server",
+			"version": "1.0.0",
+			"packages": [{
+				"registryType": "npm",
+				"identifier": "@example/weather",
+				"version": "1.0.0",
+				"transport": {"type": "stdio"}
+			}]
+		}`))
+		require.NoError(t, err)
+		assert.Empty(t, violations)
+	})
+
+	t.Run("reports every violation with its path", func(t *testing.T) {
+		violations, err := schema.Validate([]byte(`{
+			"$schema": "` + model.CurrentSchemaURL + `",
+			"name": "weather",
+			"version": "1.0.0",
+			"packages": [{
+				"registryType": "npm",
+				"identifier": "@example/weather",
+				"version": "1.0.0",
+				"transport": {"type": "stdio"},
+				"packageArguments": [{"type": "named"}]
+			}],
+			"remotes": [{"type": "streamable-http"}]
+		}`))
+		require.NoError(t, err)
+
+		paths := make([]string, len(violations))
+		for i, violation := range violations {
+			paths[i] = violation.Path
+			assert.NotEmpty(t, violation.Message)
+		}
+		assert.Equal(t, []string{"", "/name", "/packages/0/packageArguments/0", "/remotes/0"}, paths)
+		assert.Contains(t, violations[0].Message, "description")
+		assert.Contains(t, violations[3].Message, "url")
+	})
+
+	t.Run("schema versions without a JSON Schema", func(t *testing.T) {
+		_, err := schema.Validate([]byte(`{"$schema": "https://example.com/custom.schema.json", "name": "io.github.example/weather"}`))
+		assert.ErrorIs(t, err, schema.ErrNoJSONSchema)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := schema.Validate([]byte(`not json`))
+		assert.ErrorContains(t, err, "invalid server.json")
+	})
+}
@@ -19,6 +19,7 @@ var (
 	// Registry validation errors
 	ErrUnsupportedRegistryBaseURL   = errors.New("unsupported registry base URL")
 	ErrMismatchedRegistryTypeAndURL = errors.New("registry type and base URL do not match")
+	ErrOCIDigestRequired            = errors.New("OCI package identifier must be pinned to a digest")
 
 	// Argument validation errors
 	ErrNamedArgumentNameRequired     = errors.New("named argument name is required")
@@ -0,0 +1,72 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validators/registries"
+)
+
+// ValidatePackage validates that the package referenced in the server configuration is:
+// 1. allowed on the official registry (based on registry base url, including any configured upstream registries); and
+// 2. owned by the publisher, by checking for a matching server name in the package metadata
+func ValidatePackage(ctx context.Context, pkg model.Package, serverName string, upstreams ...registries.UpstreamRegistry) error {
+	switch pkg.RegistryType {
+	case model.RegistryTypeNPM:
+		return registries.ValidateNPM(ctx, pkg, serverName, upstreams...)
+	case model.RegistryTypePyPI:
+		return registries.ValidatePyPI(ctx, pkg, serverName, upstreams...)
+	case model.RegistryTypeNuGet:
+		return registries.ValidateNuGet(ctx, pkg, serverName, upstreams...)
+	case model.RegistryTypeOCI:
+		return registries.ValidateOCI(ctx, pkg, serverName)
+	case model.RegistryTypeMCPB:
+		return registries.ValidateMCPB(ctx, pkg, serverName)
+	default:
+		return fmt.Errorf("unsupported registry type: %s", pkg.RegistryType)
+	}
+}
+
+// ResolvePackages validates every package like ValidatePackage, recording failures in result,
+// and returns the digest and platforms each OCI package resolved to
+func ResolvePackages(ctx context.Context, result *ValidationResult, req apiv0.ServerJSON, upstreams []registries.UpstreamRegistry) []apiv0.OCIImage {
+	var images []apiv0.OCIImage
+	for i, pkg := range req.Packages {
+		var err error
+		if pkg.RegistryType == model.RegistryTypeOCI {
+			var resolution *registries.OCIResolution
+			resolution, err = registries.ResolveOCI(ctx, pkg, req.Name)
+			switch {
+			case errors.Is(err, registries.ErrRateLimited):
+				// Skip validation, as ValidateOCI does, but nothing can be recorded
+				log.Printf("Skipping OCI validation for %s due to rate limiting", pkg.Identifier)
+				err = nil
+			case err == nil:
+				images = append(images, apiv0.OCIImage{
+					Identifier: pkg.Identifier,
+					Digest:     resolution.Digest,
+					Platforms:  resolution.Platforms,
+				})
+			}
+		} else {
+			err = ValidatePackage(ctx, pkg, req.Name, upstreams...)
+		}
+
+		if err != nil {
+			result.AddError(jsonPointer("packages", i), CodeRegistryOwner, fmt.Errorf("registry validation failed for package %d (%s): %w", i, pkg.Identifier, err))
+		}
+	}
+	return images
+}
+
+// CheckPackages checks that every package is allowed on the official registry and owned by the publisher,
+// like publish-time registry validation without any configured upstream registries
+func CheckPackages(ctx context.Context, serverJSON *apiv0.ServerJSON) *ValidationResult {
+	result := &ValidationResult{}
+	ResolvePackages(ctx, result, *serverJSON, nil)
+	return result
+}
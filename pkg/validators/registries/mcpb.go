@@ -1,10 +1,17 @@
 package registries
 
 import (
+	"archive/zip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"regexp"
 	"strings"
 	"time"
@@ -15,9 +22,21 @@ import (
 var (
 	ErrMissingIdentifierForMCPB = fmt.Errorf("package identifier is required for MCPB packages")
 	ErrMissingFileSHA256ForMCPB = fmt.Errorf("must include a fileSha256 hash for integrity verification")
+	ErrMCPBTooLarge             = errors.New("MCPB bundle exceeds the maximum allowed size")
+	ErrMCPBHashMismatch         = errors.New("MCPB bundle SHA-256 does not match fileSha256")
+	ErrInvalidMCPBManifest      = errors.New("invalid MCPB manifest")
 )
 
-func ValidateMCPB(ctx context.Context, pkg model.Package, _ string) error {
+// maxMCPBSize caps how much of a bundle is downloaded for verification
+const maxMCPBSize int64 = 256 << 20 // 256 MiB
+
+// mcpbManifest holds the manifest.json fields checked against the server entry
+type mcpbManifest struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+func ValidateMCPB(ctx context.Context, pkg model.Package, serverName string) error {
 	// MCPB packages must include a file hash for integrity verification
 	if pkg.FileSHA256 == "" {
 		return ErrMissingFileSHA256ForMCPB
@@ -54,23 +73,112 @@ func ValidateMCPB(ctx context.Context, pkg model.Package, _ string) error {
 		return fmt.Errorf("MCPB package URL must contain 'mcp': %s", pkg.Identifier)
 	}
 
-	// Verify the file exists and is publicly accessible
-	client := &http.Client{Timeout: 10 * time.Second}
-	req, err := http.NewRequestWithContext(ctx, http.MethodHead, pkg.Identifier, nil)
+	// Download the bundle and verify its hash and manifest
+	client := &http.Client{Timeout: 2 * time.Minute}
+	return verifyMCPBBundle(ctx, client, pkg, serverName, maxMCPBSize)
+}
+
+// verifyMCPBBundle downloads the bundle, up to maxSize bytes, checks its SHA-256 against fileSha256,
+// and checks that its manifest.json matches the server entry
+func verifyMCPBBundle(ctx context.Context, client *http.Client, pkg model.Package, serverName string, maxSize int64) error {
+	bundle, err := os.CreateTemp("", "mcpb-*.zip")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		_ = bundle.Close()
+		_ = os.Remove(bundle.Name())
+	}()
+
+	size, digest, err := downloadMCPB(ctx, client, pkg.Identifier, bundle, maxSize)
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(digest, pkg.FileSHA256) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrMCPBHashMismatch, pkg.FileSHA256, digest)
+	}
+
+	manifest, err := readMCPBManifest(bundle, size)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return err
+	}
+
+	return validateMCPBManifest(manifest, pkg, serverName)
+}
+
+// downloadMCPB streams the bundle into dst, returning its size and hex-encoded SHA-256.
+// Bundles larger than maxSize are rejected with ErrMCPBTooLarge.
+func downloadMCPB(ctx context.Context, client *http.Client, bundleURL string, dst io.Writer, maxSize int64) (int64, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, bundleURL, nil)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to verify MCPB package accessibility: %w", err)
+		return 0, "", fmt.Errorf("failed to verify MCPB package accessibility: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("MCPB package '%s' is not publicly accessible (status: %d)", pkg.Identifier, resp.StatusCode)
+		return 0, "", fmt.Errorf("MCPB package '%s' is not publicly accessible (status: %d)", bundleURL, resp.StatusCode)
+	}
+
+	if resp.ContentLength > maxSize {
+		return 0, "", fmt.Errorf("%w (%d bytes, limit %d bytes)", ErrMCPBTooLarge, resp.ContentLength, maxSize)
+	}
+
+	// Read one byte past the limit so oversized bundles without a Content-Length are caught
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(dst, hash), io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to download MCPB package: %w", err)
+	}
+	if size > maxSize {
+		return 0, "", fmt.Errorf("%w (limit %d bytes)", ErrMCPBTooLarge, maxSize)
+	}
+
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// readMCPBManifest parses manifest.json from the root of the bundle archive
+func readMCPBManifest(bundle io.ReaderAt, size int64) (*mcpbManifest, error) {
+	archive, err := zip.NewReader(bundle, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: bundle is not a valid zip archive: %w", ErrInvalidMCPBManifest, err)
+	}
+
+	file, err := archive.Open("manifest.json")
+	if err != nil {
+		return nil, fmt.Errorf("%w: manifest.json not found in bundle", ErrInvalidMCPBManifest)
+	}
+	defer file.Close()
+
+	var manifest mcpbManifest
+	if err := json.NewDecoder(file).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse manifest.json: %w", ErrInvalidMCPBManifest, err)
+	}
+
+	return &manifest, nil
+}
+
+// validateMCPBManifest checks that the manifest name matches the server name, either in full or its
+// final segment, and that the manifest version matches the package version when one is given
+func validateMCPBManifest(manifest *mcpbManifest, pkg model.Package, serverName string) error {
+	if manifest.Name == "" {
+		return fmt.Errorf("%w: manifest.json is missing 'name'", ErrInvalidMCPBManifest)
+	}
+
+	shortName := serverName[strings.LastIndex(serverName, "/")+1:]
+	if !strings.EqualFold(manifest.Name, serverName) && !strings.EqualFold(manifest.Name, shortName) {
+		return fmt.Errorf("%w: manifest name '%s' does not match server name '%s'", ErrInvalidMCPBManifest, manifest.Name, serverName)
+	}
+
+	if pkg.Version != "" && manifest.Version != pkg.Version {
+		return fmt.Errorf("%w: manifest version '%s' does not match package version '%s'", ErrInvalidMCPBManifest, manifest.Version, pkg.Version)
 	}
 
 	return nil
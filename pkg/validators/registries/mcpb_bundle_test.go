@@ -0,0 +1,189 @@
+//nolint:testpackage
+package registries
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildMCPBFixture builds a zip bundle containing the given files
+func buildMCPBFixture(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+
+	return buf.Bytes()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestVerifyMCPBBundle(t *testing.T) {
+	validBundle := buildMCPBFixture(t, map[string]string{
+		"manifest.json":   `{"manifest_version": "0.2", "name": "airtable-mcp-server", "version": "1.7.2"}`,
+		"server/index.js": "console.log('hello')",
+	})
+	noManifestBundle := buildMCPBFixture(t, map[string]string{"server/index.js": "console.log('hello')"})
+	wrongNameBundle := buildMCPBFixture(t, map[string]string{"manifest.json": `{"name": "other-server", "version": "1.7.2"}`})
+	notAZip := []byte("definitely not a zip archive")
+
+	fixtures := map[string][]byte{
+		"/valid.mcpb":       validBundle,
+		"/no-manifest.mcpb": noManifestBundle,
+		"/wrong-name.mcpb":  wrongNameBundle,
+		"/not-a-zip.mcpb":   notAZip,
+	}
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := fixtures[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		path         string
+		fileSHA256   string
+		version      string
+		serverName   string
+		expectedErr  error
+		errorMessage string
+	}{
+		{
+			name:       "matching hash and manifest",
+			path:       "/valid.mcpb",
+			fileSHA256: sha256Hex(validBundle),
+			version:    "1.7.2",
+			serverName: "io.github.domdomegg/airtable-mcp-server",
+		},
+		{
+			name:       "manifest name matching the full server name",
+			path:       "/valid.mcpb",
+			fileSHA256: sha256Hex(validBundle),
+			serverName: "airtable-mcp-server",
+		},
+		{
+			name:        "hash mismatch",
+			path:        "/valid.mcpb",
+			fileSHA256:  "fe333e598595000ae021bd27117db32ec69af6987f507ba7a63c90638ff633ce",
+			serverName:  "io.github.domdomegg/airtable-mcp-server",
+			expectedErr: ErrMCPBHashMismatch,
+		},
+		{
+			name:         "manifest version mismatch",
+			path:         "/valid.mcpb",
+			fileSHA256:   sha256Hex(validBundle),
+			version:      "2.0.0",
+			serverName:   "io.github.domdomegg/airtable-mcp-server",
+			expectedErr:  ErrInvalidMCPBManifest,
+			errorMessage: "does not match package version",
+		},
+		{
+			name:         "manifest name mismatch",
+			path:         "/wrong-name.mcpb",
+			fileSHA256:   sha256Hex(wrongNameBundle),
+			serverName:   "io.github.domdomegg/airtable-mcp-server",
+			expectedErr:  ErrInvalidMCPBManifest,
+			errorMessage: "does not match server name",
+		},
+		{
+			name:         "missing manifest",
+			path:         "/no-manifest.mcpb",
+			fileSHA256:   sha256Hex(noManifestBundle),
+			serverName:   "io.github.domdomegg/airtable-mcp-server",
+			expectedErr:  ErrInvalidMCPBManifest,
+			errorMessage: "manifest.json not found",
+		},
+		{
+			name:         "not a zip archive",
+			path:         "/not-a-zip.mcpb",
+			fileSHA256:   sha256Hex(notAZip),
+			serverName:   "io.github.domdomegg/airtable-mcp-server",
+			expectedErr:  ErrInvalidMCPBManifest,
+			errorMessage: "not a valid zip archive",
+		},
+		{
+			name:         "missing file",
+			path:         "/missing.mcpb",
+			fileSHA256:   sha256Hex(validBundle),
+			serverName:   "io.github.domdomegg/airtable-mcp-server",
+			errorMessage: "not publicly accessible (status: 404)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg := model.Package{
+				RegistryType: model.RegistryTypeMCPB,
+				Identifier:   server.URL + tt.path,
+				Version:      tt.version,
+				FileSHA256:   tt.fileSHA256,
+			}
+
+			err := verifyMCPBBundle(context.Background(), server.Client(), pkg, tt.serverName, maxMCPBSize)
+
+			if tt.expectedErr == nil && tt.errorMessage == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			}
+			assert.Contains(t, err.Error(), tt.errorMessage)
+		})
+	}
+}
+
+func TestVerifyMCPBBundle_SizeLimit(t *testing.T) {
+	bundle := buildMCPBFixture(t, map[string]string{
+		"manifest.json": `{"name": "airtable-mcp-server", "version": "1.7.2"}`,
+	})
+
+	limit := int64(len(bundle) - 1)
+
+	for _, chunked := range []bool{false, true} {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if chunked {
+				// Flushing before writing the body forces chunked encoding, so no Content-Length is sent
+				w.(http.Flusher).Flush()
+			}
+			_, _ = w.Write(bundle)
+		}))
+
+		pkg := model.Package{
+			RegistryType: model.RegistryTypeMCPB,
+			Identifier:   server.URL + "/airtable-mcp-server.mcpb",
+			FileSHA256:   sha256Hex(bundle),
+		}
+
+		err := verifyMCPBBundle(context.Background(), server.Client(), pkg, "io.github.domdomegg/airtable-mcp-server", limit)
+		assert.ErrorIs(t, err, ErrMCPBTooLarge, "chunked=%v", chunked)
+
+		server.Close()
+	}
+}
@@ -4,8 +4,8 @@ import (
 	"context"
 	"testing"
 
-	"github.com/modelcontextprotocol/registry/internal/validators/registries"
 	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validators/registries"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -48,15 +48,16 @@ func TestValidateMCPB(t *testing.T) {
 			name:        "valid MCPB package should pass",
 			packageName: "https://github.com/domdomegg/airtable-mcp-server/releases/download/v1.7.2/airtable-mcp-server.mcpb",
 			serverName:  "io.github.domdomegg/airtable-mcp-server",
-			fileSHA256:  "fe333e598595000ae021bd27117db32ec69af6987f507ba7a63c90638ff633ce",
+			fileSHA256:  "8220de07a08ebe908f04da139ea03dbfe29758141347e945da60535fb7bcca20",
 			expectError: false,
 		},
 		{
-			name:        "valid MCPB package should pass",
-			packageName: "https://github.com/microsoft/playwright-mcp/releases/download/v0.0.36/playwright-mcp-extension-v0.0.36.zip",
-			serverName:  "com.microsoft/playwright-mcp",
-			fileSHA256:  "abc123ef4567890abcdef1234567890abcdef1234567890abcdef1234567890",
-			expectError: false,
+			name:         "MCPB package with mismatched file hash should fail",
+			packageName:  "https://github.com/microsoft/playwright-mcp/releases/download/v0.0.36/playwright-mcp-extension-v0.0.36.zip",
+			serverName:   "com.microsoft/playwright-mcp",
+			fileSHA256:   "abc123ef4567890abcdef1234567890abcdef1234567890abcdef1234567890",
+			expectError:  true,
+			errorMessage: "does not match fileSha256",
 		},
 		{
 			name:         "MCPB package without file hash should fail",
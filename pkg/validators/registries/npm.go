@@ -22,8 +22,9 @@ type NPMPackageResponse struct {
 	MCPName string `json:"mcpName"`
 }
 
-// ValidateNPM validates that an NPM package contains the correct MCP server name
-func ValidateNPM(ctx context.Context, pkg model.Package, serverName string) error {
+// ValidateNPM validates that an NPM package contains the correct MCP server name.
+// Packages may also come from any of the given upstream registries.
+func ValidateNPM(ctx context.Context, pkg model.Package, serverName string, upstreams ...UpstreamRegistry) error {
 	// Set default registry base URL if empty
 	if pkg.RegistryBaseURL == "" {
 		pkg.RegistryBaseURL = model.RegistryURLNPM
@@ -46,15 +47,15 @@ func ValidateNPM(ctx context.Context, pkg model.Package, serverName string) erro
 		return fmt.Errorf("NPM packages must not have 'fileSha256' field")
 	}
 
-	// Validate that the registry base URL matches NPM exactly
-	if pkg.RegistryBaseURL != model.RegistryURLNPM {
-		return fmt.Errorf("registry type and base URL do not match: '%s' is not valid for registry type '%s'. Expected: %s",
-			pkg.RegistryBaseURL, model.RegistryTypeNPM, model.RegistryURLNPM)
+	// Validate that the registry base URL is the public NPM registry or a configured upstream
+	upstream, err := resolveUpstream(model.RegistryTypeNPM, model.RegistryURLNPM, pkg.RegistryBaseURL, serverName, upstreams)
+	if err != nil {
+		return err
 	}
 
 	client := &http.Client{Timeout: 10 * time.Second}
 
-	requestURL := pkg.RegistryBaseURL + "/" + url.PathEscape(pkg.Identifier) + "/" + url.PathEscape(pkg.Version)
+	requestURL := upstream.BaseURL + "/" + url.PathEscape(pkg.Identifier) + "/" + url.PathEscape(pkg.Version)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
@@ -62,6 +63,7 @@ func ValidateNPM(ctx context.Context, pkg model.Package, serverName string) erro
 
 	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
 	req.Header.Set("Accept", "application/json")
+	authorizeUpstreamRequest(req, upstream)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -73,6 +75,10 @@ func ValidateNPM(ctx context.Context, pkg model.Package, serverName string) erro
 		return fmt.Errorf("NPM package '%s' not found (status: %d)", pkg.Identifier, resp.StatusCode)
 	}
 
+	if !checksOwnership(upstream) {
+		return nil
+	}
+
 	var npmResp NPMPackageResponse
 	if err := json.NewDecoder(resp.Body).Decode(&npmResp); err != nil {
 		return fmt.Errorf("failed to parse NPM package metadata: %w", err)
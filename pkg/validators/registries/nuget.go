@@ -17,8 +17,9 @@ var (
 	ErrMissingVersionForNuget    = errors.New("package version is required for NuGet packages")
 )
 
-// ValidateNuGet validates that a NuGet package contains the correct MCP server name
-func ValidateNuGet(ctx context.Context, pkg model.Package, serverName string) error {
+// ValidateNuGet validates that a NuGet package contains the correct MCP server name.
+// Packages may also come from any of the given upstream registries.
+func ValidateNuGet(ctx context.Context, pkg model.Package, serverName string, upstreams ...UpstreamRegistry) error {
 	// Set default registry base URL if empty
 	if pkg.RegistryBaseURL == "" {
 		pkg.RegistryBaseURL = model.RegistryURLNuGet
@@ -33,10 +34,10 @@ func ValidateNuGet(ctx context.Context, pkg model.Package, serverName string) er
 		return fmt.Errorf("NuGet packages must not have 'fileSha256' field - this is only for MCPB packages")
 	}
 
-	// Validate that the registry base URL matches NuGet exactly
-	if pkg.RegistryBaseURL != model.RegistryURLNuGet {
-		return fmt.Errorf("registry type and base URL do not match: '%s' is not valid for registry type '%s'. Expected: %s",
-			pkg.RegistryBaseURL, model.RegistryTypeNuGet, model.RegistryURLNuGet)
+	// Validate that the registry base URL is the public NuGet registry or a configured upstream
+	upstream, err := resolveUpstream(model.RegistryTypeNuGet, model.RegistryURLNuGet, pkg.RegistryBaseURL, serverName, upstreams)
+	if err != nil {
+		return err
 	}
 
 	client := &http.Client{Timeout: 10 * time.Second}
@@ -47,14 +48,19 @@ func ValidateNuGet(ctx context.Context, pkg model.Package, serverName string) er
 		return ErrMissingVersionForNuget
 	}
 
+	if !checksOwnership(upstream) {
+		return checkNuGetPackageExists(ctx, client, upstream, lowerID, lowerVersion)
+	}
+
 	// Try to get README from the package
-	readmeURL := fmt.Sprintf("%s/v3-flatcontainer/%s/%s/readme", pkg.RegistryBaseURL, lowerID, lowerVersion)
+	readmeURL := fmt.Sprintf("%s/v3-flatcontainer/%s/%s/readme", upstream.BaseURL, lowerID, lowerVersion)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, readmeURL, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+	authorizeUpstreamRequest(req, upstream)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -80,3 +86,27 @@ func ValidateNuGet(ctx context.Context, pkg model.Package, serverName string) er
 
 	return fmt.Errorf("NuGet package '%s' ownership validation failed. The server name '%s' must appear as 'mcp-name: %s' in the package README. Add it to your package README", pkg.Identifier, serverName, serverName)
 }
+
+// checkNuGetPackageExists checks that the package version's nuspec can be fetched
+func checkNuGetPackageExists(ctx context.Context, client *http.Client, upstream *UpstreamRegistry, lowerID, lowerVersion string) error {
+	nuspecURL := fmt.Sprintf("%s/v3-flatcontainer/%s/%s/%s.nuspec", upstream.BaseURL, lowerID, lowerVersion, lowerID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, nuspecURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+	authorizeUpstreamRequest(req, upstream)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch package from NuGet: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("NuGet package '%s' not found (status: %d)", lowerID, resp.StatusCode)
+	}
+
+	return nil
+}
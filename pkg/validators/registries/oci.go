@@ -2,11 +2,15 @@ package registries
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/modelcontextprotocol/registry/pkg/model"
@@ -60,10 +64,26 @@ func getRegistryConfig(registryBaseURL, namespace, repo string) *RegistryConfig
 	}
 }
 
+// OCIPlatform identifies the platform an image manifest was built for
+type OCIPlatform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+// String formats the platform as os/arch, with the variant appended if set (e.g. linux/arm64/v8)
+func (p OCIPlatform) String() string {
+	if p.Variant != "" {
+		return p.OS + "/" + p.Architecture + "/" + p.Variant
+	}
+	return p.OS + "/" + p.Architecture
+}
+
 // OCIManifest represents an OCI image manifest
 type OCIManifest struct {
 	Manifests []struct {
-		Digest string `json:"digest"`
+		Digest   string       `json:"digest"`
+		Platform *OCIPlatform `json:"platform,omitempty"`
 	} `json:"manifests,omitempty"`
 	Config struct {
 		Digest string `json:"digest"`
@@ -72,11 +92,20 @@ type OCIManifest struct {
 
 // OCIImageConfig represents an OCI image configuration
 type OCIImageConfig struct {
+	OCIPlatform
 	Config struct {
 		Labels map[string]string `json:"Labels"`
 	} `json:"config"`
 }
 
+// OCIResolution records what an OCI reference resolved to in its registry
+type OCIResolution struct {
+	// Digest is the digest of the manifest (or manifest index) the reference resolved to
+	Digest string
+	// Platforms lists the platforms the image supports, as os/arch[/variant]
+	Platforms []string
+}
+
 // ValidateOCI validates that an OCI image contains the correct MCP server name annotation.
 // Supports canonical OCI references including:
 //   - registry/namespace/image:tag
@@ -84,31 +113,43 @@ type OCIImageConfig struct {
 //   - registry/namespace/image:tag@sha256:digest
 //   - namespace/image:tag (defaults to docker.io)
 func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) error {
+	_, err := ResolveOCI(ctx, pkg, serverName)
+	if errors.Is(err, ErrRateLimited) {
+		// Handle rate limiting explicitly - skip validation
+		log.Printf("Skipping OCI validation for %s due to rate limiting", pkg.Identifier)
+		return nil
+	}
+	return err
+}
+
+// ResolveOCI validates an OCI package like ValidateOCI, and returns the manifest digest and platforms
+// its identifier resolved to. Returns an error wrapping ErrRateLimited if the registry rate limited us.
+func ResolveOCI(ctx context.Context, pkg model.Package, serverName string) (*OCIResolution, error) {
 	if pkg.Identifier == "" {
-		return ErrMissingIdentifierForOCI
+		return nil, ErrMissingIdentifierForOCI
 	}
 
 	// Validate that old format fields are not present
 	if pkg.RegistryBaseURL != "" {
-		return fmt.Errorf("OCI packages must not have 'registryBaseUrl' field - use canonical reference in 'identifier' instead (e.g., 'docker.io/owner/image:1.0.0')")
+		return nil, fmt.Errorf("OCI packages must not have 'registryBaseUrl' field - use canonical reference in 'identifier' instead (e.g., 'docker.io/owner/image:1.0.0')")
 	}
 	if pkg.Version != "" {
-		return fmt.Errorf("OCI packages must not have 'version' field - include version in 'identifier' instead (e.g., 'docker.io/owner/image:1.0.0')")
+		return nil, fmt.Errorf("OCI packages must not have 'version' field - include version in 'identifier' instead (e.g., 'docker.io/owner/image:1.0.0')")
 	}
 	if pkg.FileSHA256 != "" {
-		return fmt.Errorf("OCI packages must not have 'fileSha256' field")
+		return nil, fmt.Errorf("OCI packages must not have 'fileSha256' field")
 	}
 
 	// Parse the canonical OCI reference from the identifier
 	ociRef, err := ParseOCIReference(pkg.Identifier)
 	if err != nil {
-		return fmt.Errorf("invalid OCI reference: %w", err)
+		return nil, fmt.Errorf("invalid OCI reference: %w", err)
 	}
 
 	// Validate that the registry is supported
 	registryBaseURL := ociRef.GetRegistryBaseURL()
 	if err := validateRegistryURL(registryBaseURL); err != nil {
-		return err
+		return nil, err
 	}
 
 	client := &http.Client{Timeout: 10 * time.Second}
@@ -116,9 +157,15 @@ func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) erro
 	// Get registry configuration
 	registryConfig := getRegistryConfig(registryBaseURL, ociRef.Namespace, ociRef.Image)
 	if registryConfig == nil {
-		return fmt.Errorf("unsupported registry: %s", registryBaseURL)
+		return nil, fmt.Errorf("unsupported registry: %s", registryBaseURL)
 	}
 
+	return resolveImage(ctx, client, registryConfig, ociRef, serverName)
+}
+
+// resolveImage fetches the image manifest and config, validates the server name annotation,
+// and returns the manifest digest and supported platforms
+func resolveImage(ctx context.Context, client *http.Client, registryConfig *RegistryConfig, ociRef *OCIReference, serverName string) (*OCIResolution, error) {
 	// Determine what to use for manifest lookup: digest if available (most secure), otherwise tag
 	manifestRef := ociRef.Tag
 	if ociRef.Digest != "" {
@@ -126,24 +173,66 @@ func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) erro
 	}
 
 	// Get the image manifest
-	manifest, err := fetchImageManifest(ctx, client, registryConfig, ociRef.Namespace, ociRef.Image, manifestRef)
+	manifest, manifestDigest, err := fetchImageManifest(ctx, client, registryConfig, ociRef.Namespace, ociRef.Image, manifestRef)
 	if err != nil {
-		// Handle rate limiting explicitly - skip validation
-		if errors.Is(err, ErrRateLimited) {
-			log.Printf("Skipping OCI validation for %s due to rate limiting", ociRef.String())
-			return nil
-		}
-		return err
+		return nil, err
+	}
+	if ociRef.Digest != "" {
+		manifestDigest = ociRef.Digest
 	}
 
 	// Get config digest from manifest
 	configDigest, err := getConfigDigestFromManifest(ctx, client, registryConfig, ociRef.Namespace, ociRef.Image, manifest)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// Get image config (contains labels)
+	config, err := getImageConfig(ctx, client, registryConfig, ociRef.Namespace, ociRef.Image, configDigest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image config: %w", err)
 	}
 
 	// Validate server name annotation
-	return validateServerNameAnnotation(ctx, client, registryConfig, ociRef.Namespace, ociRef.Image, ociRef.Tag, configDigest, serverName)
+	if err := validateServerNameAnnotation(config, ociRef.Namespace, ociRef.Image, ociRef.Tag, serverName); err != nil {
+		return nil, err
+	}
+
+	return &OCIResolution{
+		Digest:    manifestDigest,
+		Platforms: manifestPlatforms(manifest, config),
+	}, nil
+}
+
+// manifestPlatforms lists the platforms in a manifest index, or the platform of the image config for single-arch images
+func manifestPlatforms(manifest *OCIManifest, config *OCIImageConfig) []string {
+	if len(manifest.Manifests) == 0 {
+		if config.OS == "" || config.Architecture == "" {
+			return nil
+		}
+		return appendPlatform(nil, config.OCIPlatform)
+	}
+
+	var platforms []string
+	for _, m := range manifest.Manifests {
+		// Skip entries without a platform, and attestation manifests which use unknown/unknown
+		if m.Platform == nil || m.Platform.OS == "" || m.Platform.OS == "unknown" {
+			continue
+		}
+		platforms = appendPlatform(platforms, *m.Platform)
+	}
+	return platforms
+}
+
+// appendPlatform adds a platform as os/arch, and also with its variant if it has one, so that
+// filtering by os/arch finds images that were built for a specific variant
+func appendPlatform(platforms []string, platform OCIPlatform) []string {
+	for _, p := range []string{platform.OS + "/" + platform.Architecture, platform.String()} {
+		if !slices.Contains(platforms, p) {
+			platforms = append(platforms, p)
+		}
+	}
+	return platforms
 }
 
 // validateRegistryURL validates that the registry base URL is supported
@@ -155,19 +244,19 @@ func validateRegistryURL(registryURL string) error {
 	return nil
 }
 
-// fetchImageManifest fetches the OCI manifest for an image
-func fetchImageManifest(ctx context.Context, client *http.Client, registryConfig *RegistryConfig, namespace, repo, tag string) (*OCIManifest, error) {
+// fetchImageManifest fetches the OCI manifest for an image, along with the digest of the manifest
+func fetchImageManifest(ctx context.Context, client *http.Client, registryConfig *RegistryConfig, namespace, repo, tag string) (*OCIManifest, string, error) {
 	manifestURL := fmt.Sprintf("%s/v2/%s/%s/manifests/%s", registryConfig.APIBaseURL, namespace, repo, tag)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create manifest request: %w", err)
+		return nil, "", fmt.Errorf("failed to create manifest request: %w", err)
 	}
 
 	// Get auth token if registry requires it
 	if registryConfig.AuthURL != "" {
 		token, err := getRegistryAuthToken(ctx, client, registryConfig)
 		if err != nil {
-			return nil, fmt.Errorf("failed to authenticate with registry: %w", err)
+			return nil, "", fmt.Errorf("failed to authenticate with registry: %w", err)
 		}
 		req.Header.Set("Authorization", "Bearer "+token)
 	}
@@ -177,28 +266,40 @@ func fetchImageManifest(ctx context.Context, client *http.Client, registryConfig
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch OCI manifest: %w", err)
+		return nil, "", fmt.Errorf("failed to fetch OCI manifest: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnauthorized {
-		return nil, fmt.Errorf("OCI image '%s/%s:%s' not found (status: %d)", namespace, repo, tag, resp.StatusCode)
+		return nil, "", fmt.Errorf("OCI image '%s/%s:%s' not found (status: %d)", namespace, repo, tag, resp.StatusCode)
 	}
 	if resp.StatusCode == http.StatusTooManyRequests {
 		// Rate limited, return explicit error
 		log.Printf("Rate limited when accessing OCI image '%s/%s:%s'", namespace, repo, tag)
-		return nil, fmt.Errorf("%w: %s/%s:%s", ErrRateLimited, namespace, repo, tag)
+		return nil, "", fmt.Errorf("%w: %s/%s:%s", ErrRateLimited, namespace, repo, tag)
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch OCI manifest (status: %d)", resp.StatusCode)
+		return nil, "", fmt.Errorf("failed to fetch OCI manifest (status: %d)", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read OCI manifest: %w", err)
 	}
 
 	var manifest OCIManifest
-	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
-		return nil, fmt.Errorf("failed to parse OCI manifest: %w", err)
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return nil, "", fmt.Errorf("failed to parse OCI manifest: %w", err)
 	}
 
-	return &manifest, nil
+	// Prefer the digest reported by the registry, which is computed over the exact bytes it stores
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		sum := sha256.Sum256(body)
+		digest = "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	return &manifest, digest, nil
 }
 
 // getConfigDigestFromManifest extracts the config digest from an OCI manifest
@@ -222,13 +323,7 @@ func getConfigDigestFromManifest(ctx context.Context, client *http.Client, regis
 }
 
 // validateServerNameAnnotation validates the MCP server name annotation in the image config
-func validateServerNameAnnotation(ctx context.Context, client *http.Client, registryConfig *RegistryConfig, namespace, repo, tag, configDigest, serverName string) error {
-	// Get image config (contains labels)
-	config, err := getImageConfig(ctx, client, registryConfig, namespace, repo, configDigest)
-	if err != nil {
-		return fmt.Errorf("failed to get image config: %w", err)
-	}
-
+func validateServerNameAnnotation(config *OCIImageConfig, namespace, repo, tag, serverName string) error {
 	mcpName, exists := config.Config.Labels["io.modelcontextprotocol.server.name"]
 	if !exists {
 		return fmt.Errorf("OCI image '%s/%s:%s' is missing required annotation. Add this to your Dockerfile: LABEL io.modelcontextprotocol.server.name=\"%s\"", namespace, repo, tag, serverName)
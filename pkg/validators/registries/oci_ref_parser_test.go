@@ -3,7 +3,7 @@ package registries_test
 import (
 	"testing"
 
-	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/validators/registries"
 )
 
 func TestParseOCIReference(t *testing.T) {
@@ -0,0 +1,123 @@
+//nolint:testpackage
+package registries
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	indexDigest     = "sha256:aaaa000000000000000000000000000000000000000000000000000000000000"
+	amd64Digest     = "sha256:bbbb000000000000000000000000000000000000000000000000000000000000"
+	configDigest    = "sha256:cccc000000000000000000000000000000000000000000000000000000000000"
+	singleArchTag   = "single"
+	multiArchTag    = "multi"
+	testServerName  = "io.github.example/weather"
+	singleArchImage = `{"config":{"digest":"` + configDigest + `"}}`
+)
+
+// newFakeOCIRegistry serves a multi-arch tag, a single-arch tag and their image config
+func newFakeOCIRegistry(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	index := `{"manifests":[
+		{"digest":"` + amd64Digest + `","platform":{"architecture":"amd64","os":"linux"}},
+		{"digest":"sha256:dddd","platform":{"architecture":"arm64","os":"linux","variant":"v8"}},
+		{"digest":"sha256:eeee","platform":{"architecture":"unknown","os":"unknown"}}
+	]}`
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/example/weather/manifests/"+multiArchTag, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Docker-Content-Digest", indexDigest)
+		_, _ = w.Write([]byte(index))
+	})
+	mux.HandleFunc("/v2/example/weather/manifests/"+indexDigest, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Docker-Content-Digest", indexDigest)
+		_, _ = w.Write([]byte(index))
+	})
+	mux.HandleFunc("/v2/example/weather/manifests/"+amd64Digest, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(singleArchImage))
+	})
+	mux.HandleFunc("/v2/example/weather/manifests/"+singleArchTag, func(w http.ResponseWriter, _ *http.Request) {
+		// No Docker-Content-Digest header, so the digest must be computed from the body
+		_, _ = w.Write([]byte(singleArchImage))
+	})
+	mux.HandleFunc("/v2/example/weather/blobs/"+configDigest, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"architecture":"amd64","os":"linux","config":{"Labels":{"io.modelcontextprotocol.server.name":"` + testServerName + `"}}}`))
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestResolveImage(t *testing.T) {
+	server := newFakeOCIRegistry(t)
+	defer server.Close()
+
+	registryConfig := &RegistryConfig{APIBaseURL: server.URL}
+	singleArchSum := sha256.Sum256([]byte(singleArchImage))
+
+	tests := []struct {
+		name              string
+		ref               OCIReference
+		serverName        string
+		expectedDigest    string
+		expectedPlatforms []string
+		errorMessage      string
+	}{
+		{
+			name:              "multi-arch tag",
+			ref:               OCIReference{Namespace: "example", Image: "weather", Tag: multiArchTag},
+			serverName:        testServerName,
+			expectedDigest:    indexDigest,
+			expectedPlatforms: []string{"linux/amd64", "linux/arm64", "linux/arm64/v8"},
+		},
+		{
+			name:              "pinned digest",
+			ref:               OCIReference{Namespace: "example", Image: "weather", Tag: multiArchTag, Digest: indexDigest},
+			serverName:        testServerName,
+			expectedDigest:    indexDigest,
+			expectedPlatforms: []string{"linux/amd64", "linux/arm64", "linux/arm64/v8"},
+		},
+		{
+			name:              "single-arch tag without digest header",
+			ref:               OCIReference{Namespace: "example", Image: "weather", Tag: singleArchTag},
+			serverName:        testServerName,
+			expectedDigest:    "sha256:" + hex.EncodeToString(singleArchSum[:]),
+			expectedPlatforms: []string{"linux/amd64"},
+		},
+		{
+			name:         "server name annotation mismatch",
+			ref:          OCIReference{Namespace: "example", Image: "weather", Tag: multiArchTag},
+			serverName:   "io.github.example/other",
+			errorMessage: "ownership validation failed",
+		},
+		{
+			name:         "missing tag",
+			ref:          OCIReference{Namespace: "example", Image: "weather", Tag: "missing"},
+			serverName:   testServerName,
+			errorMessage: "not found (status: 404)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolution, err := resolveImage(context.Background(), server.Client(), registryConfig, &tt.ref, tt.serverName)
+
+			if tt.errorMessage != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedDigest, resolution.Digest)
+			assert.Equal(t, tt.expectedPlatforms, resolution.Platforms)
+		})
+	}
+}
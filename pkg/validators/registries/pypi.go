@@ -24,8 +24,9 @@ type PyPIPackageResponse struct {
 	} `json:"info"`
 }
 
-// ValidatePyPI validates that a PyPI package contains the correct MCP server name
-func ValidatePyPI(ctx context.Context, pkg model.Package, serverName string) error {
+// ValidatePyPI validates that a PyPI package contains the correct MCP server name.
+// Packages may also come from any of the given upstream registries.
+func ValidatePyPI(ctx context.Context, pkg model.Package, serverName string, upstreams ...UpstreamRegistry) error {
 	// Set default registry base URL if empty
 	if pkg.RegistryBaseURL == "" {
 		pkg.RegistryBaseURL = model.RegistryURLPyPI
@@ -44,15 +45,15 @@ func ValidatePyPI(ctx context.Context, pkg model.Package, serverName string) err
 		return fmt.Errorf("PyPI packages must not have 'fileSha256' field - this is only for MCPB packages")
 	}
 
-	// Validate that the registry base URL matches PyPI exactly
-	if pkg.RegistryBaseURL != model.RegistryURLPyPI {
-		return fmt.Errorf("registry type and base URL do not match: '%s' is not valid for registry type '%s'. Expected: %s",
-			pkg.RegistryBaseURL, model.RegistryTypePyPI, model.RegistryURLPyPI)
+	// Validate that the registry base URL is the public PyPI registry or a configured upstream
+	upstream, err := resolveUpstream(model.RegistryTypePyPI, model.RegistryURLPyPI, pkg.RegistryBaseURL, serverName, upstreams)
+	if err != nil {
+		return err
 	}
 
 	client := &http.Client{Timeout: 10 * time.Second}
 
-	url := fmt.Sprintf("%s/pypi/%s/%s/json", pkg.RegistryBaseURL, pkg.Identifier, pkg.Version)
+	url := fmt.Sprintf("%s/pypi/%s/%s/json", upstream.BaseURL, pkg.Identifier, pkg.Version)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
@@ -60,6 +61,7 @@ func ValidatePyPI(ctx context.Context, pkg model.Package, serverName string) err
 
 	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
 	req.Header.Set("Accept", "application/json")
+	authorizeUpstreamRequest(req, upstream)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -71,6 +73,10 @@ func ValidatePyPI(ctx context.Context, pkg model.Package, serverName string) err
 		return fmt.Errorf("PyPI package '%s' not found (status: %d)", pkg.Identifier, resp.StatusCode)
 	}
 
+	if !checksOwnership(upstream) {
+		return nil
+	}
+
 	var pypiResp PyPIPackageResponse
 	if err := json.NewDecoder(resp.Body).Decode(&pypiResp); err != nil {
 		return fmt.Errorf("failed to parse PyPI package metadata: %w", err)
@@ -4,8 +4,8 @@ import (
 	"context"
 	"testing"
 
-	"github.com/modelcontextprotocol/registry/internal/validators/registries"
 	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validators/registries"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -0,0 +1,106 @@
+package registries
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Ownership checks applied to packages hosted on an upstream registry
+const (
+	// UpstreamOwnershipMetadata checks package metadata for the server name, as for the public registries
+	UpstreamOwnershipMetadata = "metadata"
+	// UpstreamOwnershipNone only checks that the package version exists
+	UpstreamOwnershipNone = "none"
+)
+
+// Authentication schemes for upstream registry lookups
+const (
+	UpstreamAuthBearer = "bearer"
+	UpstreamAuthBasic  = "basic"
+)
+
+// UpstreamAuth holds the credentials used when looking up packages on an upstream registry
+type UpstreamAuth struct {
+	Type     string
+	Token    string
+	Username string
+	Password string
+}
+
+// UpstreamRegistry is an additional package registry that packages may reference with registryBaseUrl
+type UpstreamRegistry struct {
+	// RegistryType is the package registry type this base URL is accepted for (npm, pypi or nuget)
+	RegistryType string
+	// BaseURL is the registry base URL packages must use, e.g. https://artifactory.example.com/api/npm/npm
+	BaseURL string
+	// Auth holds optional credentials for package lookups
+	Auth *UpstreamAuth
+	// Ownership selects how package ownership is checked, defaulting to UpstreamOwnershipMetadata
+	Ownership string
+	// Namespaces optionally restricts which servers may use this registry, by server name prefix (e.g. "com.example/")
+	Namespaces []string
+}
+
+// allowsServer reports whether a server may reference packages on this registry
+func (r *UpstreamRegistry) allowsServer(serverName string) bool {
+	if len(r.Namespaces) == 0 {
+		return true
+	}
+	for _, prefix := range r.Namespaces {
+		if strings.HasPrefix(serverName, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveUpstream returns the registry a package's base URL refers to: either the public registry for
+// its type, or one of the upstream registries the server is allowed to use
+func resolveUpstream(registryType, publicURL, baseURL, serverName string, upstreams []UpstreamRegistry) (*UpstreamRegistry, error) {
+	if baseURL == publicURL {
+		return &UpstreamRegistry{
+			RegistryType: registryType,
+			BaseURL:      publicURL,
+			Ownership:    UpstreamOwnershipMetadata,
+		}, nil
+	}
+
+	var upstream *UpstreamRegistry
+	for _, candidate := range upstreams {
+		candidate.BaseURL = strings.TrimSuffix(candidate.BaseURL, "/")
+		if candidate.RegistryType == registryType && candidate.BaseURL == strings.TrimSuffix(baseURL, "/") {
+			upstream = &candidate
+			break
+		}
+	}
+	if upstream == nil {
+		return nil, fmt.Errorf("registry type and base URL do not match: '%s' is not valid for registry type '%s'. Expected: %s",
+			baseURL, registryType, publicURL)
+	}
+
+	if !upstream.allowsServer(serverName) {
+		return nil, fmt.Errorf("server '%s' is not allowed to use registry '%s'", serverName, upstream.BaseURL)
+	}
+
+	return upstream, nil
+}
+
+// checksOwnership reports whether package metadata must name the server, rather than the package only having to exist
+func checksOwnership(upstream *UpstreamRegistry) bool {
+	return upstream.Ownership != UpstreamOwnershipNone
+}
+
+// authorizeUpstreamRequest adds the upstream registry's credentials, if any, to a lookup request
+func authorizeUpstreamRequest(req *http.Request, upstream *UpstreamRegistry) {
+	if upstream.Auth == nil {
+		return
+	}
+
+	switch upstream.Auth.Type {
+	case UpstreamAuthBearer:
+		req.Header.Set("Authorization", "Bearer "+upstream.Auth.Token)
+	case UpstreamAuthBasic:
+		req.SetBasicAuth(upstream.Auth.Username, upstream.Auth.Password)
+	}
+}
@@ -0,0 +1,196 @@
+package registries_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validators/registries"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const upstreamServerName = "com.example/internal-tool"
+
+// newUpstreamRegistry starts a stand-in npm, PyPI and NuGet registry that requires the given Authorization header.
+// Packages named "owned" declare upstreamServerName, packages named "unowned" do not, and anything else is not found.
+func newUpstreamRegistry(t *testing.T, authorization string) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/{name}/{version}", func(w http.ResponseWriter, r *http.Request) {
+		mcpName := map[string]string{"owned": upstreamServerName, "unowned": "com.example/other"}[r.PathValue("name")]
+		if mcpName == "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"name": r.PathValue("name"), "mcpName": mcpName})
+	})
+	mux.HandleFunc("/pypi/{name}/{version}/json", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("name") != "owned" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"info": map[string]string{"description": "mcp-name: " + upstreamServerName}})
+	})
+	mux.HandleFunc("/v3-flatcontainer/{name}/{version}/{file}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("name") != "owned" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("mcp-name: " + upstreamServerName))
+	})
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != authorization {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+}
+
+func TestValidateNPM_UpstreamRegistry(t *testing.T) {
+	server := newUpstreamRegistry(t, "Bearer secret-token")
+	defer server.Close()
+
+	upstream := registries.UpstreamRegistry{
+		RegistryType: model.RegistryTypeNPM,
+		BaseURL:      server.URL,
+		Auth:         &registries.UpstreamAuth{Type: registries.UpstreamAuthBearer, Token: "secret-token"},
+	}
+
+	tests := []struct {
+		name         string
+		identifier   string
+		baseURL      string
+		serverName   string
+		upstream     registries.UpstreamRegistry
+		errorMessage string
+	}{
+		{
+			name:       "owned package on upstream registry",
+			identifier: "owned",
+			serverName: upstreamServerName,
+			upstream:   upstream,
+		},
+		{
+			name:         "package owned by another server",
+			identifier:   "unowned",
+			serverName:   upstreamServerName,
+			upstream:     upstream,
+			errorMessage: "ownership validation failed",
+		},
+		{
+			name:       "ownership check disabled only requires the package to exist",
+			identifier: "unowned",
+			serverName: upstreamServerName,
+			upstream: registries.UpstreamRegistry{
+				RegistryType: model.RegistryTypeNPM,
+				BaseURL:      server.URL,
+				Auth:         upstream.Auth,
+				Ownership:    registries.UpstreamOwnershipNone,
+			},
+		},
+		{
+			name:       "ownership check disabled still requires the package to exist",
+			identifier: "missing",
+			serverName: upstreamServerName,
+			upstream: registries.UpstreamRegistry{
+				RegistryType: model.RegistryTypeNPM,
+				BaseURL:      server.URL,
+				Auth:         upstream.Auth,
+				Ownership:    registries.UpstreamOwnershipNone,
+			},
+			errorMessage: "not found (status: 404)",
+		},
+		{
+			name:         "wrong credentials",
+			identifier:   "owned",
+			serverName:   upstreamServerName,
+			upstream:     registries.UpstreamRegistry{RegistryType: model.RegistryTypeNPM, BaseURL: server.URL},
+			errorMessage: "not found (status: 401)",
+		},
+		{
+			name:       "server outside allowed namespaces",
+			identifier: "owned",
+			serverName: upstreamServerName,
+			upstream: registries.UpstreamRegistry{
+				RegistryType: model.RegistryTypeNPM,
+				BaseURL:      server.URL,
+				Auth:         upstream.Auth,
+				Namespaces:   []string{"com.acme/"},
+			},
+			errorMessage: "is not allowed to use registry",
+		},
+		{
+			name:         "base URL not configured",
+			identifier:   "owned",
+			baseURL:      "https://npm.internal.example.com",
+			serverName:   upstreamServerName,
+			upstream:     upstream,
+			errorMessage: "registry type and base URL do not match",
+		},
+		{
+			name:         "upstream registered for another registry type",
+			identifier:   "owned",
+			serverName:   upstreamServerName,
+			upstream:     registries.UpstreamRegistry{RegistryType: model.RegistryTypePyPI, BaseURL: server.URL},
+			errorMessage: "registry type and base URL do not match",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL := tt.baseURL
+			if baseURL == "" {
+				baseURL = server.URL
+			}
+			pkg := model.Package{
+				RegistryType:    model.RegistryTypeNPM,
+				RegistryBaseURL: baseURL,
+				Identifier:      tt.identifier,
+				Version:         "1.0.0",
+			}
+
+			err := registries.ValidateNPM(context.Background(), pkg, tt.serverName, tt.upstream)
+
+			if tt.errorMessage == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMessage)
+		})
+	}
+}
+
+func TestValidatePyPIAndNuGet_UpstreamRegistry(t *testing.T) {
+	server := newUpstreamRegistry(t, "Basic dXNlcjpwYXNz") // user:pass
+	defer server.Close()
+
+	auth := &registries.UpstreamAuth{Type: registries.UpstreamAuthBasic, Username: "user", Password: "pass"}
+	upstreams := []registries.UpstreamRegistry{
+		{RegistryType: model.RegistryTypePyPI, BaseURL: server.URL, Auth: auth},
+		{RegistryType: model.RegistryTypeNuGet, BaseURL: server.URL, Auth: auth, Ownership: registries.UpstreamOwnershipNone},
+	}
+
+	t.Run("pypi", func(t *testing.T) {
+		pkg := model.Package{RegistryType: model.RegistryTypePyPI, RegistryBaseURL: server.URL, Identifier: "owned", Version: "1.0.0"}
+		assert.NoError(t, registries.ValidatePyPI(context.Background(), pkg, upstreamServerName, upstreams...))
+
+		pkg.Identifier = "missing"
+		assert.ErrorContains(t, registries.ValidatePyPI(context.Background(), pkg, upstreamServerName, upstreams...), "not found")
+	})
+
+	t.Run("nuget", func(t *testing.T) {
+		pkg := model.Package{RegistryType: model.RegistryTypeNuGet, RegistryBaseURL: server.URL, Identifier: "Owned", Version: "1.0.0"}
+		assert.NoError(t, registries.ValidateNuGet(context.Background(), pkg, upstreamServerName, upstreams...))
+
+		pkg.Identifier = "Missing"
+		assert.ErrorContains(t, registries.ValidateNuGet(context.Background(), pkg, upstreamServerName, upstreams...), "not found")
+	})
+}
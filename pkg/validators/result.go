@@ -0,0 +1,166 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Severity indicates how serious a validation issue is
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Issue codes for problems that are not represented by a sentinel error
+const (
+	CodeInvalidValue     = "invalid_value"
+	CodeRequired         = "required"
+	CodeUnsupportedValue = "unsupported_value"
+	CodeNamespaceMatch   = "namespace_mismatch"
+	CodeRegistryOwner    = "registry_ownership"
+	CodeTooLarge         = "too_large"
+	CodeSchemaViolation  = "schema_violation"
+)
+
+// sentinelCodes maps sentinel validation errors to stable issue codes
+var sentinelCodes = []struct {
+	err  error
+	code string
+}{
+	{ErrInvalidRepositoryURL, "invalid_repository_url"},
+	{ErrInvalidSubfolderPath, "invalid_subfolder_path"},
+	{ErrPackageNameHasSpaces, "package_name_has_spaces"},
+	{ErrReservedVersionString, "reserved_version"},
+	{ErrVersionLooksLikeRange, "version_range"},
+	{ErrInvalidRemoteURL, "invalid_remote_url"},
+	{ErrUnsupportedRegistryBaseURL, "unsupported_registry_base_url"},
+	{ErrMismatchedRegistryTypeAndURL, "mismatched_registry_type_and_url"},
+	{ErrOCIDigestRequired, "oci_digest_required"},
+	{ErrNamedArgumentNameRequired, "named_argument_name_required"},
+	{ErrInvalidNamedArgumentName, "invalid_named_argument_name"},
+	{ErrArgumentValueStartsWithName, "argument_value_starts_with_name"},
+	{ErrArgumentDefaultStartsWithName, "argument_default_starts_with_name"},
+	{ErrMultipleSlashesInServerName, "multiple_slashes_in_server_name"},
+	{ErrInvalidServerNameFormat, "invalid_server_name_format"},
+}
+
+// ValidationIssue is a single problem found while validating a server.json
+type ValidationIssue struct {
+	Path     string   `json:"path" doc:"JSON Pointer (RFC 6901) to the offending value" example:"/packages/2/transport/url"`
+	Code     string   `json:"code" doc:"Machine-readable issue code" example:"invalid_remote_url"`
+	Message  string   `json:"message" doc:"Human-readable description of the problem"`
+	Severity Severity `json:"severity" enum:"error,warning" doc:"Issue severity"`
+
+	err error
+}
+
+// ValidationResult collects every issue found while validating a server.json
+type ValidationResult struct {
+	Issues []ValidationIssue `json:"issues"`
+}
+
+// AddError records an error-severity issue at the given JSON Pointer path.
+// The code is taken from the sentinel error wrapped by err where there is one, otherwise fallbackCode is used.
+func (r *ValidationResult) AddError(path, fallbackCode string, err error) {
+	if err == nil {
+		return
+	}
+
+	// Flatten nested validation errors so their paths are kept
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		r.Issues = append(r.Issues, validationErr.Issues...)
+		return
+	}
+
+	r.Issues = append(r.Issues, ValidationIssue{
+		Path:     path,
+		Code:     issueCode(err, fallbackCode),
+		Message:  err.Error(),
+		Severity: SeverityError,
+		err:      err,
+	})
+}
+
+// Merge appends every issue from another result
+func (r *ValidationResult) Merge(other *ValidationResult) {
+	if other != nil {
+		r.Issues = append(r.Issues, other.Issues...)
+	}
+}
+
+// Valid reports whether the result contains no error-severity issues
+func (r *ValidationResult) Valid() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			return false
+		}
+	}
+	return true
+}
+
+// Err returns a *ValidationError listing every issue if the result contains any error-severity issues, and nil otherwise
+func (r *ValidationResult) Err() error {
+	if r.Valid() {
+		return nil
+	}
+	return &ValidationError{Issues: r.Issues}
+}
+
+// ValidationError is returned when a server.json is invalid, and lists every issue found.
+// The sentinel errors behind each issue can be matched with errors.Is.
+type ValidationError struct {
+	Issues []ValidationIssue
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		if len(e.Issues) == 1 || issue.Path == "" {
+			messages = append(messages, issue.Message)
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("%s: %s", issue.Path, issue.Message))
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		if issue.err != nil {
+			errs = append(errs, issue.err)
+		}
+	}
+	return errs
+}
+
+func issueCode(err error, fallbackCode string) string {
+	for _, sentinel := range sentinelCodes {
+		if errors.Is(err, sentinel.err) {
+			return sentinel.code
+		}
+	}
+	return fallbackCode
+}
+
+// jsonPointer builds an RFC 6901 JSON Pointer from reference tokens
+func jsonPointer(tokens ...any) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteByte('/')
+		switch t := token.(type) {
+		case int:
+			b.WriteString(strconv.Itoa(t))
+		case string:
+			b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(t))
+		default:
+			b.WriteString(fmt.Sprint(t))
+		}
+	}
+	return b.String()
+}
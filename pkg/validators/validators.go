@@ -3,15 +3,18 @@ package validators
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"regexp"
 	"slices"
 	"strings"
 
-	"github.com/modelcontextprotocol/registry/internal/config"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/launch"
 	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/schema"
+	"github.com/modelcontextprotocol/registry/pkg/validators/registries"
 )
 
 // Server name validation patterns
@@ -53,92 +56,81 @@ var (
 )
 
 func ValidateServerJSON(serverJSON *apiv0.ServerJSON) error {
+	return CheckServerJSON(serverJSON).Err()
+}
+
+// CheckServerJSON validates a server.json and returns every issue found, each located by a JSON Pointer
+func CheckServerJSON(serverJSON *apiv0.ServerJSON) *ValidationResult {
+	result := &ValidationResult{}
+
 	// Validate schema version is provided and supported
 	// Note: Schema field is also marked as required in the ServerJSON struct definition
 	// for API-level validation and documentation
-	if serverJSON.Schema == "" {
-		return fmt.Errorf("$schema field is required")
-	}
-	if !strings.Contains(serverJSON.Schema, model.CurrentSchemaVersion) {
-		return fmt.Errorf("schema version %s is not supported. Please use schema version %s", serverJSON.Schema, model.CurrentSchemaVersion)
+	switch {
+	case serverJSON.Schema == "":
+		result.AddError("/$schema", CodeRequired, fmt.Errorf("$schema field is required"))
+	case !strings.Contains(serverJSON.Schema, model.CurrentSchemaVersion):
+		result.AddError("/$schema", CodeUnsupportedValue, fmt.Errorf("schema version %s is not supported. Please use schema version %s", serverJSON.Schema, model.CurrentSchemaVersion))
 	}
 
 	// Validate server name exists and format
-	if _, err := parseServerName(*serverJSON); err != nil {
-		return err
-	}
+	_, nameErr := parseServerName(*serverJSON)
+	result.AddError("/name", CodeInvalidValue, nameErr)
 
 	// Validate top-level server version is a specific version (not a range) & not "latest"
-	if err := validateVersion(serverJSON.Version); err != nil {
-		return err
-	}
+	result.AddError("/version", CodeInvalidValue, validateVersion(serverJSON.Version))
 
 	// Validate repository
-	if err := validateRepository(&serverJSON.Repository); err != nil {
-		return err
-	}
+	validateRepository(result, &serverJSON.Repository)
 
-	// Validate website URL if provided
+	// Validate website URL if provided, and that it matches the reverse-DNS namespace
 	if err := validateWebsiteURL(serverJSON.WebsiteURL); err != nil {
-		return err
+		result.AddError("/websiteUrl", CodeInvalidValue, err)
+	} else if nameErr == nil {
+		result.AddError("/websiteUrl", CodeNamespaceMatch, validateWebsiteURLNamespaceMatch(*serverJSON))
 	}
 
 	// Validate title if provided
-	if err := validateTitle(serverJSON.Title); err != nil {
-		return err
-	}
+	result.AddError("/title", CodeInvalidValue, validateTitle(serverJSON.Title))
 
 	// Validate icons if provided
-	if err := validateIcons(serverJSON.Icons); err != nil {
-		return err
-	}
+	validateIcons(result, serverJSON.Icons)
 
 	// Validate all packages (basic field validation)
 	// Detailed package validation (including registry checks) is done during publish
-	for _, pkg := range serverJSON.Packages {
-		if err := validatePackageField(&pkg); err != nil {
-			return err
-		}
+	for i, pkg := range serverJSON.Packages {
+		validatePackageField(result, jsonPointer("packages", i), &pkg)
 	}
 
-	// Validate all remotes
-	for _, remote := range serverJSON.Remotes {
-		if err := validateRemoteTransport(&remote); err != nil {
-			return err
+	// Validate all remotes, and that their URLs match the reverse-DNS namespace
+	for i, remote := range serverJSON.Remotes {
+		path := jsonPointer("remotes", i)
+		issueCount := len(result.Issues)
+		validateRemoteTransport(result, path, &remote)
+		if nameErr == nil && len(result.Issues) == issueCount {
+			result.AddError(path+"/url", CodeNamespaceMatch, validateRemoteNamespaceMatch(remote.URL, serverJSON.Name))
 		}
 	}
 
-	// Validate reverse-DNS namespace matching for remote URLs
-	if err := validateRemoteNamespaceMatch(*serverJSON); err != nil {
-		return err
-	}
-
-	// Validate reverse-DNS namespace matching for website URL
-	if err := validateWebsiteURLNamespaceMatch(*serverJSON); err != nil {
-		return err
-	}
-
-	return nil
+	return result
 }
 
-func validateRepository(obj *model.Repository) error {
+func validateRepository(result *ValidationResult, obj *model.Repository) {
 	// Skip validation for empty repository (optional field)
 	if obj.URL == "" && obj.Source == "" {
-		return nil
+		return
 	}
 
 	// validate the repository source
 	repoSource := RepositorySource(obj.Source)
 	if !IsValidRepositoryURL(repoSource, obj.URL) {
-		return fmt.Errorf("%w: %s", ErrInvalidRepositoryURL, obj.URL)
+		result.AddError("/repository/url", CodeInvalidValue, fmt.Errorf("%w: %s", ErrInvalidRepositoryURL, obj.URL))
 	}
 
 	// validate subfolder if present
 	if obj.Subfolder != "" && !IsValidSubfolderPath(obj.Subfolder) {
-		return fmt.Errorf("%w: %s", ErrInvalidSubfolderPath, obj.Subfolder)
+		result.AddError("/repository/subfolder", CodeInvalidValue, fmt.Errorf("%w: %s", ErrInvalidSubfolderPath, obj.Subfolder))
 	}
-
-	return nil
 }
 
 func validateWebsiteURL(websiteURL string) error {
@@ -180,20 +172,13 @@ func validateTitle(title string) error {
 	return nil
 }
 
-func validateIcons(icons []model.Icon) error {
-	// Skip validation if no icons are provided (optional field)
-	if len(icons) == 0 {
-		return nil
-	}
-
-	// Validate each icon
+func validateIcons(result *ValidationResult, icons []model.Icon) {
+	// Validate each icon (optional field)
 	for i, icon := range icons {
 		if err := validateIcon(&icon); err != nil {
-			return fmt.Errorf("invalid icon at index %d: %w", i, err)
+			result.AddError(jsonPointer("icons", i, "src"), CodeInvalidValue, fmt.Errorf("invalid icon at index %d: %w", i, err))
 		}
 	}
-
-	return nil
 }
 
 func validateIcon(icon *model.Icon) error {
@@ -216,37 +201,62 @@ func validateIcon(icon *model.Icon) error {
 	return nil
 }
 
-func validatePackageField(obj *model.Package) error {
+func validatePackageField(result *ValidationResult, path string, obj *model.Package) {
 	if !HasNoSpaces(obj.Identifier) {
-		return ErrPackageNameHasSpaces
+		result.AddError(path+"/identifier", CodeInvalidValue, ErrPackageNameHasSpaces)
 	}
 
 	// Validate version string
-	if err := validateVersion(obj.Version); err != nil {
-		return err
-	}
+	result.AddError(path+"/version", CodeInvalidValue, validateVersion(obj.Version))
 
 	// Validate runtime arguments
-	for _, arg := range obj.RuntimeArguments {
+	for i, arg := range obj.RuntimeArguments {
 		if err := validateArgument(&arg); err != nil {
-			return fmt.Errorf("invalid runtime argument: %w", err)
+			result.AddError(path+jsonPointer("runtimeArguments", i), CodeInvalidValue, fmt.Errorf("invalid runtime argument: %w", err))
 		}
 	}
 
 	// Validate package arguments
-	for _, arg := range obj.PackageArguments {
+	for i, arg := range obj.PackageArguments {
 		if err := validateArgument(&arg); err != nil {
-			return fmt.Errorf("invalid package argument: %w", err)
+			result.AddError(path+jsonPointer("packageArguments", i), CodeInvalidValue, fmt.Errorf("invalid package argument: %w", err))
 		}
 	}
 
 	// Validate transport with template variable support
-	availableVariables := collectAvailableVariables(obj)
-	if err := validatePackageTransport(&obj.Transport, availableVariables); err != nil {
-		return fmt.Errorf("invalid transport: %w", err)
+	availableVariables := launch.TransportVariables(obj)
+	validatePackageTransport(result, path+"/transport", &obj.Transport, availableVariables)
+}
+
+// CheckJSONSchema checks a server.json against the JSON Schema named by its $schema, reporting every
+// violation with its path. Servers whose $schema has no JSON Schema are reported by CheckServerJSON.
+func CheckJSONSchema(serverJSON *apiv0.ServerJSON) *ValidationResult {
+	result := &ValidationResult{}
+	data, err := json.Marshal(serverJSON)
+	if err != nil {
+		result.AddError("", CodeInvalidValue, fmt.Errorf("failed to encode server.json: %w", err))
+		return result
+	}
+	if serverJSON.Repository == (model.Repository{}) {
+		// The repository struct is always encoded, so drop it when it was left out
+		var doc map[string]json.RawMessage
+		if err := json.Unmarshal(data, &doc); err == nil {
+			delete(doc, "repository")
+			data, _ = json.Marshal(doc)
+		}
 	}
 
-	return nil
+	violations, err := schema.Validate(data)
+	if err != nil {
+		if !errors.Is(err, schema.ErrNoJSONSchema) {
+			result.AddError("", CodeInvalidValue, err)
+		}
+		return result
+	}
+	for _, violation := range violations {
+		result.AddError(violation.Path, CodeSchemaViolation, errors.New(violation.Message))
+	}
+	return result
 }
 
 // validateVersion validates the version string.
@@ -342,130 +352,210 @@ func validateArgumentValueFields(name, value, defaultValue string) error {
 	return nil
 }
 
-// collectAvailableVariables collects all available template variables from a package
-func collectAvailableVariables(pkg *model.Package) []string {
-	var variables []string
-
-	// Add environment variable names
-	for _, env := range pkg.EnvironmentVariables {
-		variables = append(variables, env.Name)
-	}
-
-	// Add runtime argument names and value hints
-	for _, arg := range pkg.RuntimeArguments {
-		if arg.Name != "" {
-			variables = append(variables, arg.Name)
-		}
-		if arg.ValueHint != "" {
-			variables = append(variables, arg.ValueHint)
-		}
-	}
-
-	// Add package argument names and value hints
-	for _, arg := range pkg.PackageArguments {
-		if arg.Name != "" {
-			variables = append(variables, arg.Name)
-		}
-		if arg.ValueHint != "" {
-			variables = append(variables, arg.ValueHint)
-		}
-	}
-
-	return variables
-}
-
 // validatePackageTransport validates a package's transport with templating support
-func validatePackageTransport(transport *model.Transport, availableVariables []string) error {
+func validatePackageTransport(result *ValidationResult, path string, transport *model.Transport, availableVariables []string) {
 	// Validate transport type is supported
 	switch transport.Type {
 	case model.TransportTypeStdio:
 		// Validate that URL is empty for stdio transport
 		if transport.URL != "" {
-			return fmt.Errorf("url must be empty for %s transport type, got: %s", transport.Type, transport.URL)
+			result.AddError(path+"/url", CodeInvalidValue, fmt.Errorf("invalid transport: url must be empty for %s transport type, got: %s", transport.Type, transport.URL))
 		}
-		return nil
 	case model.TransportTypeStreamableHTTP, model.TransportTypeSSE:
 		// URL is required for streamable-http and sse
 		if transport.URL == "" {
-			return fmt.Errorf("url is required for %s transport type", transport.Type)
+			result.AddError(path+"/url", CodeRequired, fmt.Errorf("invalid transport: url is required for %s transport type", transport.Type))
+			return
 		}
 		// Validate URL format with template variable support
 		if !IsValidTemplatedURL(transport.URL, availableVariables, true) {
 			// Check if it's a template variable issue or basic URL issue
 			templateVars := extractTemplateVariables(transport.URL)
 			if len(templateVars) > 0 {
-				return fmt.Errorf("%w: template variables in URL %s reference undefined variables. Available variables: %v",
-					ErrInvalidRemoteURL, transport.URL, availableVariables)
+				result.AddError(path+"/url", CodeInvalidValue, fmt.Errorf("invalid transport: %w: template variables in URL %s reference undefined variables. Available variables: %v",
+					ErrInvalidRemoteURL, transport.URL, availableVariables))
+				return
 			}
-			return fmt.Errorf("%w: %s", ErrInvalidRemoteURL, transport.URL)
+			result.AddError(path+"/url", CodeInvalidValue, fmt.Errorf("invalid transport: %w: %s", ErrInvalidRemoteURL, transport.URL))
 		}
-		return nil
 	default:
-		return fmt.Errorf("unsupported transport type: %s", transport.Type)
+		result.AddError(path+"/type", CodeUnsupportedValue, fmt.Errorf("invalid transport: unsupported transport type: %s", transport.Type))
 	}
 }
 
 // validateRemoteTransport validates a remote transport (no templating allowed)
-func validateRemoteTransport(obj *model.Transport) error {
+func validateRemoteTransport(result *ValidationResult, path string, obj *model.Transport) {
 	// Validate transport type is supported - remotes only support streamable-http and sse
 	switch obj.Type {
 	case model.TransportTypeStreamableHTTP, model.TransportTypeSSE:
 		// URL is required for streamable-http and sse
 		if obj.URL == "" {
-			return fmt.Errorf("url is required for %s transport type", obj.Type)
+			result.AddError(path+"/url", CodeRequired, fmt.Errorf("url is required for %s transport type", obj.Type))
+			return
 		}
 		// Validate URL format (no templates allowed for remotes, no localhost)
 		if !IsValidRemoteURL(obj.URL) {
-			return fmt.Errorf("%w: %s", ErrInvalidRemoteURL, obj.URL)
+			result.AddError(path+"/url", CodeInvalidValue, fmt.Errorf("%w: %s", ErrInvalidRemoteURL, obj.URL))
 		}
-		return nil
 	default:
-		return fmt.Errorf("unsupported transport type for remotes: %s (only streamable-http and sse are supported)", obj.Type)
+		result.AddError(path+"/type", CodeUnsupportedValue, fmt.Errorf("unsupported transport type for remotes: %s (only streamable-http and sse are supported)", obj.Type))
 	}
 }
 
-// ValidatePublishRequest validates a complete publish request including extensions
-func ValidatePublishRequest(ctx context.Context, req apiv0.ServerJSON, cfg *config.Config) error {
+// Options configures the publish request checks that depend on how the registry is run
+type Options struct {
+	// EnableRegistryValidation checks that each package exists and is owned by the publisher
+	EnableRegistryValidation bool
+	// OCIRequireDigest requires OCI package identifiers to be pinned to a digest
+	OCIRequireDigest bool
+	// UpstreamRegistries are package registries that packages may reference besides the public ones
+	UpstreamRegistries []registries.UpstreamRegistry
+}
+
+// ValidatePublishRequest validates a complete publish request including extensions.
+// If the request is invalid, the returned error is a *ValidationError listing every issue found.
+func ValidatePublishRequest(ctx context.Context, req apiv0.ServerJSON, opts Options) error {
+	_, err := ResolvePublishRequest(ctx, req, opts)
+	return err
+}
+
+// ResolvePublishRequest validates a publish request like ValidatePublishRequest, and also returns
+// the digests and platforms its OCI packages resolved to during registry validation
+func ResolvePublishRequest(ctx context.Context, req apiv0.ServerJSON, opts Options) ([]apiv0.OCIImage, error) {
+	// Validate against the JSON Schema before the rules below
+	result := CheckJSONSchema(&req)
+
 	// Validate publisher extensions in _meta
-	if err := validatePublisherExtensions(req); err != nil {
-		return err
-	}
+	validatePublisherExtensions(result, req)
 
 	// Validate the server detail (includes all nested validation)
-	if err := ValidateServerJSON(&req); err != nil {
-		return err
+	result.Merge(CheckServerJSON(&req))
+
+	if opts.OCIRequireDigest {
+		validateOCIDigestPinning(result, req)
 	}
 
 	// Validate registry ownership for all packages if validation is enabled
-	if cfg.EnableRegistryValidation {
-		for i, pkg := range req.Packages {
-			if err := ValidatePackage(ctx, pkg, req.Name); err != nil {
-				return fmt.Errorf("registry validation failed for package %d (%s): %w", i, pkg.Identifier, err)
-			}
+	var images []apiv0.OCIImage
+	if opts.EnableRegistryValidation {
+		images = ResolvePackages(ctx, result, req, opts.UpstreamRegistries)
+	}
+
+	if err := result.Err(); err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+// validateOCIDigestPinning checks that every OCI package identifier includes a digest
+func validateOCIDigestPinning(result *ValidationResult, req apiv0.ServerJSON) {
+	for i, pkg := range req.Packages {
+		if pkg.RegistryType != model.RegistryTypeOCI {
+			continue
+		}
+		ref, err := registries.ParseOCIReference(pkg.Identifier)
+		if err != nil || ref.Digest != "" {
+			// Unparseable references are reported by registry validation
+			continue
 		}
+		result.AddError(jsonPointer("packages", i, "identifier"), CodeInvalidValue, fmt.Errorf("%w: %s", ErrOCIDigestRequired, pkg.Identifier))
 	}
+}
 
-	return nil
+// CheckPublishRequest runs every request-level publish check and reports the outcome of each,
+// rather than returning a single error like ValidatePublishRequest
+func CheckPublishRequest(ctx context.Context, req apiv0.ServerJSON, opts Options) []apiv0.PublishCheck {
+	extensions := &ValidationResult{}
+	validatePublisherExtensions(extensions, req)
+
+	checks := publishChecksFromResult("json-schema", CheckJSONSchema(&req))
+	checks = append(checks, publishChecksFromResult("publisher-extensions", extensions)...)
+	checks = append(checks, publishChecksFromResult("server-json", CheckServerJSON(&req))...)
+
+	if opts.OCIRequireDigest {
+		pinning := &ValidationResult{}
+		validateOCIDigestPinning(pinning, req)
+		checks = append(checks, publishChecksFromResult("oci-digest", pinning)...)
+	}
+
+	for i, pkg := range req.Packages {
+		name := fmt.Sprintf("registry-ownership: packages[%d] (%s)", i, pkg.Identifier)
+		if !opts.EnableRegistryValidation {
+			checks = append(checks, apiv0.PublishCheck{Name: name, Status: apiv0.CheckStatusSkip, Message: "registry validation is disabled"})
+			continue
+		}
+		checks = append(checks, NewPublishCheck(name, ValidatePackage(ctx, pkg, req.Name, opts.UpstreamRegistries...)))
+	}
+
+	return checks
 }
 
-func validatePublisherExtensions(req apiv0.ServerJSON) error {
+// NewPublishCheck builds a passing check, or a failing one carrying the error message
+func NewPublishCheck(name string, err error) apiv0.PublishCheck {
+	if err != nil {
+		return apiv0.PublishCheck{Name: name, Status: apiv0.CheckStatusFail, Message: err.Error()}
+	}
+	return apiv0.PublishCheck{Name: name, Status: apiv0.CheckStatusPass}
+}
+
+// publishChecksFromResult reports a single passing check, or one failing check per issue named after its path
+func publishChecksFromResult(name string, result *ValidationResult) []apiv0.PublishCheck {
+	if len(result.Issues) == 0 {
+		return []apiv0.PublishCheck{NewPublishCheck(name, nil)}
+	}
+
+	checks := make([]apiv0.PublishCheck, 0, len(result.Issues))
+	for _, issue := range result.Issues {
+		checks = append(checks, apiv0.PublishCheck{
+			Name:    name + ": " + issue.Path,
+			Status:  apiv0.CheckStatusFail,
+			Message: issue.Message,
+		})
+	}
+	return checks
+}
+
+func validatePublisherExtensions(result *ValidationResult, req apiv0.ServerJSON) {
 	const maxExtensionSize = 4 * 1024 // 4KB limit
 
+	if req.Meta == nil {
+		return
+	}
+
 	// Check size limit for _meta publisher-provided extension
-	if req.Meta != nil && req.Meta.PublisherProvided != nil {
+	if req.Meta.PublisherProvided != nil {
+		path := jsonPointer("_meta", "io.modelcontextprotocol.registry/publisher-provided")
 		extensionsJSON, err := json.Marshal(req.Meta.PublisherProvided)
 		if err != nil {
-			return fmt.Errorf("failed to marshal _meta.io.modelcontextprotocol.registry/publisher-provided extension: %w", err)
-		}
-		if len(extensionsJSON) > maxExtensionSize {
-			return fmt.Errorf("_meta.io.modelcontextprotocol.registry/publisher-provided extension exceeds 4KB limit (%d bytes)", len(extensionsJSON))
+			result.AddError(path, CodeInvalidValue, fmt.Errorf("failed to marshal _meta.io.modelcontextprotocol.registry/publisher-provided extension: %w", err))
+		} else if len(extensionsJSON) > maxExtensionSize {
+			result.AddError(path, CodeTooLarge, fmt.Errorf("_meta.io.modelcontextprotocol.registry/publisher-provided extension exceeds 4KB limit (%d bytes)", len(extensionsJSON)))
 		}
 	}
 
-	// Note: ServerJSON._meta only contains PublisherProvided data
-	// Official registry metadata is handled separately in the response structure
+	// Check declared capabilities are well-formed
+	if req.Meta.Capabilities != nil {
+		validateDeclaredCapabilities(result, jsonPointer("_meta", "io.modelcontextprotocol.registry/capabilities"), req.Meta.Capabilities)
+	}
 
-	return nil
+	// Note: Official registry metadata is handled separately in the response structure
+}
+
+func validateDeclaredCapabilities(result *ValidationResult, path string, caps *apiv0.Capabilities) {
+	if caps.Source != "" && caps.Source != apiv0.CapabilitiesSourceDeclared {
+		result.AddError(path+"/source", CodeInvalidValue, fmt.Errorf("_meta.io.modelcontextprotocol.registry/capabilities source must be '%s' when set by the publisher", apiv0.CapabilitiesSourceDeclared))
+	}
+
+	for _, kind := range []struct {
+		name  string
+		items []apiv0.Capability
+	}{{"tools", caps.Tools}, {"prompts", caps.Prompts}, {"resources", caps.Resources}} {
+		for i, item := range kind.items {
+			if strings.TrimSpace(item.Name) == "" {
+				result.AddError(path+jsonPointer(kind.name, i, "name"), CodeRequired, fmt.Errorf("_meta.io.modelcontextprotocol.registry/capabilities %s[%d] is missing a name", kind.name, i))
+			}
+		}
+	}
 }
 
 func parseServerName(serverJSON apiv0.ServerJSON) (string, error) {
@@ -510,14 +600,10 @@ func parseServerName(serverJSON apiv0.ServerJSON) (string, error) {
 	return name, nil
 }
 
-// validateRemoteNamespaceMatch validates that remote URLs match the reverse-DNS namespace
-func validateRemoteNamespaceMatch(serverJSON apiv0.ServerJSON) error {
-	namespace := serverJSON.Name
-
-	for _, remote := range serverJSON.Remotes {
-		if err := validateRemoteURLMatchesNamespace(remote.URL, namespace); err != nil {
-			return fmt.Errorf("remote URL %s does not match namespace %s: %w", remote.URL, namespace, err)
-		}
+// validateRemoteNamespaceMatch validates that a remote URL matches the reverse-DNS namespace
+func validateRemoteNamespaceMatch(remoteURL, namespace string) error {
+	if err := validateRemoteURLMatchesNamespace(remoteURL, namespace); err != nil {
+		return fmt.Errorf("remote URL %s does not match namespace %s: %w", remoteURL, namespace, err)
 	}
 
 	return nil
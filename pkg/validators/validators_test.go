@@ -6,11 +6,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
-	"github.com/modelcontextprotocol/registry/internal/config"
-	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validators"
 )
 
 func TestValidate(t *testing.T) {
@@ -1619,8 +1619,10 @@ func TestValidate_RegistryTypesAndUrls(t *testing.T) {
 		{"valid_oci", "io.github.domdomegg/airtable-mcp-server", model.RegistryTypeOCI, "", "domdomegg/airtable-mcp-server:1.7.2", "", "", false},
 		{"valid_nuget", "io.github.domdomegg/time-mcp-server", model.RegistryTypeNuGet, model.RegistryURLNuGet, "TimeMcpServer", "1.0.2", "", false},
 		{"valid_nuget", "io.github.domdomegg/time-mcp-server", model.RegistryTypeNuGet, "", "TimeMcpServer", "1.0.2", "", false},
-		{"valid_mcpb_github", "io.github.domdomegg/airtable-mcp-server", model.RegistryTypeMCPB, "", "https://github.com/domdomegg/airtable-mcp-server/releases/download/v1.7.2/airtable-mcp-server.mcpb", "", "fe333e598595000ae021bd27117db32ec69af6987f507ba7a63c90638ff633ce", false},
-		{"valid_mcpb_gitlab", "io.gitlab.fforster/gitlab-mcp", model.RegistryTypeMCPB, "", "https://gitlab.com/fforster/gitlab-mcp/-/releases/v1.31.0/downloads/gitlab-mcp_1.31.0_Linux_x86_64.tar.gz", "", "abc123ef4567890abcdef1234567890abcdef1234567890abcdef1234567890", false}, // this is not actually a valid mcpb, but it's the closest I can get for testing for now
+		{"valid_mcpb_github", "io.github.domdomegg/airtable-mcp-server", model.RegistryTypeMCPB, "", "https://github.com/domdomegg/airtable-mcp-server/releases/download/v1.7.2/airtable-mcp-server.mcpb", "", "8220de07a08ebe908f04da139ea03dbfe29758141347e945da60535fb7bcca20", false},
+
+		// Test MCPB whose file hash does not match the downloaded file (should fail)
+		{"invalid_mcpb_hash_mismatch", "io.gitlab.fforster/gitlab-mcp", model.RegistryTypeMCPB, "", "https://gitlab.com/fforster/gitlab-mcp/-/releases/v1.31.0/downloads/gitlab-mcp_1.31.0_Linux_x86_64.tar.gz", "", "abc123ef4567890abcdef1234567890abcdef1234567890abcdef1234567890", true},
 
 		// Test MCPB without file hash (should fail)
 		{"invalid_mcpb_no_hash", "io.github.domdomegg/airtable-mcp-server", model.RegistryTypeMCPB, "", "https://github.com/domdomegg/airtable-mcp-server/releases/download/v1.7.2/airtable-mcp-server.mcpb", "", "", true},
@@ -1665,7 +1667,7 @@ func TestValidate_RegistryTypesAndUrls(t *testing.T) {
 				},
 			}
 
-			err := validators.ValidatePublishRequest(context.Background(), serverJSON, &config.Config{
+			err := validators.ValidatePublishRequest(context.Background(), serverJSON, validators.Options{
 				EnableRegistryValidation: true,
 			})
 			if tc.expectError {
@@ -1677,6 +1679,200 @@ func TestValidate_RegistryTypesAndUrls(t *testing.T) {
 	}
 }
 
+func TestValidatePublishRequest_DeclaredCapabilities(t *testing.T) {
+	tests := []struct {
+		name          string
+		capabilities  *apiv0.Capabilities
+		expectedError string
+	}{
+		{
+			name: "valid declared capabilities",
+			capabilities: &apiv0.Capabilities{
+				Tools:     []apiv0.Capability{{Name: "create_issue", Description: "Create an issue"}},
+				Resources: []apiv0.Capability{{Name: "app.log", URI: "file:///logs/app.log"}},
+			},
+		},
+		{
+			name: "tool without a name",
+			capabilities: &apiv0.Capabilities{
+				Tools: []apiv0.Capability{{Description: "Missing name"}},
+			},
+			expectedError: "tools[0] is missing a name",
+		},
+		{
+			name: "publisher cannot claim discovered source",
+			capabilities: &apiv0.Capabilities{
+				Source: apiv0.CapabilitiesSourceDiscovered,
+				Tools:  []apiv0.Capability{{Name: "create_issue"}},
+			},
+			expectedError: "source must be 'declared'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverJSON := apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "com.example/test-server",
+				Description: "A test server",
+				Version:     "1.0.0",
+				Meta:        &apiv0.ServerMeta{Capabilities: tt.capabilities},
+			}
+
+			err := validators.ValidatePublishRequest(context.Background(), serverJSON, validators.Options{})
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestCheckServerJSON_CollectsEveryIssue(t *testing.T) {
+	serverJSON := apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/test-server",
+		Description: "A test server",
+		Version:     "^1.0.0",
+		Repository: model.Repository{
+			URL:    "not-a-url",
+			Source: "github",
+		},
+		Packages: []model.Package{
+			{
+				Identifier:   "valid-package",
+				RegistryType: model.RegistryTypeNPM,
+				Version:      "1.0.0",
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+			},
+			{
+				Identifier:   "package with spaces",
+				RegistryType: model.RegistryTypeNPM,
+				Version:      "1.0.0",
+				Transport: model.Transport{
+					Type: model.TransportTypeStreamableHTTP,
+					URL:  "https://{undefined}.example.com/mcp",
+				},
+			},
+		},
+		Remotes: []model.Transport{
+			{Type: model.TransportTypeStreamableHTTP, URL: "https://other.org/mcp"},
+		},
+	}
+
+	result := validators.CheckServerJSON(&serverJSON)
+	require.False(t, result.Valid())
+
+	type issueKey struct{ path, code string }
+	var got []issueKey
+	for _, issue := range result.Issues {
+		assert.Equal(t, validators.SeverityError, issue.Severity)
+		assert.NotEmpty(t, issue.Message)
+		got = append(got, issueKey{issue.Path, issue.Code})
+	}
+	assert.Equal(t, []issueKey{
+		{"/version", "version_range"},
+		{"/repository/url", "invalid_repository_url"},
+		{"/packages/1/identifier", "package_name_has_spaces"},
+		{"/packages/1/transport/url", "invalid_remote_url"},
+		{"/remotes/0/url", validators.CodeNamespaceMatch},
+	}, got)
+
+	// The result still behaves as a regular error for callers that only need the first problem
+	err := validators.ValidateServerJSON(&serverJSON)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, validators.ErrInvalidRepositoryURL)
+	assert.ErrorIs(t, err, validators.ErrVersionLooksLikeRange)
+	assert.Contains(t, err.Error(), "/packages/1/transport/url: invalid transport")
+}
+
+func TestValidatePublishRequest_JSONSchema(t *testing.T) {
+	serverJSON := apiv0.ServerJSON{
+		Schema:  model.CurrentSchemaURL,
+		Name:    "com.example/test-server",
+		Version: "1.0.0",
+		Packages: []model.Package{{
+			Identifier:   "@example/test-server",
+			RegistryType: model.RegistryTypeNPM,
+			Version:      "1.0.0",
+			Transport:    model.Transport{Type: model.TransportTypeStdio},
+			PackageArguments: []model.Argument{
+				{Type: model.ArgumentTypePositional, Name: "path"},
+			},
+		}},
+	}
+
+	// The Go rules accept a missing description and a positional argument without value or valueHint,
+	// but the JSON Schema does not
+	require.NoError(t, validators.ValidateServerJSON(&serverJSON))
+
+	err := validators.ValidatePublishRequest(context.Background(), serverJSON, validators.Options{})
+	var validationErr *validators.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	var paths []string
+	for _, issue := range validationErr.Issues {
+		assert.Equal(t, validators.CodeSchemaViolation, issue.Code)
+		paths = append(paths, issue.Path)
+	}
+	assert.Equal(t, []string{"/description", "/packages/0/packageArguments/0"}, paths)
+}
+
+func TestValidatePublishRequest_MetaPaths(t *testing.T) {
+	serverJSON := apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/test-server",
+		Description: "A test server",
+		Version:     "1.0.0",
+		Meta: &apiv0.ServerMeta{
+			Capabilities: &apiv0.Capabilities{
+				Tools: []apiv0.Capability{{Name: "ok"}, {Name: " "}},
+			},
+		},
+	}
+
+	err := validators.ValidatePublishRequest(context.Background(), serverJSON, validators.Options{})
+	var validationErr *validators.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, validationErr.Issues, 1)
+	assert.Equal(t, "/_meta/io.modelcontextprotocol.registry~1capabilities/tools/1/name", validationErr.Issues[0].Path)
+	assert.Equal(t, validators.CodeRequired, validationErr.Issues[0].Code)
+}
+
+func TestValidatePublishRequest_OCIRequireDigest(t *testing.T) {
+	serverJSON := apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.domdomegg/airtable-mcp-server",
+		Description: "A test server",
+		Version:     "1.0.0",
+		Packages: []model.Package{
+			{
+				RegistryType: model.RegistryTypeOCI,
+				Identifier:   "docker.io/domdomegg/airtable-mcp-server:1.7.2@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+			},
+			{
+				RegistryType: model.RegistryTypeOCI,
+				Identifier:   "docker.io/domdomegg/airtable-mcp-server:1.7.2",
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+			},
+		},
+	}
+
+	// Tags are accepted unless the registry requires digests
+	require.NoError(t, validators.ValidatePublishRequest(context.Background(), serverJSON, validators.Options{}))
+
+	err := validators.ValidatePublishRequest(context.Background(), serverJSON, validators.Options{OCIRequireDigest: true})
+	require.ErrorIs(t, err, validators.ErrOCIDigestRequired)
+
+	var validationErr *validators.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, validationErr.Issues, 1)
+	assert.Equal(t, "/packages/1/identifier", validationErr.Issues[0].Path)
+	assert.Equal(t, "oci_digest_required", validationErr.Issues[0].Code)
+}
+
 func createValidServerWithArgument(arg model.Argument) apiv0.ServerJSON {
 	return apiv0.ServerJSON{
 		Schema:      model.CurrentSchemaURL,
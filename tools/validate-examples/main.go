@@ -12,8 +12,8 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/validators"
 	jsonschema "github.com/santhosh-tekuri/jsonschema/v5"
 )
 